@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	nethttp "net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,10 +12,31 @@ import (
 
 	"haphap/swimo-api/config"
 	"haphap/swimo-api/database"
+	"haphap/swimo-api/internal/app/admin"
+	adminHttp "haphap/swimo-api/internal/app/admin/delivery/http"
+	"haphap/swimo-api/internal/app/appconfig"
 	"haphap/swimo-api/internal/app/auth"
 	"haphap/swimo-api/internal/app/auth/delivery/http"
+	"haphap/swimo-api/internal/app/pace"
+	paceHttp "haphap/swimo-api/internal/app/pace/delivery/http"
+	"haphap/swimo-api/internal/app/plan"
+	planHttp "haphap/swimo-api/internal/app/plan/delivery/http"
+	"haphap/swimo-api/internal/app/profile"
+	profileHttp "haphap/swimo-api/internal/app/profile/delivery/http"
+	"haphap/swimo-api/internal/app/program"
+	programHttp "haphap/swimo-api/internal/app/program/delivery/http"
+	"haphap/swimo-api/internal/app/tutorial"
+	tutorialHttp "haphap/swimo-api/internal/app/tutorial/delivery/http"
+	"haphap/swimo-api/internal/app/workout"
+	workoutHttp "haphap/swimo-api/internal/app/workout/delivery/http"
+	"haphap/swimo-api/internal/middleware"
 	"haphap/swimo-api/internal/server"
+	"haphap/swimo-api/pkg/energy"
 	"haphap/swimo-api/pkg/logging"
+	"haphap/swimo-api/pkg/mailer"
+	"haphap/swimo-api/pkg/oidc"
+	"haphap/swimo-api/pkg/security"
+	"haphap/swimo-api/pkg/storage"
 )
 
 func main() {
@@ -33,20 +56,101 @@ func main() {
 	}
 	defer db.Close()
 
+	if cfg.Database.AutoMigrate {
+		migrator, err := database.NewMigrator(db.Pool)
+		if err == nil {
+			_, err = migrator.Up(ctx)
+		}
+		if err != nil {
+			slog.Error("database migrate failed", slog.String("err", err.Error()))
+			os.Exit(1)
+		}
+	}
+
 	// server
 	srv := server.NewServer(cfg)
 
 	// repositories
+	appConfigRepo := appconfig.NewAppConfigRepository(db.Pool)
 	authRepo := auth.NewAuthRepository(db.Pool)
+	adminRepo := admin.NewAdminRepository(db.Pool)
+	profileRepo := profile.NewProfileRepository(db.Pool)
+	workoutRepo := workout.NewWorkoutRepository(db.Pool)
+	planRepo := plan.NewPlanRepository(db.Pool)
+	programRepo := program.NewProgramRepository(db.Pool)
+	tutorialRepo := tutorial.NewTutorialRepository(db.Pool)
+	paceRepo := pace.NewPaceRepository(db.Pool)
+
+	// runtime config (app_config table)
+	runtimeCfg := appconfig.NewProvider(db.Pool, appConfigRepo, cfg.App.RuntimeRefresh)
+	if err := runtimeCfg.Reload(ctx); err != nil {
+		slog.Error("app config load failed", slog.String("err", err.Error()))
+		os.Exit(1)
+	}
+
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	defer stopWatch()
+	go runtimeCfg.Watch(watchCtx)
+
+	// mailer
+	mail := newMailer(cfg)
+
+	// file storage
+	store, err := newStorage(cfg)
+	if err != nil {
+		slog.Error("storage init failed", slog.String("err", err.Error()))
+		os.Exit(1)
+	}
+
+	// calorie estimates
+	metTable, err := energy.Load(cfg.Energy.METTableFile)
+	if err != nil {
+		slog.Error("met table load failed", slog.String("err", err.Error()))
+		os.Exit(1)
+	}
+
+	// jwt signing keys
+	keys, err := newKeySet(cfg)
+	if err != nil {
+		slog.Error("jwt keys load failed", slog.String("err", err.Error()))
+		os.Exit(1)
+	}
 
 	// usecases
-	authUsecase := auth.NewAuthUseCase(cfg, db.Pool, authRepo)
+	tutorialUsecase := tutorial.NewTutorialUseCase(cfg.Tutorial, tutorialRepo, store)
+	authUsecase := auth.NewAuthUseCase(cfg, db.Pool, authRepo, runtimeCfg, mail, keys, newIdentityProviders(cfg), tutorialUsecase)
+	adminUsecase := admin.NewAdminUseCase(db.Pool, adminRepo, appConfigRepo, runtimeCfg)
+	workoutUsecase := workout.NewWorkoutUseCase(db.Pool, workoutRepo, metTable)
+	planUsecase := plan.NewPlanUseCase(planRepo)
+	programUsecase := program.NewProgramUseCase(db.Pool, programRepo)
+	paceUsecase := pace.NewPaceUseCase(db.Pool, paceRepo)
+	profileUsecase := profile.NewProfileUseCase(profileRepo, authUsecase, workoutUsecase, planUsecase, programUsecase, tutorialUsecase, paceUsecase)
+
+	// purge accounts past their deletion grace period
+	go auth.NewAccountPurger(db.Pool, authRepo, cfg.Auth.PurgeInterval, programUsecase).Run(watchCtx)
+
+	// middlewares
+	authMiddleware := middleware.NewAuthMiddleware(keys, authRepo)
 
 	// handlers
 	authHandler := http.NewAuthHandler(authUsecase)
+	adminHandler := adminHttp.NewAdminHandler(adminUsecase)
+	profileHandler := profileHttp.NewProfileHandler(profileUsecase)
+	workoutHandler := workoutHttp.NewWorkoutHandler(workoutUsecase)
+	planHandler := planHttp.NewPlanHandler(planUsecase)
+	programHandler := programHttp.NewProgramHandler(programUsecase)
+	tutorialHandler := tutorialHttp.NewTutorialHandler(tutorialUsecase, cfg.Tutorial)
+	paceHandler := paceHttp.NewPaceHandler(paceUsecase)
 
 	// routes
-	http.Register(srv.App, authHandler)
+	http.Register(srv.App, authHandler, authMiddleware)
+	adminHttp.Register(srv.App, adminHandler, authMiddleware)
+	profileHttp.Register(srv.App, profileHandler, authMiddleware)
+	workoutHttp.Register(srv.App, workoutHandler, authMiddleware, adminRepo)
+	planHttp.Register(srv.App, planHandler, authMiddleware)
+	programHttp.Register(srv.App, programHandler, authMiddleware)
+	tutorialHttp.Register(srv.App, tutorialHandler, authMiddleware)
+	paceHttp.Register(srv.App, paceHandler, authMiddleware)
 
 	// run + graceful shutdown
 	errCh := make(chan error, 1)
@@ -77,3 +181,124 @@ func getenv(k, def string) string {
 	}
 	return def
 }
+
+func newMailer(cfg *config.Config) mailer.Mailer {
+	switch cfg.Mail.Driver {
+	case "", "log":
+		return mailer.NewLogMailer(cfg.Mail.From)
+	case "file":
+		dir := cfg.Mail.Dir
+		if dir == "" {
+			dir = "tmp/mails"
+		}
+
+		m, err := mailer.NewFileMailer(cfg.Mail.From, dir)
+		if err != nil {
+			slog.Error("file mailer init failed, falling back to log", slog.String("err", err.Error()))
+			return mailer.NewLogMailer(cfg.Mail.From)
+		}
+		return m
+	default:
+		slog.Warn("unknown mail driver, falling back to log", slog.String("driver", cfg.Mail.Driver))
+		return mailer.NewLogMailer(cfg.Mail.From)
+	}
+}
+
+func newStorage(cfg *config.Config) (storage.Storage, error) {
+	switch cfg.Storage.Driver {
+	case "", "local":
+		dir := cfg.Storage.Dir
+		if dir == "" {
+			dir = "tmp/storage"
+		}
+		return storage.NewLocalStorage(dir)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+	}
+}
+
+func newIdentityProviders(cfg *config.Config) map[string]auth.IdentityProvider {
+	httpClient := &nethttp.Client{Timeout: 10 * time.Second}
+
+	providers := make(map[string]auth.IdentityProvider, len(cfg.OIDC.Providers))
+	for _, p := range cfg.OIDC.Providers {
+		if p.Issuer == "" || p.ClientID == "" || p.RedirectURL == "" {
+			slog.Warn("oidc provider misconfigured, skipping", slog.String("provider", p.Name))
+			continue
+		}
+
+		providers[p.Name] = oidc.NewClient(oidc.ProviderConfig{
+			Name:         p.Name,
+			Issuer:       p.Issuer,
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  p.RedirectURL,
+			Scopes:       p.Scopes,
+		}, httpClient)
+	}
+
+	return providers
+}
+
+// newKeySet loads JWT_SECRET as the legacy HS256 key plus every asymmetric key of JWT_KEYS.
+func newKeySet(cfg *config.Config) (*security.KeySet, error) {
+	var keys []*security.SigningKey
+
+	if cfg.Auth.JWTSecret != "" {
+		key, err := security.NewHMACKey(security.LegacyKeyID, []byte(cfg.Auth.JWTSecret))
+		if err != nil {
+			return nil, err
+		}
+
+		if key.RetiredAt, err = parseRetiredAt(security.LegacyKeyID, cfg.Auth.JWTSecretRetiredAt); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	for _, kc := range cfg.Auth.SigningKeys {
+		data, err := os.ReadFile(kc.File)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", kc.ID, err)
+		}
+
+		key, err := security.ParsePrivateKeyPEM(kc.ID, data)
+		if err != nil {
+			return nil, err
+		}
+
+		if key.RetiredAt, err = parseRetiredAt(kc.ID, kc.RetiredAt); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	activeID := cfg.Auth.ActiveKeyID
+	if activeID == "" {
+		activeID = security.LegacyKeyID
+		if len(cfg.Auth.SigningKeys) > 0 {
+			activeID = cfg.Auth.SigningKeys[0].ID
+		}
+	}
+
+	// Retired keys must outlive every token they signed
+	grace := max(cfg.Auth.JWTAccessTTL, cfg.Auth.MFAChallengeTTL, auth.ReauthTokenTTL)
+
+	return security.NewKeySet(activeID, grace, keys...)
+}
+
+// parseRetiredAt reads the RFC3339 retirement time of a signing key, nil while the key is in use.
+func parseRetiredAt(keyID, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	retiredAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("key %q: retired at: %w", keyID, err)
+	}
+
+	return &retiredAt, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"haphap/swimo-api/config"
+	"haphap/swimo-api/database"
+	"haphap/swimo-api/pkg/logging"
+)
+
+const usage = `usage: migrate <command>
+
+commands:
+  up             apply all pending migrations
+  down [N]       revert the last N migrations (default 1)
+  status         list migrations and whether they are applied
+  force VERSION  mark migrations up to VERSION as applied without running them (0 = none)`
+
+func main() {
+	_, cleanup, _ := logging.Init("swimo-migrate", getenv("LOG_LEVEL", "info"), getenv("LOG_FORMAT", "text"), "", false)
+	defer cleanup()
+
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err := run(os.Args[1], os.Args[2:]); err != nil {
+		slog.Error("migrate failed", slog.String("cmd", os.Args[1]), slog.String("err", err.Error()))
+		cleanup()
+		os.Exit(1)
+	}
+}
+
+func run(cmd string, args []string) error {
+	cfg := config.Parse()
+
+	ctx := context.Background()
+	db, err := database.Connect(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(db.Pool)
+	if err != nil {
+		return err
+	}
+
+	switch cmd {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		slog.Info("migrate up done", slog.Int("applied", applied))
+
+	case "down":
+		n := 1
+		if len(args) > 0 {
+			if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+				return fmt.Errorf("down: N must be a positive number")
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, n)
+		if err != nil {
+			return err
+		}
+		slog.Info("migrate down done", slog.Int("reverted", reverted))
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+
+	case "force":
+		if len(args) < 1 {
+			return fmt.Errorf("force: VERSION is required")
+		}
+		version, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("force: invalid VERSION %q", args[0])
+		}
+
+		if err := migrator.Force(ctx, version); err != nil {
+			return err
+		}
+		slog.Info("migrate force done", slog.Int64("version", version))
+
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+
+	return nil
+}
+
+func getenv(k, def string) string {
+	if v := os.Getenv(k); v != "" {
+		return v
+	}
+	return def
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,11 +17,17 @@ type (
 		CORS      CORSConfig
 		RateLimit RateLimitConfig
 		Auth      AuthConfig
+		Mail      MailConfig
+		OIDC      OIDCConfig
+		Storage   StorageConfig
+		Tutorial  TutorialConfig
+		Energy    EnergyConfig
 	}
 
 	AppConfig struct {
-		Name string
-		Env  string // dev|staging|prod
+		Name           string
+		Env            string        // dev|staging|prod
+		RuntimeRefresh time.Duration // reload interval of the app_config table
 	}
 
 	LogConfig struct {
@@ -43,6 +50,7 @@ type (
 		MaxConnLifetime time.Duration
 		MaxConnIdleTime time.Duration
 		HealthTimeout   time.Duration
+		AutoMigrate     bool // apply pending migrations on boot
 	}
 
 	HTTPConfig struct {
@@ -54,6 +62,7 @@ type (
 		IdleTimeout    time.Duration
 		BodyLimitBytes int
 		EnableETag     bool
+		MediaTimeout   time.Duration // read and write timeout of media routes, uploads and videos outlive the defaults
 	}
 
 	CORSConfig struct {
@@ -72,11 +81,73 @@ type (
 	}
 
 	AuthConfig struct {
-		GuestEnabled       bool
+		GuestEnabled       bool // master switch, app_config.guest_sign_in_enabled toggles it at runtime
 		GuestRatePerMinute int
-		JWTSecret          string        // minimal 32 chars
+		JWTSecret          string // minimal 32 chars, HS256 key with kid "default"
+		JWTSecretRetiredAt string // RFC3339, set once JWT_SECRET no longer signs
+		SigningKeys        []SigningKeyConfig
+		ActiveKeyID        string        // kid used to sign new tokens
 		JWTAccessTTL       time.Duration // ex: 15m
 		JWTRefreshTTL      time.Duration // ex: 720h (30d)
+		PasswordResetTTL   time.Duration // ex: 30m
+		EmailVerifyTTL     time.Duration // ex: 48h
+		RequireVerified    bool          // reject sign-in until the email is verified
+		LockoutThreshold   int           // failures per account before a temporary lockout
+		IPLockoutThreshold int           // failures per ip before a temporary lockout
+		LockoutBase        time.Duration // first lockout, doubled for every further failure
+		LockoutMax         time.Duration
+		FailureWindow      time.Duration // counters reset after this long without failures
+		MFAKey             string        // 32 bytes hex/base64, encrypts TOTP secrets at rest
+		MFAIssuer          string        // issuer shown in authenticator apps
+		MFAChallengeTTL    time.Duration // ex: 5m
+		DeletionGrace      time.Duration // delay before a deleted account is purged, signing in cancels
+		PurgeInterval      time.Duration // how often scheduled deletions are purged
+	}
+
+	// SigningKeyConfig is an asymmetric JWT key, the algorithm follows the key type (RSA or Ed25519).
+	SigningKeyConfig struct {
+		ID        string // kid
+		File      string // PEM encoded private key
+		RetiredAt string // RFC3339, set when the key was rotated out
+	}
+
+	OIDCConfig struct {
+		Providers []OIDCProviderConfig
+		StateTTL  time.Duration // lifetime of a pending authorization request
+	}
+
+	OIDCProviderConfig struct {
+		Name         string // path segment, ex: google
+		Issuer       string
+		ClientID     string
+		ClientSecret string
+		RedirectURL  string
+		Scopes       []string
+	}
+
+	MailConfig struct {
+		Driver string // log|file
+		From   string
+		AppURL string // base url used to build links in emails
+		Dir    string // output directory of the file driver
+	}
+
+	StorageConfig struct {
+		Driver string // local
+		Dir    string // root directory of the local driver
+	}
+
+	// TutorialConfig limits uploads on their own, HTTPConfig.BodyLimitBytes does not apply to media routes.
+	TutorialConfig struct {
+		VideoMaxBytes     int64
+		VideoTypes        []string // sniffed MIME types accepted for videos
+		ThumbnailMaxBytes int64
+		ThumbnailTypes    []string
+		MediaMaxAge       time.Duration // Cache-Control max-age of published media
+	}
+
+	EnergyConfig struct {
+		METTableFile string // JSON MET table replacing the embedded one, see pkg/energy/met.json
 	}
 )
 
@@ -88,10 +159,71 @@ func atoiDef(s string, def int) int {
 	return n
 }
 
+func listDef(s string, def []string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	if len(out) == 0 {
+		return def
+	}
+	return out
+}
+
+// parseSigningKeys reads JWT_KEYS=2026-01,2025-07 and JWT_KEY_<ID>_* for each entry.
+func parseSigningKeys() []SigningKeyConfig {
+	var keys []SigningKeyConfig
+	for _, id := range strings.Split(os.Getenv("JWT_KEYS"), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+
+		prefix := "JWT_KEY_" + strings.ToUpper(strings.ReplaceAll(id, "-", "_")) + "_"
+		keys = append(keys, SigningKeyConfig{
+			ID:        id,
+			File:      os.Getenv(prefix + "FILE"),
+			RetiredAt: os.Getenv(prefix + "RETIRED_AT"),
+		})
+	}
+
+	return keys
+}
+
+// parseOIDCProviders reads OIDC_PROVIDERS=google,apple and OIDC_<NAME>_* for each entry.
+func parseOIDCProviders() []OIDCProviderConfig {
+	var providers []OIDCProviderConfig
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		provider := OIDCProviderConfig{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+		}
+		if scopes := os.Getenv(prefix + "SCOPES"); scopes != "" {
+			provider.Scopes = strings.Fields(strings.ReplaceAll(scopes, ",", " "))
+		}
+
+		providers = append(providers, provider)
+	}
+
+	return providers
+}
+
 func Parse() *Config {
 	app := AppConfig{
-		Name: os.Getenv("APP_NAME"),
-		Env:  os.Getenv("APP_ENV"),
+		Name:           os.Getenv("APP_NAME"),
+		Env:            os.Getenv("APP_ENV"),
+		RuntimeRefresh: time.Duration(max(atoiDef(os.Getenv("APP_CONFIG_REFRESH_SEC"), 30), 1)) * time.Second, // 0 would reload in a tight loop
 	}
 
 	log := LogConfig{
@@ -114,6 +246,7 @@ func Parse() *Config {
 		MaxConnLifetime: time.Duration(atoiDef(os.Getenv("DB_MAX_CONN_LIFETIME_SEC"), 3600)) * time.Second,
 		MaxConnIdleTime: time.Duration(atoiDef(os.Getenv("DB_MAX_CONN_IDLE_SEC"), 300)) * time.Second,
 		HealthTimeout:   time.Duration(atoiDef(os.Getenv("DB_HEALTH_TIMEOUT_MS"), 1500)) * time.Millisecond,
+		AutoMigrate:     os.Getenv("DB_AUTO_MIGRATE") == "true",
 	}
 	if database.URL == "" {
 		database.URL = fmt.Sprintf(
@@ -131,6 +264,7 @@ func Parse() *Config {
 		IdleTimeout:    time.Duration(atoiDef(os.Getenv("HTTP_IDLE_TIMEOUT_MS"), 60000)) * time.Millisecond,
 		BodyLimitBytes: atoiDef(os.Getenv("HTTP_BODY_LIMIT_BYTES"), 10<<20), // 10MB
 		EnableETag:     os.Getenv("HTTP_ETAG") == "true",
+		MediaTimeout:   time.Duration(atoiDef(os.Getenv("HTTP_MEDIA_TIMEOUT_MIN"), 30)) * time.Minute,
 	}
 
 	cors := CORSConfig{
@@ -152,8 +286,53 @@ func Parse() *Config {
 		GuestEnabled:       os.Getenv("GUEST_ENABLED") == "true",
 		GuestRatePerMinute: atoiDef(os.Getenv("GUEST_SIGNIN_RATE_PER_MIN"), 10),
 		JWTSecret:          os.Getenv("JWT_SECRET"),
+		JWTSecretRetiredAt: os.Getenv("JWT_SECRET_RETIRED_AT"),
+		SigningKeys:        parseSigningKeys(),
+		ActiveKeyID:        os.Getenv("JWT_ACTIVE_KEY"),
 		JWTAccessTTL:       time.Duration(atoiDef(os.Getenv("JWT_ACCESS_TTL_MIN"), 15)) * time.Minute,
 		JWTRefreshTTL:      time.Duration(atoiDef(os.Getenv("JWT_REFRESH_TTL_HOURS"), 720)) * time.Hour,
+		PasswordResetTTL:   time.Duration(atoiDef(os.Getenv("PASSWORD_RESET_TTL_MIN"), 30)) * time.Minute,
+		EmailVerifyTTL:     time.Duration(atoiDef(os.Getenv("EMAIL_VERIFY_TTL_HOURS"), 48)) * time.Hour,
+		RequireVerified:    os.Getenv("AUTH_REQUIRE_VERIFIED_EMAIL") == "true",
+		LockoutThreshold:   atoiDef(os.Getenv("AUTH_LOCKOUT_THRESHOLD"), 5),
+		IPLockoutThreshold: atoiDef(os.Getenv("AUTH_IP_LOCKOUT_THRESHOLD"), 20),
+		LockoutBase:        time.Duration(atoiDef(os.Getenv("AUTH_LOCKOUT_BASE_SEC"), 30)) * time.Second,
+		LockoutMax:         time.Duration(atoiDef(os.Getenv("AUTH_LOCKOUT_MAX_SEC"), 3600)) * time.Second,
+		FailureWindow:      time.Duration(atoiDef(os.Getenv("AUTH_FAILURE_WINDOW_MIN"), 1440)) * time.Minute,
+		MFAKey:             os.Getenv("MFA_ENCRYPTION_KEY"),
+		MFAIssuer:          os.Getenv("MFA_ISSUER"),
+		MFAChallengeTTL:    time.Duration(atoiDef(os.Getenv("MFA_CHALLENGE_TTL_MIN"), 5)) * time.Minute,
+		DeletionGrace:      time.Duration(atoiDef(os.Getenv("ACCOUNT_DELETION_GRACE_DAYS"), 14)) * 24 * time.Hour,
+		PurgeInterval:      time.Duration(atoiDef(os.Getenv("ACCOUNT_PURGE_INTERVAL_MIN"), 60)) * time.Minute,
+	}
+
+	mail := MailConfig{
+		Driver: os.Getenv("MAIL_DRIVER"),
+		From:   os.Getenv("MAIL_FROM"),
+		AppURL: os.Getenv("MAIL_APP_URL"),
+		Dir:    os.Getenv("MAIL_DIR"),
+	}
+
+	oidc := OIDCConfig{
+		Providers: parseOIDCProviders(),
+		StateTTL:  time.Duration(atoiDef(os.Getenv("OIDC_STATE_TTL_MIN"), 10)) * time.Minute,
+	}
+
+	storage := StorageConfig{
+		Driver: os.Getenv("STORAGE_DRIVER"),
+		Dir:    os.Getenv("STORAGE_DIR"),
+	}
+
+	tutorial := TutorialConfig{
+		VideoMaxBytes:     int64(atoiDef(os.Getenv("TUTORIAL_VIDEO_MAX_MB"), 500)) << 20,
+		VideoTypes:        listDef(os.Getenv("TUTORIAL_VIDEO_TYPES"), []string{"video/mp4", "video/webm"}),
+		ThumbnailMaxBytes: int64(atoiDef(os.Getenv("TUTORIAL_THUMBNAIL_MAX_MB"), 5)) << 20,
+		ThumbnailTypes:    listDef(os.Getenv("TUTORIAL_THUMBNAIL_TYPES"), []string{"image/jpeg", "image/png", "image/webp"}),
+		MediaMaxAge:       time.Duration(atoiDef(os.Getenv("TUTORIAL_MEDIA_MAX_AGE_SEC"), 86400)) * time.Second,
+	}
+
+	energy := EnergyConfig{
+		METTableFile: os.Getenv("ENERGY_MET_TABLE_FILE"),
 	}
 
 	cfg := &Config{
@@ -164,6 +343,11 @@ func Parse() *Config {
 		CORS:      cors,
 		RateLimit: rateLimit,
 		Auth:      auth,
+		Mail:      mail,
+		OIDC:      oidc,
+		Storage:   storage,
+		Tutorial:  tutorial,
+		Energy:    energy,
 	}
 
 	return cfg
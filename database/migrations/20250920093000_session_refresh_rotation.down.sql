@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS uq_sessions_refresh_token_hash;
+DROP TABLE IF EXISTS session_refresh_tokens;
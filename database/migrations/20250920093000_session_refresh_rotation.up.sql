@@ -0,0 +1,10 @@
+-- SESSION_REFRESH_TOKENS: hashes of refresh tokens that were already rotated.
+-- Presenting one of these again means the token leaked, so the whole session is revoked.
+CREATE TABLE IF NOT EXISTS session_refresh_tokens (
+  token_hash  text PRIMARY KEY,
+  session_id  uuid NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+  rotated_at  timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_session_refresh_tokens_session ON session_refresh_tokens(session_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_sessions_refresh_token_hash ON sessions(refresh_token_hash);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_sessions_guest_active;
+DROP TRIGGER IF EXISTS trg_app_config_changed ON app_config;
+DROP FUNCTION IF EXISTS notify_app_config_changed();
@@ -0,0 +1,15 @@
+-- Notify listeners whenever the runtime configuration changes
+CREATE OR REPLACE FUNCTION notify_app_config_changed() RETURNS trigger AS $$
+BEGIN
+  PERFORM pg_notify('app_config_changed', '');
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_app_config_changed ON app_config;
+CREATE TRIGGER trg_app_config_changed
+AFTER INSERT OR UPDATE OR DELETE ON app_config
+FOR EACH STATEMENT EXECUTE FUNCTION notify_app_config_changed();
+
+CREATE INDEX IF NOT EXISTS idx_sessions_guest_active ON sessions(refresh_expires_at)
+WHERE kind = 'guest' AND revoked_at IS NULL;
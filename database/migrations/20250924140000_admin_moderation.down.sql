@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS admin_audit_logs;
+DROP INDEX IF EXISTS idx_accounts_email_trgm;
+ALTER TABLE accounts DROP COLUMN IF EXISTS is_admin;
@@ -0,0 +1,18 @@
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS is_admin boolean NOT NULL DEFAULT false;
+
+-- Fuzzy email search for moderation
+CREATE INDEX IF NOT EXISTS idx_accounts_email_trgm ON accounts USING gin ((email::text) gin_trgm_ops);
+
+-- ADMIN_AUDIT_LOGS: who changed what and when
+CREATE TABLE IF NOT EXISTS admin_audit_logs (
+  id               uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  actor_account_id uuid REFERENCES accounts(id) ON DELETE SET NULL,
+  action           text NOT NULL,
+  target_type      text NOT NULL,
+  target_id        text,
+  details          jsonb NOT NULL DEFAULT '{}'::jsonb,
+  ip               text,
+  created_at       timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_logs_created ON admin_audit_logs(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_logs_target  ON admin_audit_logs(target_type, target_id);
@@ -0,0 +1,11 @@
+-- ACCOUNT_TOKENS: single-use opaque tokens sent by email (hashed)
+CREATE TABLE IF NOT EXISTS account_tokens (
+  id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  account_id  uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  purpose     text NOT NULL CHECK (purpose IN ('password_reset')),
+  token_hash  text NOT NULL UNIQUE,
+  expires_at  timestamptz NOT NULL,
+  used_at     timestamptz,
+  created_at  timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_account_tokens_account ON account_tokens(account_id, purpose, created_at);
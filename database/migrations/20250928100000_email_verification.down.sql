@@ -0,0 +1,6 @@
+DELETE FROM account_tokens WHERE purpose = 'email_verify';
+ALTER TABLE account_tokens DROP CONSTRAINT IF EXISTS account_tokens_purpose_check;
+ALTER TABLE account_tokens ADD CONSTRAINT account_tokens_purpose_check
+  CHECK (purpose IN ('password_reset'));
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS email_verified_at;
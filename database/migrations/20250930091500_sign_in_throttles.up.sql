@@ -0,0 +1,9 @@
+-- SIGN_IN_THROTTLES: failed sign-in counters per account email and per IP.
+-- Temporary lockouts live here and expire on their own, unlike accounts.is_locked.
+CREATE TABLE IF NOT EXISTS sign_in_throttles (
+  key              text PRIMARY KEY,          -- 'account:<email>' | 'ip:<addr>'
+  failures         integer NOT NULL DEFAULT 0,
+  last_failure_at  timestamptz NOT NULL DEFAULT now(),
+  locked_until     timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_sign_in_throttles_last_failure ON sign_in_throttles(last_failure_at);
@@ -0,0 +1,19 @@
+-- ACCOUNT_MFA: TOTP secret per account, encrypted with the MFA key from config
+CREATE TABLE IF NOT EXISTS account_mfa (
+  account_id       uuid PRIMARY KEY REFERENCES accounts(id) ON DELETE CASCADE,
+  totp_secret_enc  bytea NOT NULL,
+  enabled_at       timestamptz,          -- NULL until the first code is confirmed
+  last_used_step   bigint,               -- rejects replay of an accepted code
+  created_at       timestamptz NOT NULL DEFAULT now(),
+  updated_at       timestamptz NOT NULL DEFAULT now()
+);
+
+-- MFA_RECOVERY_CODES: single-use backup codes (hashed)
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+  id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  account_id  uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  code_hash   text NOT NULL,
+  used_at     timestamptz,
+  created_at  timestamptz NOT NULL DEFAULT now(),
+  UNIQUE (account_id, code_hash)
+);
@@ -0,0 +1,13 @@
+-- Password-less accounts cannot be represented anymore, they must get a password
+-- (or be deleted) by hand first: rolling back never removes accounts
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM accounts WHERE password_hash IS NULL) THEN
+    RAISE EXCEPTION 'accounts without a password exist, set their password or delete them before rolling back';
+  END IF;
+END $$;
+
+DROP TABLE IF EXISTS oauth_states;
+DROP TABLE IF EXISTS account_identities;
+
+ALTER TABLE accounts ALTER COLUMN password_hash SET NOT NULL;
@@ -0,0 +1,26 @@
+-- Accounts created through social login have no password
+ALTER TABLE accounts ALTER COLUMN password_hash DROP NOT NULL;
+
+-- ACCOUNT_IDENTITIES: external OpenID Connect identities linked to an account
+CREATE TABLE IF NOT EXISTS account_identities (
+  id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  account_id     uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  provider       text NOT NULL,
+  subject        text NOT NULL,               -- "sub" claim, stable per provider
+  email          citext,
+  created_at     timestamptz NOT NULL DEFAULT now(),
+  last_login_at  timestamptz NOT NULL DEFAULT now(),
+  UNIQUE (provider, subject)
+);
+CREATE INDEX IF NOT EXISTS idx_account_identities_account ON account_identities(account_id);
+
+-- OAUTH_STATES: pending authorization requests (PKCE verifier kept server-side)
+CREATE TABLE IF NOT EXISTS oauth_states (
+  state_hash     text PRIMARY KEY,
+  provider       text NOT NULL,
+  code_verifier  text NOT NULL,
+  nonce          text NOT NULL,
+  expires_at     timestamptz NOT NULL,
+  created_at     timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_oauth_states_expires ON oauth_states(expires_at);
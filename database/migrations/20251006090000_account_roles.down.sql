@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS coach_swimmers;
+
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS is_admin boolean NOT NULL DEFAULT false;
+UPDATE accounts AS a SET is_admin = true
+WHERE EXISTS (SELECT 1 FROM account_roles AS r WHERE r.account_id = a.id AND r.role = 'admin');
+
+DROP TABLE IF EXISTS account_roles;
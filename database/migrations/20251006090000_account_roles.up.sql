@@ -0,0 +1,29 @@
+-- ACCOUNT_ROLES: an account may hold several roles (swimmer, coach, admin)
+CREATE TABLE IF NOT EXISTS account_roles (
+  account_id  uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  role        text NOT NULL CHECK (role IN ('swimmer', 'coach', 'admin')),
+  granted_at  timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (account_id, role)
+);
+CREATE INDEX IF NOT EXISTS idx_account_roles_role ON account_roles(role);
+
+-- Every existing account swims, former is_admin flags become the admin role
+INSERT INTO account_roles (account_id, role)
+SELECT id, 'swimmer' FROM accounts
+ON CONFLICT DO NOTHING;
+
+INSERT INTO account_roles (account_id, role)
+SELECT id, 'admin' FROM accounts WHERE is_admin
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS is_admin;
+
+-- COACH_SWIMMERS: swimmers a coach may act on
+CREATE TABLE IF NOT EXISTS coach_swimmers (
+  coach_account_id    uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  swimmer_account_id  uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  created_at          timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (coach_account_id, swimmer_account_id),
+  CONSTRAINT chk_coach_not_self CHECK (coach_account_id <> swimmer_account_id)
+);
+CREATE INDEX IF NOT EXISTS idx_coach_swimmers_swimmer ON coach_swimmers(swimmer_account_id);
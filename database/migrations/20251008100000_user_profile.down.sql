@@ -0,0 +1,16 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS age_years smallint;
+
+UPDATE users
+SET age_years = LEAST(120, EXTRACT(YEAR FROM age(current_date, birth_date)))::smallint
+WHERE birth_date IS NOT NULL;
+
+ALTER TABLE users
+  ADD CONSTRAINT chk_age CHECK (age_years IS NULL OR (age_years >= 0 AND age_years <= 120));
+
+ALTER TABLE users
+  DROP CONSTRAINT IF EXISTS chk_birth_date,
+  DROP CONSTRAINT IF EXISTS chk_distance_unit,
+  DROP CONSTRAINT IF EXISTS chk_unit_system,
+  DROP COLUMN IF EXISTS birth_date,
+  DROP COLUMN IF EXISTS distance_unit,
+  DROP COLUMN IF EXISTS unit_system;
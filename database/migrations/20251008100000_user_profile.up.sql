@@ -0,0 +1,18 @@
+-- Unit preference: values stay metric in the database, the API converts on input and output
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS unit_system   text NOT NULL DEFAULT 'metric',
+  ADD COLUMN IF NOT EXISTS distance_unit text NOT NULL DEFAULT 'm',
+  ADD COLUMN IF NOT EXISTS birth_date    date;
+
+ALTER TABLE users
+  ADD CONSTRAINT chk_unit_system   CHECK (unit_system IN ('metric', 'imperial')),
+  ADD CONSTRAINT chk_distance_unit CHECK (distance_unit IN ('m', 'yd')),
+  ADD CONSTRAINT chk_birth_date    CHECK (birth_date IS NULL OR birth_date >= DATE '1900-01-01');
+
+-- Best effort: the exact day is unknown, anchor on the same day age_years was last right
+UPDATE users
+SET birth_date = (updated_at::date - make_interval(years => age_years))::date
+WHERE age_years IS NOT NULL AND birth_date IS NULL;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_age;
+ALTER TABLE users DROP COLUMN IF EXISTS age_years;
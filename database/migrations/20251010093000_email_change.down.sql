@@ -0,0 +1,7 @@
+DELETE FROM account_tokens WHERE purpose = 'email_change';
+
+ALTER TABLE account_tokens DROP CONSTRAINT IF EXISTS account_tokens_purpose_check;
+ALTER TABLE account_tokens ADD CONSTRAINT account_tokens_purpose_check
+  CHECK (purpose IN ('password_reset','email_verify'));
+
+ALTER TABLE account_tokens DROP COLUMN IF EXISTS new_email;
@@ -0,0 +1,6 @@
+-- Email change tokens carry the address they confirm, the account keeps its email until then
+ALTER TABLE account_tokens ADD COLUMN IF NOT EXISTS new_email citext;
+
+ALTER TABLE account_tokens DROP CONSTRAINT IF EXISTS account_tokens_purpose_check;
+ALTER TABLE account_tokens ADD CONSTRAINT account_tokens_purpose_check
+  CHECK (purpose IN ('password_reset','email_verify','email_change'));
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_accounts_deletion_scheduled;
+ALTER TABLE accounts DROP COLUMN IF EXISTS deletion_scheduled_at;
@@ -0,0 +1,5 @@
+-- Accounts are purged once deletion_scheduled_at has passed, every owned row goes with ON DELETE CASCADE
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS deletion_scheduled_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_accounts_deletion_scheduled
+  ON accounts(deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
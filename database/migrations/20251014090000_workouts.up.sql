@@ -0,0 +1,25 @@
+-- WORKOUTS: logged swim sessions, distances are stored in meters
+CREATE TABLE IF NOT EXISTS workouts (
+  id               uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  account_id       uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  swum_on          date NOT NULL,
+  pool_length      text NOT NULL CHECK (pool_length IN ('25m', '50m', '25yd', 'open_water')),
+  total_distance_m numeric(8,2) NOT NULL CHECK (total_distance_m >= 0),
+  duration_sec     integer NOT NULL CHECK (duration_sec > 0),
+  notes            text,
+  created_at       timestamptz NOT NULL DEFAULT now(),
+  updated_at       timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_workouts_account_date ON workouts(account_id, swum_on DESC, created_at DESC);
+
+-- WORKOUT_SETS: ordered intervals of a workout, distance and time are per repetition
+CREATE TABLE IF NOT EXISTS workout_sets (
+  workout_id  uuid NOT NULL REFERENCES workouts(id) ON DELETE CASCADE,
+  position    smallint NOT NULL,
+  stroke      text NOT NULL CHECK (stroke IN ('freestyle', 'backstroke', 'breaststroke', 'butterfly', 'im', 'kick', 'pull', 'drill', 'choice')),
+  repetitions smallint NOT NULL CHECK (repetitions > 0),
+  distance_m  numeric(8,2) NOT NULL CHECK (distance_m > 0),
+  rest_sec    integer NOT NULL DEFAULT 0 CHECK (rest_sec >= 0),
+  time_sec    numeric(8,2) CHECK (time_sec IS NULL OR time_sec > 0),
+  PRIMARY KEY (workout_id, position)
+);
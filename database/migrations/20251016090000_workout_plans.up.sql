@@ -0,0 +1,13 @@
+-- WORKOUT_PLANS: reusable workouts, blocks hold the tree of sections and steps
+CREATE TABLE IF NOT EXISTS workout_plans (
+  id             uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  account_id     uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  name           text NOT NULL,
+  description    text,
+  distance_unit  text NOT NULL CHECK (distance_unit IN ('m', 'yd')),
+  blocks         jsonb NOT NULL DEFAULT '[]'::jsonb,
+  total_distance numeric(9,2) NOT NULL DEFAULT 0, -- in distance_unit, denormalized for listing
+  created_at     timestamptz NOT NULL DEFAULT now(),
+  updated_at     timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_workout_plans_account ON workout_plans(account_id, updated_at DESC);
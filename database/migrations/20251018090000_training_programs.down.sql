@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS scheduled_workouts;
+DROP TABLE IF EXISTS enrollments;
+DROP TABLE IF EXISTS program_workouts;
+DROP TABLE IF EXISTS programs;
@@ -0,0 +1,53 @@
+-- PROGRAMS: multi-week schedules of plans, authored by content managers
+CREATE TABLE IF NOT EXISTS programs (
+  id                uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  author_account_id uuid REFERENCES accounts(id) ON DELETE SET NULL,
+  name              text NOT NULL,
+  description       text,
+  weeks             smallint NOT NULL CHECK (weeks BETWEEN 1 AND 52),
+  is_published      boolean NOT NULL DEFAULT false,
+  created_at        timestamptz NOT NULL DEFAULT now(),
+  updated_at        timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_programs_published ON programs(created_at DESC) WHERE is_published;
+
+-- PROGRAM_WORKOUTS: which plan is swum on which weekday (1 = Monday) of which week
+CREATE TABLE IF NOT EXISTS program_workouts (
+  program_id uuid NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+  week       smallint NOT NULL CHECK (week >= 1),
+  weekday    smallint NOT NULL CHECK (weekday BETWEEN 1 AND 7),
+  plan_id    uuid NOT NULL REFERENCES workout_plans(id) ON DELETE CASCADE, -- the plans API refuses to delete used plans
+  PRIMARY KEY (program_id, week, weekday)
+);
+CREATE INDEX IF NOT EXISTS idx_program_workouts_plan ON program_workouts(plan_id);
+
+-- ENROLLMENTS: a user following a program from a start date
+CREATE TABLE IF NOT EXISTS enrollments (
+  id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  account_id uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  program_id uuid NOT NULL REFERENCES programs(id) ON DELETE RESTRICT,
+  start_date date NOT NULL,
+  status     text NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed', 'cancelled')),
+  created_at timestamptz NOT NULL DEFAULT now(),
+  updated_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_enrollments_active ON enrollments(account_id, program_id) WHERE status = 'active';
+
+-- SCHEDULED_WORKOUTS: the calendar materialized at enrollment
+CREATE TABLE IF NOT EXISTS scheduled_workouts (
+  id            uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  enrollment_id uuid NOT NULL REFERENCES enrollments(id) ON DELETE CASCADE,
+  account_id    uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  plan_id       uuid REFERENCES workout_plans(id) ON DELETE SET NULL,
+  week          smallint NOT NULL,
+  weekday       smallint NOT NULL,
+  scheduled_on  date NOT NULL,
+  status        text NOT NULL DEFAULT 'planned' CHECK (status IN ('planned', 'completed', 'skipped')),
+  workout_id    uuid REFERENCES workouts(id) ON DELETE SET NULL, -- the logged workout that completed it
+  completed_at  timestamptz,
+  created_at    timestamptz NOT NULL DEFAULT now(),
+  updated_at    timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_scheduled_workouts_account_date ON scheduled_workouts(account_id, scheduled_on);
+CREATE INDEX IF NOT EXISTS idx_scheduled_workouts_enrollment ON scheduled_workouts(enrollment_id, scheduled_on);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_scheduled_workouts_workout ON scheduled_workouts(workout_id) WHERE workout_id IS NOT NULL;
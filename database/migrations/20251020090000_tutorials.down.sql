@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tutorial_media;
+DROP TABLE IF EXISTS tutorials;
@@ -0,0 +1,27 @@
+-- TUTORIALS: video catalog, categorized by stroke, drill and skill level
+CREATE TABLE IF NOT EXISTS tutorials (
+  id                uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  author_account_id uuid REFERENCES accounts(id) ON DELETE SET NULL,
+  title             text NOT NULL,
+  description       text,
+  stroke            text CHECK (stroke IN ('freestyle', 'backstroke', 'breaststroke', 'butterfly', 'im', 'kick', 'pull', 'drill', 'choice')),
+  drill             text, -- ex: catch-up, 6-kick switch
+  skill_level       text NOT NULL CHECK (skill_level IN ('beginner', 'intermediate', 'advanced')),
+  duration_sec      integer CHECK (duration_sec > 0),
+  is_published      boolean NOT NULL DEFAULT false,
+  created_at        timestamptz NOT NULL DEFAULT now(),
+  updated_at        timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_tutorials_published ON tutorials(skill_level, stroke) WHERE is_published;
+
+-- TUTORIAL_MEDIA: uploaded files of a tutorial, the bytes live in the storage backend
+CREATE TABLE IF NOT EXISTS tutorial_media (
+  tutorial_id  uuid NOT NULL REFERENCES tutorials(id) ON DELETE CASCADE,
+  kind         text NOT NULL CHECK (kind IN ('video', 'thumbnail')),
+  storage_key  text NOT NULL,
+  content_type text NOT NULL, -- sniffed on upload
+  size_bytes   bigint NOT NULL CHECK (size_bytes > 0),
+  checksum     text NOT NULL, -- hex sha256, served as the ETag
+  uploaded_at  timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (tutorial_id, kind)
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS tutorial_favorites;
+DROP TABLE IF EXISTS tutorial_progress;
+
+ALTER TABLE users
+  DROP CONSTRAINT IF EXISTS chk_skill_level,
+  DROP COLUMN IF EXISTS skill_level;
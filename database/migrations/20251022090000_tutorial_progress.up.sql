@@ -0,0 +1,32 @@
+-- Skill level picked by the user, drives tutorial recommendations
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS skill_level text;
+
+ALTER TABLE users
+  ADD CONSTRAINT chk_skill_level CHECK (skill_level IS NULL OR skill_level IN ('beginner', 'intermediate', 'advanced'));
+
+-- TUTORIAL_PROGRESS: watch position per viewer, guests track under their session until they register
+CREATE TABLE IF NOT EXISTS tutorial_progress (
+  account_id   uuid REFERENCES accounts(id) ON DELETE CASCADE,
+  session_id   uuid REFERENCES sessions(id) ON DELETE CASCADE,
+  owner_id     uuid GENERATED ALWAYS AS (COALESCE(account_id, session_id)) STORED,
+  tutorial_id  uuid NOT NULL REFERENCES tutorials(id) ON DELETE CASCADE,
+  position_sec integer NOT NULL DEFAULT 0 CHECK (position_sec >= 0),
+  completed_at timestamptz, -- sticky, rewatching keeps it
+  updated_at   timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT chk_tutorial_progress_owner CHECK (num_nonnulls(account_id, session_id) = 1),
+  PRIMARY KEY (owner_id, tutorial_id)
+);
+CREATE INDEX IF NOT EXISTS idx_tutorial_progress_session ON tutorial_progress(session_id) WHERE session_id IS NOT NULL;
+
+-- TUTORIAL_FAVORITES: same ownership rules as tutorial_progress
+CREATE TABLE IF NOT EXISTS tutorial_favorites (
+  account_id  uuid REFERENCES accounts(id) ON DELETE CASCADE,
+  session_id  uuid REFERENCES sessions(id) ON DELETE CASCADE,
+  owner_id    uuid GENERATED ALWAYS AS (COALESCE(account_id, session_id)) STORED,
+  tutorial_id uuid NOT NULL REFERENCES tutorials(id) ON DELETE CASCADE,
+  created_at  timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT chk_tutorial_favorites_owner CHECK (num_nonnulls(account_id, session_id) = 1),
+  PRIMARY KEY (owner_id, tutorial_id)
+);
+CREATE INDEX IF NOT EXISTS idx_tutorial_favorites_session ON tutorial_favorites(session_id) WHERE session_id IS NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE users
+  DROP CONSTRAINT IF EXISTS chk_threshold_pace,
+  DROP COLUMN IF EXISTS threshold_pace_sec;
@@ -0,0 +1,6 @@
+-- Threshold pace in seconds per 100m (freestyle), sets the intensity of calorie estimates
+ALTER TABLE users
+  ADD COLUMN IF NOT EXISTS threshold_pace_sec numeric(6,2);
+
+ALTER TABLE users
+  ADD CONSTRAINT chk_threshold_pace CHECK (threshold_pace_sec IS NULL OR (threshold_pace_sec >= 30 AND threshold_pace_sec <= 600));
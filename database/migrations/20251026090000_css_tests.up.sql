@@ -0,0 +1,14 @@
+-- CSS_TESTS: 400 and 200 time trials, the latest one sets users.threshold_pace_sec
+CREATE TABLE IF NOT EXISTS css_tests (
+  id            uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  account_id    uuid NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+  tested_on     date NOT NULL,
+  distance_unit text NOT NULL CHECK (distance_unit IN ('m', 'yd')), -- the trials were swum in
+  time_400_sec  numeric(7,2) NOT NULL,
+  time_200_sec  numeric(7,2) NOT NULL,
+  css_pace_sec  numeric(6,2) NOT NULL,                               -- per 100m
+  created_at    timestamptz NOT NULL DEFAULT now(),
+  CONSTRAINT chk_css_times CHECK (time_200_sec > 0 AND time_400_sec > 2 * time_200_sec),
+  CONSTRAINT chk_css_pace CHECK (css_pace_sec >= 30 AND css_pace_sec <= 600)
+);
+CREATE INDEX IF NOT EXISTS idx_css_tests_account_date ON css_tests(account_id, tested_on DESC, created_at DESC);
@@ -0,0 +1,10 @@
+-- WORKOUT_SET_LAPS: one row per pool length of a set, repetitions follow each other
+CREATE TABLE IF NOT EXISTS workout_set_laps (
+  workout_id   uuid NOT NULL,
+  set_position smallint NOT NULL,
+  lap          smallint NOT NULL CHECK (lap > 0),
+  time_sec     numeric(7,2) NOT NULL CHECK (time_sec > 0),
+  strokes      smallint CHECK (strokes IS NULL OR strokes >= 0),
+  PRIMARY KEY (workout_id, set_position, lap),
+  FOREIGN KEY (workout_id, set_position) REFERENCES workout_sets(workout_id, position) ON DELETE CASCADE
+);
@@ -0,0 +1,13 @@
+-- Detached plans have no account left to give them back to
+DO $$
+BEGIN
+  IF EXISTS (SELECT 1 FROM workout_plans WHERE account_id IS NULL) THEN
+    RAISE EXCEPTION 'workout_plans has plans detached from purged accounts, reassign them before rolling back';
+  END IF;
+END $$;
+
+ALTER TABLE program_workouts DROP CONSTRAINT IF EXISTS program_workouts_plan_id_fkey;
+ALTER TABLE program_workouts
+  ADD CONSTRAINT program_workouts_plan_id_fkey FOREIGN KEY (plan_id) REFERENCES workout_plans(id) ON DELETE CASCADE;
+
+ALTER TABLE workout_plans ALTER COLUMN account_id SET NOT NULL;
@@ -0,0 +1,7 @@
+-- Plans scheduled by a program outlive their author: the account purge detaches them
+-- (account_id NULL) and deleting one still in use fails instead of emptying the program
+ALTER TABLE workout_plans ALTER COLUMN account_id DROP NOT NULL;
+
+ALTER TABLE program_workouts DROP CONSTRAINT IF EXISTS program_workouts_plan_id_fkey;
+ALTER TABLE program_workouts
+  ADD CONSTRAINT program_workouts_plan_id_fkey FOREIGN KEY (plan_id) REFERENCES workout_plans(id) ON DELETE RESTRICT;
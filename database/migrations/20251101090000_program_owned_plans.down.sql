@@ -0,0 +1,3 @@
+ALTER TABLE workout_plans DROP CONSTRAINT IF EXISTS workout_plans_owner_check;
+DROP INDEX IF EXISTS idx_workout_plans_program;
+ALTER TABLE workout_plans DROP COLUMN IF EXISTS program_id;
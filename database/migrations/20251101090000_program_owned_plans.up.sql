@@ -0,0 +1,36 @@
+-- Plans detached from a purged author belong to the program scheduling them,
+-- only that program can use them and they are deleted with it
+ALTER TABLE workout_plans ADD COLUMN IF NOT EXISTS program_id uuid REFERENCES programs(id);
+CREATE INDEX IF NOT EXISTS idx_workout_plans_program ON workout_plans(program_id) WHERE program_id IS NOT NULL;
+
+-- Plans detached so far: the first program using one takes it, the others get a copy
+DO $$
+DECLARE
+  plan_use record;
+  copy_id uuid;
+BEGIN
+  FOR plan_use IN
+    SELECT DISTINCT w.plan_id, w.program_id
+    FROM program_workouts AS w
+    JOIN workout_plans AS p ON p.id = w.plan_id
+    WHERE p.account_id IS NULL
+    ORDER BY w.plan_id, w.program_id
+  LOOP
+    IF EXISTS (SELECT 1 FROM workout_plans WHERE id = plan_use.plan_id AND program_id IS NULL) THEN
+      UPDATE workout_plans SET program_id = plan_use.program_id WHERE id = plan_use.plan_id;
+    ELSE
+      INSERT INTO workout_plans (program_id, name, description, distance_unit, blocks, total_distance)
+      SELECT plan_use.program_id, name, description, distance_unit, blocks, total_distance
+      FROM workout_plans WHERE id = plan_use.plan_id
+      RETURNING id INTO copy_id;
+
+      UPDATE program_workouts SET plan_id = copy_id WHERE program_id = plan_use.program_id AND plan_id = plan_use.plan_id;
+    END IF;
+  END LOOP;
+END $$;
+
+-- Detached plans no program uses anymore
+DELETE FROM workout_plans WHERE account_id IS NULL AND program_id IS NULL;
+
+ALTER TABLE workout_plans
+  ADD CONSTRAINT workout_plans_owner_check CHECK (account_id IS NOT NULL OR program_id IS NOT NULL);
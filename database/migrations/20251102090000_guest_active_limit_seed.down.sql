@@ -0,0 +1 @@
+UPDATE app_config SET guest_active_limit = 4, updated_at = now() WHERE id AND guest_active_limit = 1000;
@@ -0,0 +1,3 @@
+-- The first seed allowed 4 active guests in total; raise it to the column default
+-- unless an admin already changed it
+UPDATE app_config SET guest_active_limit = 1000, updated_at = now() WHERE id AND guest_active_limit = 4;
@@ -0,0 +1,281 @@
+package http
+
+import (
+	"errors"
+	"haphap/swimo-api/internal/app/admin"
+	"haphap/swimo-api/internal/app/admin/dto"
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/validator"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AdminHandler struct {
+	adminUsecase admin.AdminUseCase
+}
+
+func NewAdminHandler(adminUsecase admin.AdminUseCase) *AdminHandler {
+	return &AdminHandler{adminUsecase}
+}
+
+func actor(c *fiber.Ctx) admin.Actor {
+	return admin.Actor{AccountID: middleware.GetPrincipal(c).AccountID, IP: c.IP()}
+}
+
+func (h *AdminHandler) GetAppConfig(c *fiber.Ctx) error {
+	out, err := h.adminUsecase.GetAppConfig(c.Context())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "App config retrieved successfully.",
+	})
+}
+
+func (h *AdminHandler) UpdateAppConfig(c *fiber.Ctx) error {
+	var req dto.UpdateAppConfigRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.adminUsecase.UpdateAppConfig(c.Context(), actor(c), req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "App config updated successfully.",
+	})
+}
+
+func (h *AdminHandler) SearchAccounts(c *fiber.Ctx) error {
+	var query dto.SearchAccountsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid query parameters."})
+	}
+
+	out, total, err := h.adminUsecase.SearchAccounts(c.Context(), query)
+	if err != nil {
+		return err
+	}
+
+	query.Normalize()
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    response.Page{Items: out, Total: total, Limit: query.Limit, Offset: query.Offset},
+		Message: "Accounts retrieved successfully.",
+	})
+}
+
+func (h *AdminHandler) LockAccount(c *fiber.Ctx) error {
+	return h.setAccountLocked(c, true)
+}
+
+func (h *AdminHandler) UnlockAccount(c *fiber.Ctx) error {
+	return h.setAccountLocked(c, false)
+}
+
+func (h *AdminHandler) setAccountLocked(c *fiber.Ctx, locked bool) error {
+	accountID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(accountID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Account not found."})
+	}
+
+	if err := h.adminUsecase.SetAccountLocked(c.Context(), actor(c), accountID, locked); err != nil {
+		switch {
+		case errors.Is(err, admin.ErrAccountNotFound):
+			return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Account not found."})
+		case errors.Is(err, admin.ErrSelfAction):
+			return c.Status(http.StatusConflict).JSON(response.Base{Message: "You cannot lock or unlock your own account."})
+		default:
+			return err
+		}
+	}
+
+	message := "Account unlocked successfully."
+	if locked {
+		message = "Account locked successfully."
+	}
+	return c.Status(http.StatusOK).JSON(response.Base{Message: message})
+}
+
+func (h *AdminHandler) RevokeAccountSessions(c *fiber.Ctx) error {
+	accountID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(accountID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Account not found."})
+	}
+
+	count, err := h.adminUsecase.RevokeAccountSessions(c.Context(), actor(c), accountID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    fiber.Map{"revoked": count},
+		Message: "Sessions revoked successfully.",
+	})
+}
+
+func (h *AdminHandler) RevokeSession(c *fiber.Ctx) error {
+	sessionID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(sessionID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Session not found."})
+	}
+
+	if err := h.adminUsecase.RevokeSession(c.Context(), actor(c), sessionID); err != nil {
+		if errors.Is(err, admin.ErrSessionNotFound) {
+			return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Session not found."})
+		}
+
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Session revoked successfully."})
+}
+
+func (h *AdminHandler) ListAuditLogs(c *fiber.Ctx) error {
+	var query dto.ListAuditLogsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid query parameters."})
+	}
+
+	out, err := h.adminUsecase.ListAuditLogs(c.Context(), query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Audit logs retrieved successfully.",
+	})
+}
+
+func (h *AdminHandler) SetAccountRoles(c *fiber.Ctx) error {
+	accountID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(accountID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Account not found."})
+	}
+
+	var req dto.SetRolesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	req.Normalize()
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	roles, err := h.adminUsecase.SetAccountRoles(c.Context(), actor(c), accountID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, admin.ErrAccountNotFound):
+			return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Account not found."})
+		case errors.Is(err, admin.ErrSelfAction):
+			return c.Status(http.StatusConflict).JSON(response.Base{Message: "You cannot remove your own admin role."})
+		default:
+			return err
+		}
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    fiber.Map{"roles": roles},
+		Message: "Roles updated successfully.",
+	})
+}
+
+func (h *AdminHandler) ListCoachSwimmers(c *fiber.Ctx) error {
+	coachID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(coachID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Account not found."})
+	}
+
+	out, err := h.adminUsecase.ListCoachSwimmers(c.Context(), coachID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Swimmers retrieved successfully.",
+	})
+}
+
+// ListSwimmers is the coach's own list, coach-scoped routes take these ids.
+func (h *AdminHandler) ListSwimmers(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	out, err := h.adminUsecase.ListSwimmers(c.Context(), principal.AccountID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Swimmers retrieved successfully.",
+	})
+}
+
+func (h *AdminHandler) LinkCoach(c *fiber.Ctx) error {
+	coachID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(coachID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Account not found."})
+	}
+
+	var req dto.LinkCoachRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	if err := h.adminUsecase.LinkCoach(c.Context(), actor(c), coachID, req.SwimmerID); err != nil {
+		return coachLinkError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Swimmer linked to coach successfully."})
+}
+
+func (h *AdminHandler) UnlinkCoach(c *fiber.Ctx) error {
+	coachID, swimmerID := c.Params("id"), c.Params("swimmerId")
+	if !validator.UUIDPattern.MatchString(coachID) || !validator.UUIDPattern.MatchString(swimmerID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Link not found."})
+	}
+
+	if err := h.adminUsecase.UnlinkCoach(c.Context(), actor(c), coachID, swimmerID); err != nil {
+		return coachLinkError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Swimmer unlinked from coach successfully."})
+}
+
+func coachLinkError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, admin.ErrAccountNotFound):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Account not found."})
+	case errors.Is(err, admin.ErrLinkNotFound):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Link not found."})
+	case errors.Is(err, admin.ErrNotCoach):
+		return c.Status(http.StatusConflict).JSON(response.Base{Message: "Account does not have the coach role."})
+	case errors.Is(err, admin.ErrSelfAction):
+		return c.Status(http.StatusConflict).JSON(response.Base{Message: "A coach cannot be linked to themselves."})
+	default:
+		return err
+	}
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/rbac"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func Register(app *fiber.App, adminHandler *AdminHandler, authMw *middleware.AuthMiddleware) {
+	manageConfig := middleware.RequirePermission(rbac.PermManageConfig)
+	manageAccounts := middleware.RequirePermission(rbac.PermManageAccounts)
+
+	adminV1 := app.Group("/api/v1/admin", authMw.Require(middleware.UserOnly))
+	adminV1.Get("/config", manageConfig, adminHandler.GetAppConfig)
+	adminV1.Patch("/config", manageConfig, adminHandler.UpdateAppConfig)
+
+	adminV1.Get("/accounts", manageAccounts, adminHandler.SearchAccounts)
+	adminV1.Post("/accounts/:id/lock", manageAccounts, adminHandler.LockAccount)
+	adminV1.Post("/accounts/:id/unlock", manageAccounts, adminHandler.UnlockAccount)
+	adminV1.Post("/accounts/:id/revoke-sessions", manageAccounts, adminHandler.RevokeAccountSessions)
+	adminV1.Put("/accounts/:id/roles", manageAccounts, adminHandler.SetAccountRoles)
+	adminV1.Delete("/sessions/:id", manageAccounts, adminHandler.RevokeSession)
+
+	adminV1.Get("/coaches/:id/swimmers", manageAccounts, adminHandler.ListCoachSwimmers)
+	adminV1.Post("/coaches/:id/swimmers", manageAccounts, adminHandler.LinkCoach)
+	adminV1.Delete("/coaches/:id/swimmers/:swimmerId", manageAccounts, adminHandler.UnlinkCoach)
+
+	adminV1.Get("/audit-logs", middleware.RequirePermission(rbac.PermViewAuditLogs), adminHandler.ListAuditLogs)
+
+	app.Get("/api/v1/swimmers",
+		authMw.Require(middleware.UserOnly),
+		middleware.RequirePermission(rbac.PermViewSwimmers),
+		adminHandler.ListSwimmers,
+	)
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"haphap/swimo-api/internal/app/admin/entity"
+	"haphap/swimo-api/pkg/response"
+	"strings"
+	"time"
+)
+
+type (
+	SearchAccountsQuery struct {
+		Email  string `query:"email"`
+		Limit  int    `query:"limit"`
+		Offset int    `query:"offset"`
+	}
+
+	AccountResponse struct {
+		ID             string    `json:"id"`
+		Email          string    `json:"email"`
+		Name           *string   `json:"name"`
+		IsLocked       bool      `json:"isLocked"`
+		Roles          []string  `json:"roles"`
+		ActiveSessions int       `json:"activeSessions"`
+		CreatedAt      time.Time `json:"createdAt"`
+	}
+)
+
+func (q *SearchAccountsQuery) Normalize() {
+	q.Email = strings.TrimSpace(strings.ToLower(q.Email))
+	q.Limit, q.Offset = response.NormalizePage(q.Limit, q.Offset)
+}
+
+func ToAccountResponse(account *entity.Account) AccountResponse {
+	return AccountResponse{
+		ID:             account.ID,
+		Email:          account.Email,
+		Name:           account.Name,
+		IsLocked:       account.IsLocked,
+		Roles:          account.Roles,
+		ActiveSessions: account.ActiveSessions,
+		CreatedAt:      account.CreatedAt,
+	}
+}
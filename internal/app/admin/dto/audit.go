@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"haphap/swimo-api/internal/app/admin/entity"
+	"time"
+)
+
+type (
+	ListAuditLogsQuery struct {
+		Limit  int `query:"limit"`
+		Offset int `query:"offset"`
+	}
+
+	AuditLogResponse struct {
+		ID             string         `json:"id"`
+		ActorAccountID *string        `json:"actorAccountId"`
+		Action         string         `json:"action"`
+		TargetType     string         `json:"targetType"`
+		TargetID       *string        `json:"targetId"`
+		Details        map[string]any `json:"details"`
+		IP             *string        `json:"ip"`
+		CreatedAt      time.Time      `json:"createdAt"`
+	}
+)
+
+func ToAuditLogResponse(log *entity.AuditLog) AuditLogResponse {
+	return AuditLogResponse{
+		ID:             log.ID,
+		ActorAccountID: log.ActorAccountID,
+		Action:         log.Action,
+		TargetType:     log.TargetType,
+		TargetID:       log.TargetID,
+		Details:        log.Details,
+		IP:             log.IP,
+		CreatedAt:      log.CreatedAt,
+	}
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"haphap/swimo-api/internal/app/appconfig/entity"
+	"haphap/swimo-api/pkg/validator"
+	"time"
+)
+
+type (
+	AppConfigResponse struct {
+		GuestSignInEnabled bool      `json:"guestSignInEnabled"`
+		GuestActiveLimit   int       `json:"guestActiveLimit"`
+		UpdatedAt          time.Time `json:"updatedAt"`
+	}
+
+	UpdateAppConfigRequest struct {
+		GuestSignInEnabled *bool `json:"guestSignInEnabled"`
+		GuestActiveLimit   *int  `json:"guestActiveLimit"`
+	}
+)
+
+func ToAppConfigResponse(cfg *entity.AppConfig) AppConfigResponse {
+	return AppConfigResponse{
+		GuestSignInEnabled: cfg.GuestSignInEnabled,
+		GuestActiveLimit:   cfg.GuestActiveLimit,
+		UpdatedAt:          cfg.UpdatedAt,
+	}
+}
+
+// Apply merges the provided fields into cfg.
+func (r *UpdateAppConfigRequest) Apply(cfg *entity.AppConfig) {
+	if r.GuestSignInEnabled != nil {
+		cfg.GuestSignInEnabled = *r.GuestSignInEnabled
+	}
+	if r.GuestActiveLimit != nil {
+		cfg.GuestActiveLimit = *r.GuestActiveLimit
+	}
+}
+
+func (r *UpdateAppConfigRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if r.GuestSignInEnabled == nil && r.GuestActiveLimit == nil {
+		errors["body"] = "At least one field must be provided"
+	}
+
+	if r.GuestActiveLimit != nil && *r.GuestActiveLimit < 0 {
+		errors["guestActiveLimit"] = "Guest active limit cannot be negative"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"haphap/swimo-api/internal/app/admin/entity"
+	"haphap/swimo-api/pkg/rbac"
+	"haphap/swimo-api/pkg/validator"
+	"slices"
+	"strings"
+)
+
+type (
+	SetRolesRequest struct {
+		Roles []string `json:"roles"`
+	}
+
+	LinkCoachRequest struct {
+		SwimmerID string `json:"swimmerId"`
+	}
+
+	// SwimmerResponse is what a coach sees of a linked swimmer.
+	SwimmerResponse struct {
+		ID    string  `json:"id"`
+		Email string  `json:"email"`
+		Name  *string `json:"name"`
+	}
+)
+
+// Normalize lowercases, dedupes and sorts the roles.
+func (r *SetRolesRequest) Normalize() {
+	roles := make([]string, 0, len(r.Roles))
+	for _, role := range r.Roles {
+		roles = append(roles, strings.TrimSpace(strings.ToLower(role)))
+	}
+	slices.Sort(roles)
+	r.Roles = slices.Compact(roles)
+}
+
+func (r *SetRolesRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if len(r.Roles) == 0 {
+		errors["roles"] = "At least one role is required"
+	}
+	for _, role := range r.Roles {
+		if !rbac.IsValidRole(role) {
+			errors["roles"] = "Roles must be one of: " + strings.Join(rbac.Roles(), ", ")
+			break
+		}
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (r *LinkCoachRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if !validator.UUIDPattern.MatchString(r.SwimmerID) {
+		errors["swimmerId"] = "Swimmer id must be a valid UUID"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func ToSwimmerResponse(account *entity.Account) SwimmerResponse {
+	return SwimmerResponse{ID: account.ID, Email: account.Email, Name: account.Name}
+}
@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+const (
+	ActionUpdateConfig   = "app_config.update"
+	ActionLockAccount    = "account.lock"
+	ActionUnlockAccount  = "account.unlock"
+	ActionRevokeSessions = "account.revoke_sessions"
+	ActionRevokeSession  = "session.revoke"
+	ActionUpdateRoles    = "account.roles_update"
+	ActionLinkCoach      = "coach.link"
+	ActionUnlinkCoach    = "coach.unlink"
+
+	TargetAppConfig = "app_config"
+	TargetAccount   = "account"
+	TargetSession   = "session"
+)
+
+type (
+	Account struct {
+		ID             string
+		Email          string
+		Name           *string
+		IsLocked       bool
+		Roles          []string
+		ActiveSessions int
+		CreatedAt      time.Time
+	}
+
+	AuditLog struct {
+		ID             string
+		ActorAccountID *string
+		Action         string
+		TargetType     string
+		TargetID       *string
+		Details        map[string]any
+		IP             *string
+		CreatedAt      time.Time
+	}
+)
@@ -0,0 +1,290 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/admin/entity"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrAccountNotFound = errors.New("account not found")
+	ErrSessionNotFound = errors.New("session not found")
+	ErrLinkNotFound    = errors.New("coach is not linked to swimmer")
+)
+
+type AdminRepository interface {
+	SearchAccounts(ctx context.Context, email string, limit, offset int) ([]entity.Account, int, error)
+	SetAccountLocked(ctx context.Context, tx pgx.Tx, accountID string, locked bool) error
+	RevokeAccountSessions(ctx context.Context, tx pgx.Tx, accountID string) (count int64, err error)
+	RevokeSession(ctx context.Context, tx pgx.Tx, sessionID string) error
+	CreateAuditLog(ctx context.Context, tx pgx.Tx, log *entity.AuditLog) error
+	ListAuditLogs(ctx context.Context, limit, offset int) ([]entity.AuditLog, error)
+	GetAccountRolesForUpdate(ctx context.Context, tx pgx.Tx, accountID string) ([]string, error)
+	SetAccountRoles(ctx context.Context, tx pgx.Tx, accountID string, roles []string) error
+	IsCoachOf(ctx context.Context, coachAccountID, swimmerAccountID string) (bool, error)
+	LinkCoach(ctx context.Context, tx pgx.Tx, coachAccountID, swimmerAccountID string) error
+	UnlinkCoach(ctx context.Context, tx pgx.Tx, coachAccountID, swimmerAccountID string) error
+	ListCoachSwimmers(ctx context.Context, coachAccountID string) ([]entity.Account, error)
+}
+
+type adminRepository struct{ db *pgxpool.Pool }
+
+func NewAdminRepository(db *pgxpool.Pool) AdminRepository { return &adminRepository{db: db} }
+
+// SearchAccounts matches emails by substring and trigram similarity, best matches first.
+func (r *adminRepository) SearchAccounts(ctx context.Context, email string, limit, offset int) ([]entity.Account, int, error) {
+	const sql = `
+		SELECT
+			a.id, a.email, u.name, a.is_locked,
+			ARRAY(SELECT r.role FROM account_roles AS r WHERE r.account_id = a.id ORDER BY r.role),
+			a.created_at,
+			(SELECT COUNT(*) FROM sessions AS s
+			 WHERE s.account_id = a.id AND s.revoked_at IS NULL AND s.refresh_expires_at > now()),
+			COUNT(*) OVER ()
+		FROM accounts AS a
+		LEFT JOIN users AS u ON u.account_id = a.id
+		WHERE $1 = ''
+		   OR a.email::text ILIKE '%' || $2 || '%'
+		   OR a.email::text % $1
+		ORDER BY similarity(a.email::text, $1) DESC, a.created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.Query(ctx, sql, email, escapeLike(email), limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	total := 0
+	accounts := make([]entity.Account, 0)
+	for rows.Next() {
+		var account entity.Account
+		if err := rows.Scan(
+			&account.ID,
+			&account.Email,
+			&account.Name,
+			&account.IsLocked,
+			&account.Roles,
+			&account.CreatedAt,
+			&account.ActiveSessions,
+			&total,
+		); err != nil {
+			return nil, 0, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, total, rows.Err()
+}
+
+func (r *adminRepository) SetAccountLocked(ctx context.Context, tx pgx.Tx, accountID string, locked bool) error {
+	const sql = `UPDATE accounts SET is_locked = $2, updated_at = now() WHERE id = $1`
+
+	tag, err := tx.Exec(ctx, sql, accountID, locked)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAccountNotFound
+	}
+
+	return nil
+}
+
+func (r *adminRepository) RevokeAccountSessions(ctx context.Context, tx pgx.Tx, accountID string) (count int64, err error) {
+	const sql = `UPDATE sessions SET revoked_at = now() WHERE account_id = $1 AND revoked_at IS NULL`
+
+	tag, err := tx.Exec(ctx, sql, accountID)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *adminRepository) RevokeSession(ctx context.Context, tx pgx.Tx, sessionID string) error {
+	const sql = `UPDATE sessions SET revoked_at = COALESCE(revoked_at, now()) WHERE id = $1`
+
+	tag, err := tx.Exec(ctx, sql, sessionID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *adminRepository) CreateAuditLog(ctx context.Context, tx pgx.Tx, log *entity.AuditLog) error {
+	const sql = `
+		INSERT INTO admin_audit_logs (actor_account_id, action, target_type, target_id, details, ip)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	details := log.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+
+	return tx.QueryRow(ctx, sql, log.ActorAccountID, log.Action, log.TargetType, log.TargetID, details, log.IP).
+		Scan(&log.ID, &log.CreatedAt)
+}
+
+func (r *adminRepository) ListAuditLogs(ctx context.Context, limit, offset int) ([]entity.AuditLog, error) {
+	const sql = `
+		SELECT id, actor_account_id, action, target_type, target_id, details, ip, created_at
+		FROM admin_audit_logs
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2`
+
+	rows, err := r.db.Query(ctx, sql, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := make([]entity.AuditLog, 0)
+	for rows.Next() {
+		var log entity.AuditLog
+		if err := rows.Scan(
+			&log.ID,
+			&log.ActorAccountID,
+			&log.Action,
+			&log.TargetType,
+			&log.TargetID,
+			&log.Details,
+			&log.IP,
+			&log.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		logs = append(logs, log)
+	}
+
+	return logs, rows.Err()
+}
+
+// GetAccountRolesForUpdate locks the account row so concurrent role changes serialize.
+func (r *adminRepository) GetAccountRolesForUpdate(ctx context.Context, tx pgx.Tx, accountID string) ([]string, error) {
+	const sql = `
+		SELECT ARRAY(SELECT r.role FROM account_roles AS r WHERE r.account_id = a.id ORDER BY r.role)
+		FROM accounts AS a
+		WHERE a.id = $1
+		FOR UPDATE OF a`
+
+	var roles []string
+	if err := tx.QueryRow(ctx, sql, accountID).Scan(&roles); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAccountNotFound
+		}
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// SetAccountRoles replaces the roles of an account with the given set.
+func (r *adminRepository) SetAccountRoles(ctx context.Context, tx pgx.Tx, accountID string, roles []string) error {
+	const deleteSQL = `DELETE FROM account_roles WHERE account_id = $1 AND role <> ALL($2::text[])`
+	const insertSQL = `
+		INSERT INTO account_roles (account_id, role)
+		SELECT $1, unnest($2::text[])
+		ON CONFLICT DO NOTHING`
+
+	if _, err := tx.Exec(ctx, deleteSQL, accountID, roles); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, insertSQL, accountID, roles)
+	return err
+}
+
+func (r *adminRepository) IsCoachOf(ctx context.Context, coachAccountID, swimmerAccountID string) (linked bool, err error) {
+	const sql = `
+		SELECT EXISTS (
+			SELECT 1 FROM coach_swimmers
+			WHERE coach_account_id = $1 AND swimmer_account_id = $2
+		)`
+
+	err = r.db.QueryRow(ctx, sql, coachAccountID, swimmerAccountID).Scan(&linked)
+	return linked, err
+}
+
+func (r *adminRepository) LinkCoach(ctx context.Context, tx pgx.Tx, coachAccountID, swimmerAccountID string) error {
+	const sql = `
+		INSERT INTO coach_swimmers (coach_account_id, swimmer_account_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`
+
+	if _, err := tx.Exec(ctx, sql, coachAccountID, swimmerAccountID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			return ErrAccountNotFound
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *adminRepository) UnlinkCoach(ctx context.Context, tx pgx.Tx, coachAccountID, swimmerAccountID string) error {
+	const sql = `DELETE FROM coach_swimmers WHERE coach_account_id = $1 AND swimmer_account_id = $2`
+
+	tag, err := tx.Exec(ctx, sql, coachAccountID, swimmerAccountID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLinkNotFound
+	}
+
+	return nil
+}
+
+func (r *adminRepository) ListCoachSwimmers(ctx context.Context, coachAccountID string) ([]entity.Account, error) {
+	const sql = `
+		SELECT
+			a.id, a.email, u.name, a.is_locked,
+			ARRAY(SELECT r.role FROM account_roles AS r WHERE r.account_id = a.id ORDER BY r.role),
+			a.created_at
+		FROM coach_swimmers AS cs
+		JOIN accounts AS a ON a.id = cs.swimmer_account_id
+		LEFT JOIN users AS u ON u.account_id = a.id
+		WHERE cs.coach_account_id = $1
+		ORDER BY cs.created_at`
+
+	rows, err := r.db.Query(ctx, sql, coachAccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := make([]entity.Account, 0)
+	for rows.Next() {
+		var account entity.Account
+		if err := rows.Scan(
+			&account.ID,
+			&account.Email,
+			&account.Name,
+			&account.IsLocked,
+			&account.Roles,
+			&account.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, rows.Err()
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
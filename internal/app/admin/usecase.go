@@ -0,0 +1,312 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/admin/dto"
+	"haphap/swimo-api/internal/app/admin/entity"
+	"haphap/swimo-api/internal/app/appconfig"
+	"haphap/swimo-api/pkg/rbac"
+	"haphap/swimo-api/pkg/response"
+	"log/slog"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrSelfAction = errors.New("admin cannot moderate own account")
+	ErrNotCoach   = errors.New("account does not have the coach role")
+)
+
+// Actor identifies the admin performing a change, recorded in the audit log.
+type Actor struct {
+	AccountID string
+	IP        string
+}
+
+type AdminUseCase interface {
+	GetAppConfig(ctx context.Context) (*dto.AppConfigResponse, error)
+	UpdateAppConfig(ctx context.Context, actor Actor, req dto.UpdateAppConfigRequest) (*dto.AppConfigResponse, error)
+	SearchAccounts(ctx context.Context, query dto.SearchAccountsQuery) ([]dto.AccountResponse, int, error)
+	SetAccountLocked(ctx context.Context, actor Actor, accountID string, locked bool) error
+	RevokeAccountSessions(ctx context.Context, actor Actor, accountID string) (int64, error)
+	RevokeSession(ctx context.Context, actor Actor, sessionID string) error
+	ListAuditLogs(ctx context.Context, query dto.ListAuditLogsQuery) ([]dto.AuditLogResponse, error)
+	SetAccountRoles(ctx context.Context, actor Actor, accountID string, req dto.SetRolesRequest) ([]string, error)
+	LinkCoach(ctx context.Context, actor Actor, coachID, swimmerID string) error
+	UnlinkCoach(ctx context.Context, actor Actor, coachID, swimmerID string) error
+	ListCoachSwimmers(ctx context.Context, coachID string) ([]dto.AccountResponse, error)
+	ListSwimmers(ctx context.Context, coachID string) ([]dto.SwimmerResponse, error)
+}
+
+type adminUseCase struct {
+	pool          *pgxpool.Pool
+	adminRepo     AdminRepository
+	appConfigRepo appconfig.AppConfigRepository
+	runtimeCfg    appconfig.Provider
+}
+
+func NewAdminUseCase(pool *pgxpool.Pool, adminRepo AdminRepository, appConfigRepo appconfig.AppConfigRepository, runtimeCfg appconfig.Provider) AdminUseCase {
+	return &adminUseCase{pool, adminRepo, appConfigRepo, runtimeCfg}
+}
+
+func (uc *adminUseCase) GetAppConfig(ctx context.Context) (*dto.AppConfigResponse, error) {
+	cfg, err := uc.appConfigRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := dto.ToAppConfigResponse(cfg)
+	return &out, nil
+}
+
+func (uc *adminUseCase) UpdateAppConfig(ctx context.Context, actor Actor, req dto.UpdateAppConfigRequest) (*dto.AppConfigResponse, error) {
+	cfg, err := uc.appConfigRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	before := dto.ToAppConfigResponse(cfg)
+
+	req.Apply(cfg)
+
+	err = uc.withAudit(ctx, actor, entity.ActionUpdateConfig, entity.TargetAppConfig, "",
+		map[string]any{
+			"before": map[string]any{"guestSignInEnabled": before.GuestSignInEnabled, "guestActiveLimit": before.GuestActiveLimit},
+			"after":  map[string]any{"guestSignInEnabled": cfg.GuestSignInEnabled, "guestActiveLimit": cfg.GuestActiveLimit},
+		},
+		func(tx pgx.Tx) error {
+			return uc.appConfigRepo.Update(ctx, tx, cfg)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Other instances pick the change up through NOTIFY, reload ours right away
+	if err := uc.runtimeCfg.Reload(ctx); err != nil {
+		slog.Warn("admin: reload app config failed", slog.String("err", err.Error()))
+	}
+
+	out := dto.ToAppConfigResponse(cfg)
+	return &out, nil
+}
+
+func (uc *adminUseCase) SearchAccounts(ctx context.Context, query dto.SearchAccountsQuery) ([]dto.AccountResponse, int, error) {
+	query.Normalize()
+
+	accounts, total, err := uc.adminRepo.SearchAccounts(ctx, query.Email, query.Limit, query.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]dto.AccountResponse, 0, len(accounts))
+	for i := range accounts {
+		out = append(out, dto.ToAccountResponse(&accounts[i]))
+	}
+
+	return out, total, nil
+}
+
+// SetAccountLocked also revokes every session when locking so the account is cut off immediately.
+func (uc *adminUseCase) SetAccountLocked(ctx context.Context, actor Actor, accountID string, locked bool) error {
+	if accountID == actor.AccountID {
+		return ErrSelfAction
+	}
+
+	action := entity.ActionUnlockAccount
+	if locked {
+		action = entity.ActionLockAccount
+	}
+
+	details := map[string]any{}
+	return uc.withAudit(ctx, actor, action, entity.TargetAccount, accountID, details, func(tx pgx.Tx) error {
+		if err := uc.adminRepo.SetAccountLocked(ctx, tx, accountID, locked); err != nil {
+			return err
+		}
+
+		if locked {
+			count, err := uc.adminRepo.RevokeAccountSessions(ctx, tx, accountID)
+			if err != nil {
+				return err
+			}
+			details["revokedSessions"] = count
+		}
+
+		return nil
+	})
+}
+
+func (uc *adminUseCase) RevokeAccountSessions(ctx context.Context, actor Actor, accountID string) (count int64, err error) {
+	details := map[string]any{}
+	err = uc.withAudit(ctx, actor, entity.ActionRevokeSessions, entity.TargetAccount, accountID, details, func(tx pgx.Tx) error {
+		count, err = uc.adminRepo.RevokeAccountSessions(ctx, tx, accountID)
+		details["revokedSessions"] = count
+		return err
+	})
+
+	return count, err
+}
+
+func (uc *adminUseCase) RevokeSession(ctx context.Context, actor Actor, sessionID string) error {
+	return uc.withAudit(ctx, actor, entity.ActionRevokeSession, entity.TargetSession, sessionID, nil, func(tx pgx.Tx) error {
+		return uc.adminRepo.RevokeSession(ctx, tx, sessionID)
+	})
+}
+
+func (uc *adminUseCase) ListAuditLogs(ctx context.Context, query dto.ListAuditLogsQuery) ([]dto.AuditLogResponse, error) {
+	limit, offset := response.NormalizePage(query.Limit, query.Offset)
+
+	logs, err := uc.adminRepo.ListAuditLogs(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]dto.AuditLogResponse, 0, len(logs))
+	for i := range logs {
+		out = append(out, dto.ToAuditLogResponse(&logs[i]))
+	}
+
+	return out, nil
+}
+
+// SetAccountRoles replaces the roles of an account. Removing a role revokes the sessions,
+// access tokens embed the roles and would otherwise keep it until they expire.
+func (uc *adminUseCase) SetAccountRoles(ctx context.Context, actor Actor, accountID string, req dto.SetRolesRequest) ([]string, error) {
+	req.Normalize()
+
+	if accountID == actor.AccountID && !rbac.HasRole(req.Roles, rbac.RoleAdmin) {
+		return nil, ErrSelfAction
+	}
+
+	details := map[string]any{"after": req.Roles}
+	err := uc.withAudit(ctx, actor, entity.ActionUpdateRoles, entity.TargetAccount, accountID, details, func(tx pgx.Tx) error {
+		before, err := uc.adminRepo.GetAccountRolesForUpdate(ctx, tx, accountID)
+		if err != nil {
+			return err
+		}
+		details["before"] = before
+
+		if err := uc.adminRepo.SetAccountRoles(ctx, tx, accountID, req.Roles); err != nil {
+			return err
+		}
+
+		removed := slices.ContainsFunc(before, func(role string) bool { return !slices.Contains(req.Roles, role) })
+		if removed {
+			count, err := uc.adminRepo.RevokeAccountSessions(ctx, tx, accountID)
+			if err != nil {
+				return err
+			}
+			details["revokedSessions"] = count
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return req.Roles, nil
+}
+
+func (uc *adminUseCase) LinkCoach(ctx context.Context, actor Actor, coachID, swimmerID string) error {
+	if coachID == swimmerID {
+		return ErrSelfAction
+	}
+
+	details := map[string]any{"coachId": coachID}
+	return uc.withAudit(ctx, actor, entity.ActionLinkCoach, entity.TargetAccount, swimmerID, details, func(tx pgx.Tx) error {
+		roles, err := uc.adminRepo.GetAccountRolesForUpdate(ctx, tx, coachID)
+		if err != nil {
+			return err
+		}
+		if !rbac.HasRole(roles, rbac.RoleCoach) {
+			return ErrNotCoach
+		}
+
+		return uc.adminRepo.LinkCoach(ctx, tx, coachID, swimmerID)
+	})
+}
+
+func (uc *adminUseCase) UnlinkCoach(ctx context.Context, actor Actor, coachID, swimmerID string) error {
+	details := map[string]any{"coachId": coachID}
+	return uc.withAudit(ctx, actor, entity.ActionUnlinkCoach, entity.TargetAccount, swimmerID, details, func(tx pgx.Tx) error {
+		return uc.adminRepo.UnlinkCoach(ctx, tx, coachID, swimmerID)
+	})
+}
+
+func (uc *adminUseCase) ListCoachSwimmers(ctx context.Context, coachID string) ([]dto.AccountResponse, error) {
+	accounts, err := uc.adminRepo.ListCoachSwimmers(ctx, coachID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]dto.AccountResponse, 0, len(accounts))
+	for i := range accounts {
+		out = append(out, dto.ToAccountResponse(&accounts[i]))
+	}
+
+	return out, nil
+}
+
+// withAudit runs change and writes the audit entry in the same transaction.
+// details is written after change returns, so change may still add to it.
+func (uc *adminUseCase) withAudit(ctx context.Context, actor Actor, action, targetType, targetID string, details map[string]any, change func(tx pgx.Tx) error) error {
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := change(tx); err != nil {
+		return err
+	}
+
+	log := &entity.AuditLog{
+		ActorAccountID: &actor.AccountID,
+		Action:         action,
+		TargetType:     targetType,
+		Details:        details,
+	}
+	if targetID != "" {
+		log.TargetID = &targetID
+	}
+	if actor.IP != "" {
+		log.IP = &actor.IP
+	}
+
+	if err := uc.adminRepo.CreateAuditLog(ctx, tx, log); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("admin: commit transaction failed", slog.String("action", action), slog.String("err", err.Error()))
+		return err
+	}
+
+	slog.Info("admin action",
+		slog.String("actor", actor.AccountID),
+		slog.String("action", action),
+		slog.String("target_type", targetType),
+		slog.String("target_id", targetID),
+	)
+	return nil
+}
+
+// ListSwimmers lists the swimmers linked to the calling coach.
+func (uc *adminUseCase) ListSwimmers(ctx context.Context, coachID string) ([]dto.SwimmerResponse, error) {
+	accounts, err := uc.adminRepo.ListCoachSwimmers(ctx, coachID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]dto.SwimmerResponse, 0, len(accounts))
+	for i := range accounts {
+		out = append(out, dto.ToSwimmerResponse(&accounts[i]))
+	}
+
+	return out, nil
+}
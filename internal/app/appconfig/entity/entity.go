@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+type (
+	AppConfig struct {
+		GuestSignInEnabled bool
+		GuestActiveLimit   int // <= 0 means unlimited
+		UpdatedAt          time.Time
+	}
+)
+
+// Default mirrors the column defaults of app_config, used when the row is missing.
+func Default() AppConfig {
+	return AppConfig{
+		GuestSignInEnabled: true,
+		GuestActiveLimit:   1000,
+	}
+}
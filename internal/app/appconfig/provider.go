@@ -0,0 +1,113 @@
+package appconfig
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/appconfig/entity"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const notifyChannel = "app_config_changed"
+
+// Provider serves the cached app_config row and keeps it fresh.
+type Provider interface {
+	Current() entity.AppConfig
+	Reload(ctx context.Context) error
+	Watch(ctx context.Context)
+}
+
+type provider struct {
+	pool     *pgxpool.Pool
+	repo     AppConfigRepository
+	interval time.Duration
+
+	mu      sync.RWMutex
+	current entity.AppConfig
+}
+
+func NewProvider(pool *pgxpool.Pool, repo AppConfigRepository, interval time.Duration) Provider {
+	return &provider{pool: pool, repo: repo, interval: interval, current: entity.Default()}
+}
+
+func (p *provider) Current() entity.AppConfig {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.current
+}
+
+func (p *provider) Reload(ctx context.Context) error {
+	cfg, err := p.repo.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	changed := p.current != *cfg
+	p.current = *cfg
+	p.mu.Unlock()
+
+	if changed {
+		slog.Info("app config loaded",
+			slog.Bool("guest_sign_in_enabled", cfg.GuestSignInEnabled),
+			slog.Int("guest_active_limit", cfg.GuestActiveLimit),
+		)
+	}
+	return nil
+}
+
+// Watch reloads on LISTEN/NOTIFY and on every interval as a fallback, until ctx is done.
+func (p *provider) Watch(ctx context.Context) {
+	for ctx.Err() == nil {
+		if err := p.listen(ctx); err != nil && ctx.Err() == nil {
+			slog.Warn("app config listen failed, retrying", slog.String("err", err.Error()))
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(p.interval):
+				p.reloadLogged(ctx)
+			}
+		}
+	}
+}
+
+func (p *provider) listen(ctx context.Context) error {
+	conn, err := p.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return err
+	}
+
+	// Catch up on changes made while we were not listening
+	p.reloadLogged(ctx)
+
+	for {
+		waitCtx, cancel := context.WithTimeout(ctx, p.interval)
+		_, err := conn.Conn().WaitForNotification(waitCtx)
+		cancel()
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+			// the connection is in an unknown state, do not hand it back to the pool
+			_ = conn.Conn().Close(context.Background())
+			return err
+		}
+
+		p.reloadLogged(ctx)
+	}
+}
+
+func (p *provider) reloadLogged(ctx context.Context) {
+	if err := p.Reload(ctx); err != nil && ctx.Err() == nil {
+		slog.Warn("app config reload failed", slog.String("err", err.Error()))
+	}
+}
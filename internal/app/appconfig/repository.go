@@ -0,0 +1,54 @@
+package appconfig
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/appconfig/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AppConfigRepository interface {
+	Get(ctx context.Context) (*entity.AppConfig, error)
+	Update(ctx context.Context, tx pgx.Tx, cfg *entity.AppConfig) error
+}
+
+type appConfigRepository struct{ db *pgxpool.Pool }
+
+func NewAppConfigRepository(db *pgxpool.Pool) AppConfigRepository {
+	return &appConfigRepository{db: db}
+}
+
+func (r *appConfigRepository) Get(ctx context.Context) (*entity.AppConfig, error) {
+	const sql = `SELECT guest_sign_in_enabled, guest_active_limit, updated_at FROM app_config WHERE id = true`
+
+	var cfg entity.AppConfig
+	if err := r.db.QueryRow(ctx, sql).Scan(
+		&cfg.GuestSignInEnabled,
+		&cfg.GuestActiveLimit,
+		&cfg.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			def := entity.Default()
+			return &def, nil
+		}
+
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func (r *appConfigRepository) Update(ctx context.Context, tx pgx.Tx, cfg *entity.AppConfig) error {
+	const sql = `
+		INSERT INTO app_config (id, guest_sign_in_enabled, guest_active_limit)
+		VALUES (true, $1, $2)
+		ON CONFLICT (id) DO UPDATE
+		SET guest_sign_in_enabled = EXCLUDED.guest_sign_in_enabled,
+		    guest_active_limit    = EXCLUDED.guest_active_limit,
+		    updated_at            = now()
+		RETURNING updated_at`
+
+	return tx.QueryRow(ctx, sql, cfg.GuestSignInEnabled, cfg.GuestActiveLimit).Scan(&cfg.UpdatedAt)
+}
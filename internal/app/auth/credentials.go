@@ -0,0 +1,262 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/auth/dto"
+	"haphap/swimo-api/internal/app/auth/entity"
+	"haphap/swimo-api/pkg/mailer"
+	"haphap/swimo-api/pkg/security"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrEmailUnchanged   = errors.New("new email is the current email")
+	ErrReauthRequired   = errors.New("re-authentication required")
+	ErrIdentityMismatch = errors.New("identity is linked to another account")
+)
+
+// ReauthTokenTTL bounds the reauth tokens from Reauthenticate, they are signed by the JWT keys.
+const ReauthTokenTTL = 5 * time.Minute
+
+// verifyCurrentPassword re-authenticates a signed-in user before a credential change.
+// Accounts without a password prove it with an authenticator code, or with a reauth token
+// from a fresh provider sign in on the same session, see Reauthenticate.
+func (uc *authUseCase) verifyCurrentPassword(ctx context.Context, auth *entity.Auth, sessionID string, req dto.Reauthentication) error {
+	if auth.PasswordHash == "" {
+		return uc.verifyPasswordless(ctx, auth, sessionID, req)
+	}
+
+	key := reauthThrottleKey(auth.AccountID)
+	if err := uc.checkThrottleKeys(ctx, key); err != nil {
+		return err
+	}
+
+	if err := auth.ComparePassword(req.CurrentPassword); err != nil {
+		uc.recordThrottleFailure(ctx, key, uc.cfg.Auth.LockoutThreshold)
+		return err
+	}
+
+	uc.clearThrottle(ctx, key)
+	return nil
+}
+
+func (uc *authUseCase) verifyPasswordless(ctx context.Context, auth *entity.Auth, sessionID string, req dto.Reauthentication) error {
+	switch {
+	case auth.MFAEnabled && strings.TrimSpace(req.MFACode) != "":
+		if err := uc.checkThrottleKeys(ctx, mfaThrottleKey(auth.AccountID)); err != nil {
+			return err
+		}
+		return uc.verifySecondFactor(ctx, auth.AccountID, req.MFACode, "")
+	case strings.TrimSpace(req.ReauthToken) != "":
+		claims, err := uc.keys.ParseReauthToken(strings.TrimSpace(req.ReauthToken))
+		if err != nil || claims.Sub != auth.AccountID || claims.SessionID != sessionID {
+			return ErrReauthRequired
+		}
+		return nil
+	default:
+		return ErrReauthRequired
+	}
+}
+
+// Reauthenticate completes a provider sign in started with StartOAuth and returns a
+// short-lived reauth token, when the provider identity is linked to the signed-in account.
+func (uc *authUseCase) Reauthenticate(ctx context.Context, accountID, sessionID, providerName string, req dto.OAuthCallbackRequest) (*dto.ReauthResponse, error) {
+	providerName = strings.ToLower(providerName)
+
+	claims, err := uc.exchangeOAuthCode(ctx, providerName, req)
+	if err != nil {
+		return nil, err
+	}
+
+	linkedID, err := uc.authRepo.TouchIdentity(ctx, providerName, claims.Subject)
+	if err != nil && !errors.Is(err, ErrIdentityUnknown) {
+		return nil, err
+	}
+	if linkedID != accountID {
+		slog.Warn("reauth: identity not linked to the account", slog.String("provider", providerName), slog.String("account_id", accountID))
+		return nil, ErrIdentityMismatch
+	}
+
+	token, exp, err := uc.keys.NewReauthToken(accountID, sessionID, ReauthTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("reauth success", slog.String("provider", providerName), slog.String("account_id", accountID))
+	return &dto.ReauthResponse{ReauthToken: token, ExpiresInMs: time.Until(exp).Milliseconds()}, nil
+}
+
+// ChangePassword keeps the current session and signs every other device out.
+func (uc *authUseCase) ChangePassword(ctx context.Context, accountID, sessionID string, req dto.ChangePasswordRequest) error {
+	auth, err := uc.authRepo.GetAuthByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.verifyCurrentPassword(ctx, auth, sessionID, req.Reauthentication); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := uc.authRepo.UpdatePassword(ctx, tx, accountID, string(hash)); err != nil {
+		return err
+	}
+
+	if err := uc.authRepo.InvalidateAccountTokens(ctx, tx, accountID, entity.TokenPurposePasswordReset); err != nil {
+		return err
+	}
+
+	if err := uc.authRepo.RevokeOtherSessions(ctx, tx, accountID, sessionID); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("change password: commit transaction failed", slog.String("account_id", accountID), slog.String("err", err.Error()))
+		return err
+	}
+
+	slog.Info("change password success", slog.String("account_id", accountID))
+
+	uc.sendAsync(uc.passwordChangedMessage(auth.Email))
+	return nil
+}
+
+// RequestEmailChange mails a confirmation token to the new address and warns the current one.
+// The account email only changes in ConfirmEmailChange.
+func (uc *authUseCase) RequestEmailChange(ctx context.Context, accountID, sessionID string, req dto.ChangeEmailRequest) error {
+	email := strings.TrimSpace(strings.ToLower(req.Email))
+
+	auth, err := uc.authRepo.GetAuthByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	if email == strings.ToLower(auth.Email) {
+		return ErrEmailUnchanged
+	}
+
+	if err := uc.verifyCurrentPassword(ctx, auth, sessionID, req.Reauthentication); err != nil {
+		return err
+	}
+
+	exists, err := uc.authRepo.EmailExists(ctx, email)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrAccountExists
+	}
+
+	since := time.Now().Add(-1 * time.Hour)
+	cnt, err := uc.authRepo.CountRecentAccountTokens(ctx, accountID, entity.TokenPurposeEmailChange, since)
+	if err != nil {
+		return err
+	}
+	if cnt >= emailChangePerHour {
+		return &ThrottledError{RetryAfter: time.Hour}
+	}
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Only the latest requested address can be confirmed
+	if err := uc.authRepo.InvalidateAccountTokens(ctx, tx, accountID, entity.TokenPurposeEmailChange); err != nil {
+		return err
+	}
+
+	token, err := entity.NewAccountToken(accountID, entity.TokenPurposeEmailChange, uc.cfg.Auth.EmailVerifyTTL)
+	if err != nil {
+		return err
+	}
+	token.NewEmail = email
+
+	if err := uc.authRepo.CreateAccountToken(ctx, tx, token); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("change email: commit transaction failed", slog.String("account_id", accountID), slog.String("err", err.Error()))
+		return err
+	}
+
+	if err := uc.mailer.Send(ctx, uc.emailChangeMessage(email, token.Token, uc.cfg.Auth.EmailVerifyTTL)); err != nil {
+		return err
+	}
+
+	slog.Info("email change requested", slog.String("account_id", accountID))
+
+	uc.sendAsync(uc.emailChangeRequestedMessage(auth.Email, email))
+	return nil
+}
+
+func (uc *authUseCase) ConfirmEmailChange(ctx context.Context, req dto.ConfirmEmailChangeRequest) error {
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	accountID, email, err := uc.authRepo.ConsumeEmailChangeToken(ctx, tx, security.SHA256Hex(strings.TrimSpace(req.Token)))
+	if err != nil {
+		return err
+	}
+
+	previous, err := uc.authRepo.UpdateEmail(ctx, tx, accountID, email)
+	if err != nil {
+		return err
+	}
+
+	// Links mailed to the previous address must not work anymore
+	for _, purpose := range []string{entity.TokenPurposeEmailChange, entity.TokenPurposeEmailVerify, entity.TokenPurposePasswordReset} {
+		if err := uc.authRepo.InvalidateAccountTokens(ctx, tx, accountID, purpose); err != nil {
+			return err
+		}
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("confirm email change: commit transaction failed", slog.String("account_id", accountID), slog.String("err", err.Error()))
+		return err
+	}
+
+	slog.Info("email change success", slog.String("account_id", accountID))
+
+	uc.sendAsync(uc.emailChangedMessage(previous, email))
+	return nil
+}
+
+// sendAsync delivers a notification in the background, failures are only logged.
+func (uc *authUseCase) sendAsync(msg mailer.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+
+		if err := uc.mailer.Send(ctx, msg); err != nil {
+			slog.Error("mail: send notification failed", slog.String("subject", msg.Subject), slog.String("err", err.Error()))
+		}
+	}()
+}
@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"context"
+	"haphap/swimo-api/internal/app/auth/dto"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const purgeBatchSize = 100
+
+// DeleteAccount schedules the purge after the grace period and signs every device out.
+// Signing in again before the purge cancels the deletion, see issueUserSession.
+func (uc *authUseCase) DeleteAccount(ctx context.Context, accountID, sessionID string, req dto.DeleteAccountRequest) (*dto.DeleteAccountResponse, error) {
+	auth, err := uc.authRepo.GetAuthByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.verifyCurrentPassword(ctx, auth, sessionID, req.Reauthentication); err != nil {
+		return nil, err
+	}
+
+	at := time.Now().Add(uc.cfg.Auth.DeletionGrace)
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := uc.authRepo.ScheduleDeletion(ctx, tx, accountID, at); err != nil {
+		return nil, err
+	}
+
+	if err := uc.authRepo.RevokeAllSessions(ctx, tx, accountID); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("delete account: commit transaction failed", slog.String("account_id", accountID), slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	slog.Info("account deletion scheduled", slog.String("account_id", accountID), slog.Time("at", at))
+
+	uc.sendAsync(uc.accountDeletionMessage(auth.Email, at))
+	return &dto.DeleteAccountResponse{DeletionScheduledAt: at}, nil
+}
+
+// cancelDeletion is called on every successful sign-in of an account scheduled for deletion.
+func (uc *authUseCase) cancelDeletion(ctx context.Context, accountID string) error {
+	cancelled, err := uc.authRepo.CancelDeletion(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if cancelled {
+		slog.Info("account deletion cancelled by sign-in", slog.String("account_id", accountID))
+	}
+
+	return nil
+}
+
+// ExportAccountData contributes the auth records to the account export.
+// Secrets (password hash, MFA secret, token hashes) are never exported.
+func (uc *authUseCase) ExportAccountData(ctx context.Context, accountID string) (map[string]any, error) {
+	auth, err := uc.authRepo.GetAuthByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := uc.authRepo.ListActiveSessions(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	sessionsOut := make([]dto.SessionResponse, 0, len(sessions))
+	for i := range sessions {
+		sessionsOut = append(sessionsOut, dto.ToSessionResponse(&sessions[i], ""))
+	}
+
+	identities, err := uc.authRepo.ListIdentities(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	identitiesOut := make([]dto.IdentityExport, 0, len(identities))
+	for i := range identities {
+		identitiesOut = append(identitiesOut, dto.ToIdentityExport(&identities[i]))
+	}
+
+	return map[string]any{
+		"account":    dto.ToAccountExport(auth),
+		"sessions":   sessionsOut,
+		"identities": identitiesOut,
+	}, nil
+}
+
+// PurgeHook runs before accounts are purged, to keep what other accounts still depend on.
+type PurgeHook interface {
+	BeforeAccountPurge(ctx context.Context, tx pgx.Tx, accountIDs []string) error
+}
+
+// AccountPurger deletes accounts whose deletion grace period is over.
+type AccountPurger struct {
+	pool     *pgxpool.Pool
+	authRepo AuthRepository
+	interval time.Duration
+	hooks    []PurgeHook
+}
+
+func NewAccountPurger(pool *pgxpool.Pool, authRepo AuthRepository, interval time.Duration, hooks ...PurgeHook) *AccountPurger {
+	return &AccountPurger{pool, authRepo, interval, hooks}
+}
+
+// Run purges once at start and then every interval until ctx is done.
+func (p *AccountPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purgeLogged(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *AccountPurger) purgeLogged(ctx context.Context) {
+	var total int64
+	for {
+		count, err := p.purgeBatch(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.Error("account purge failed", slog.String("err", err.Error()))
+			}
+			return
+		}
+
+		total += count
+		if count < purgeBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		slog.Info("accounts purged", slog.Int64("count", total))
+	}
+}
+
+// purgeBatch deletes up to purgeBatchSize accounts, the hooks run in the same transaction.
+func (p *AccountPurger) purgeBatch(ctx context.Context) (count int64, err error) {
+	// Transaction Start
+	tx, err := p.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	accountIDs, err := p.authRepo.LockPurgeableAccounts(ctx, tx, purgeBatchSize)
+	if err != nil || len(accountIDs) == 0 {
+		return 0, err
+	}
+
+	for _, hook := range p.hooks {
+		if err := hook.BeforeAccountPurge(ctx, tx, accountIDs); err != nil {
+			return 0, err
+		}
+	}
+
+	if count, err = p.authRepo.DeleteAccounts(ctx, tx, accountIDs); err != nil {
+		return 0, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("account purge: commit transaction failed", slog.String("err", err.Error()))
+		return 0, err
+	}
+
+	return count, nil
+}
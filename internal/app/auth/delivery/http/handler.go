@@ -5,9 +5,12 @@ import (
 	"haphap/swimo-api/internal/app/auth"
 	"haphap/swimo-api/internal/app/auth/dto"
 	"haphap/swimo-api/internal/app/auth/entity"
+	"haphap/swimo-api/internal/middleware"
 	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/validator"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -61,19 +64,33 @@ func (h *AuthHandler) SignIn(c *fiber.Ctx) error {
 
 	ua := string(c.Request().Header.UserAgent())
 	req.UserAgent = &ua
+	req.IP = c.IP()
 
-	out, err := h.authUsecase.SignIn(c.Context(), req)
+	out, challenge, err := h.authUsecase.SignIn(c.Context(), req)
 	if err != nil {
+		var throttled *auth.ThrottledError
 		switch {
+		case errors.As(err, &throttled):
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(throttled.RetryAfter.Seconds())))
+			return c.Status(http.StatusTooManyRequests).JSON(response.Base{Message: "Too many failed sign-in attempts. Please try again later."})
 		case errors.Is(err, entity.ErrInvalidCreds):
 			return c.Status(http.StatusUnauthorized).JSON(response.Base{Message: "Invalid Email or Passwords."})
 		case errors.Is(err, auth.ErrLocked):
 			return c.Status(http.StatusForbidden).JSON(response.Base{Message: "Your account has been locked."})
+		case errors.Is(err, auth.ErrEmailNotVerified):
+			return c.Status(http.StatusForbidden).JSON(response.Base{Message: "Please verify your email before signing in."})
 		default:
 			return err
 		}
 	}
 
+	if challenge != nil {
+		return c.Status(http.StatusOK).JSON(response.Base{
+			Data:    challenge,
+			Message: "Two-factor authentication required.",
+		})
+	}
+
 	return c.Status(http.StatusOK).JSON(response.Base{
 		Data:    out,
 		Message: "Sign-in successfull.",
@@ -100,3 +117,557 @@ func (h AuthHandler) SignInGuest(c *fiber.Ctx) error {
 		Message: "Guest sign-in successful.",
 	})
 }
+
+func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
+	var req dto.RefreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.authUsecase.Refresh(c.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrInvalidRefreshToken):
+			return c.Status(http.StatusUnauthorized).JSON(response.Base{Message: "Refresh token is invalid or expired."})
+		case errors.Is(err, auth.ErrRefreshTokenReused):
+			return c.Status(http.StatusUnauthorized).JSON(response.Base{Message: "Refresh token has already been used. Please sign in again."})
+		case errors.Is(err, auth.ErrLocked):
+			return c.Status(http.StatusForbidden).JSON(response.Base{Message: "Your account has been locked."})
+		default:
+			return err
+		}
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Token refreshed successfully.",
+	})
+}
+
+func (h *AuthHandler) SignOut(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	if err := h.authUsecase.SignOut(c.Context(), principal.SessionID); err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Signed out successfully."})
+}
+
+func (h *AuthHandler) SignOutAll(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	if err := h.authUsecase.SignOutAll(c.Context(), principal.AccountID); err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Signed out from all devices successfully."})
+}
+
+func (h *AuthHandler) ListSessions(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	out, err := h.authUsecase.ListSessions(c.Context(), principal.AccountID, principal.SessionID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Sessions retrieved successfully.",
+	})
+}
+
+func (h *AuthHandler) RevokeSession(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	sessionID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(sessionID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Session not found."})
+	}
+
+	if err := h.authUsecase.RevokeSession(c.Context(), principal.AccountID, sessionID); err != nil {
+		if errors.Is(err, auth.ErrSessionNotFound) {
+			return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Session not found."})
+		}
+
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Session revoked successfully."})
+}
+
+func (h *AuthHandler) UpgradeGuest(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var req dto.SignUpRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.authUsecase.UpgradeGuest(c.Context(), principal.SessionID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, auth.ErrAccountExists):
+			return c.Status(http.StatusConflict).JSON(response.Base{Message: "Email already exists."})
+		case errors.Is(err, auth.ErrSessionNotFound):
+			return c.Status(http.StatusUnauthorized).JSON(response.Base{Message: "Guest session is no longer valid."})
+		default:
+			return err
+		}
+	}
+
+	if out == nil {
+		return c.Status(http.StatusCreated).JSON(response.Base{Message: "Guest account upgraded, please verify your email before signing in."})
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.Base{
+		Data:    out,
+		Message: "Guest account upgraded successfully.",
+	})
+}
+
+func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	if err := h.authUsecase.ForgotPassword(c.Context(), req); err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusAccepted).JSON(response.Base{
+		Message: "If the email is registered, a password reset link has been sent.",
+	})
+}
+
+func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dto.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	if err := h.authUsecase.ResetPassword(c.Context(), req); err != nil {
+		if errors.Is(err, auth.ErrTokenNotFound) {
+			return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Reset token is invalid or expired."})
+		}
+
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Password has been reset. Please sign in again."})
+}
+
+func (h *AuthHandler) VerifyEmail(c *fiber.Ctx) error {
+	var req dto.VerifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	if err := h.authUsecase.VerifyEmail(c.Context(), req); err != nil {
+		if errors.Is(err, auth.ErrTokenNotFound) {
+			return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Verification token is invalid or expired."})
+		}
+
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Email verified successfully."})
+}
+
+func (h *AuthHandler) ResendVerification(c *fiber.Ctx) error {
+	var req dto.ResendVerificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	if err := h.authUsecase.ResendVerification(c.Context(), req); err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusAccepted).JSON(response.Base{
+		Message: "If the email is registered and not yet verified, a verification link has been sent.",
+	})
+}
+
+func (h *AuthHandler) SignInMFA(c *fiber.Ctx) error {
+	var req dto.SignInMFARequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	ua := string(c.Request().Header.UserAgent())
+	req.UserAgent = &ua
+	req.IP = c.IP()
+
+	out, err := h.authUsecase.SignInMFA(c.Context(), req)
+	if err != nil {
+		return mfaError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Sign-in successfull.",
+	})
+}
+
+func (h *AuthHandler) SetupTOTP(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	out, err := h.authUsecase.SetupTOTP(c.Context(), principal.AccountID)
+	if err != nil {
+		return mfaError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Scan the QR code with your authenticator app, then confirm with a code.",
+	})
+}
+
+func (h *AuthHandler) ConfirmTOTP(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var req dto.TOTPConfirmRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.authUsecase.ConfirmTOTP(c.Context(), principal.AccountID, req)
+	if err != nil {
+		return mfaError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Two-factor authentication enabled. Store the recovery codes somewhere safe.",
+	})
+}
+
+func (h *AuthHandler) DisableTOTP(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var req dto.TOTPDisableRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	if err := h.authUsecase.DisableTOTP(c.Context(), principal.AccountID, principal.SessionID, req); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidCreds):
+			return c.Status(http.StatusUnauthorized).JSON(response.Base{Message: "Password is incorrect."})
+		case errors.Is(err, auth.ErrReauthRequired):
+			return c.Status(http.StatusForbidden).JSON(response.Base{Message: "Re-authentication required. Send a reauthToken from POST /api/v1/me/reauthenticate/:provider."})
+		}
+
+		return mfaError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Two-factor authentication disabled."})
+}
+
+func mfaError(c *fiber.Ctx, err error) error {
+	var throttled *auth.ThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(throttled.RetryAfter.Seconds())))
+		return c.Status(http.StatusTooManyRequests).JSON(response.Base{Message: "Too many failed attempts. Please try again later."})
+	case errors.Is(err, auth.ErrInvalidMFAToken):
+		return c.Status(http.StatusUnauthorized).JSON(response.Base{Message: "MFA session is invalid or expired. Please sign in again."})
+	case errors.Is(err, auth.ErrInvalidMFACode):
+		return c.Status(http.StatusUnauthorized).JSON(response.Base{Message: "Invalid authentication code."})
+	case errors.Is(err, auth.ErrMFANotFound):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Two-factor authentication is not set up."})
+	case errors.Is(err, auth.ErrMFAAlreadyEnabled):
+		return c.Status(http.StatusConflict).JSON(response.Base{Message: "Two-factor authentication is already enabled."})
+	case errors.Is(err, auth.ErrMFAUnavailable):
+		return c.Status(http.StatusServiceUnavailable).JSON(response.Base{Message: "Two-factor authentication is not available."})
+	case errors.Is(err, auth.ErrLocked):
+		return c.Status(http.StatusForbidden).JSON(response.Base{Message: "Your account has been locked."})
+	default:
+		return err
+	}
+}
+
+func (h *AuthHandler) StartOAuth(c *fiber.Ctx) error {
+	out, err := h.authUsecase.StartOAuth(c.Context(), c.Params("provider"))
+	if err != nil {
+		return oauthError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Redirect the user to the authorization URL.",
+	})
+}
+
+func (h *AuthHandler) CompleteOAuth(c *fiber.Ctx) error {
+	var req dto.OAuthCallbackRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	ua := string(c.Request().Header.UserAgent())
+	req.UserAgent = &ua
+
+	out, challenge, err := h.authUsecase.CompleteOAuth(c.Context(), c.Params("provider"), req)
+	if err != nil {
+		return oauthError(c, err)
+	}
+
+	if challenge != nil {
+		return c.Status(http.StatusOK).JSON(response.Base{
+			Data:    challenge,
+			Message: "Two-factor authentication required.",
+		})
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Sign-in successfull.",
+	})
+}
+
+// Reauthenticate completes a provider sign in started from the signed-in session, see
+// StartOAuth, and returns the reauth token password-less accounts need for credential changes.
+func (h *AuthHandler) Reauthenticate(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var req dto.OAuthCallbackRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.authUsecase.Reauthenticate(c.Context(), principal.AccountID, principal.SessionID, c.Params("provider"), req)
+	if err != nil {
+		if errors.Is(err, auth.ErrIdentityMismatch) {
+			return c.Status(http.StatusForbidden).JSON(response.Base{Message: "This provider account is not linked to your account."})
+		}
+		return oauthError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Re-authentication successful.",
+	})
+}
+
+func oauthError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, auth.ErrUnknownProvider):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Sign-in provider not found."})
+	case errors.Is(err, auth.ErrInvalidOAuthState):
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Sign-in request is invalid or expired. Please try again."})
+	case errors.Is(err, auth.ErrOAuthFailed):
+		return c.Status(http.StatusUnauthorized).JSON(response.Base{Message: "Sign-in with the provider failed."})
+	case errors.Is(err, auth.ErrOAuthEmailRequired):
+		return c.Status(http.StatusUnprocessableEntity).JSON(response.Base{Message: "The provider did not share an email address."})
+	case errors.Is(err, auth.ErrOAuthEmailUnverified):
+		return c.Status(http.StatusConflict).JSON(response.Base{Message: "Email already registered. Sign in with your password first."})
+	case errors.Is(err, auth.ErrLocked):
+		return c.Status(http.StatusForbidden).JSON(response.Base{Message: "Your account has been locked."})
+	case errors.Is(err, auth.ErrEmailNotVerified):
+		return c.Status(http.StatusForbidden).JSON(response.Base{Message: "Please verify your email before signing in."})
+	default:
+		return err
+	}
+}
+
+// JWKS serves the raw key set (RFC 7517), not wrapped in response.Base, so standard JWT libraries can consume it.
+func (h *AuthHandler) JWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.Status(http.StatusOK).JSON(h.authUsecase.JWKS())
+}
+
+func (h *AuthHandler) ChangePassword(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var req dto.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	if err := h.authUsecase.ChangePassword(c.Context(), principal.AccountID, principal.SessionID, req); err != nil {
+		return credentialsError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Password changed. Other devices have been signed out."})
+}
+
+func (h *AuthHandler) RequestEmailChange(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var req dto.ChangeEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	if err := h.authUsecase.RequestEmailChange(c.Context(), principal.AccountID, principal.SessionID, req); err != nil {
+		return credentialsError(c, err)
+	}
+
+	return c.Status(http.StatusAccepted).JSON(response.Base{Message: "Check your new inbox to confirm the change."})
+}
+
+func (h *AuthHandler) ConfirmEmailChange(c *fiber.Ctx) error {
+	var req dto.ConfirmEmailChangeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	if err := h.authUsecase.ConfirmEmailChange(c.Context(), req); err != nil {
+		if errors.Is(err, auth.ErrTokenNotFound) {
+			return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Confirmation token is invalid or expired."})
+		}
+
+		return credentialsError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Email changed successfully."})
+}
+
+func (h *AuthHandler) DeleteAccount(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	// accounts without a password re-authenticate with mfaCode or reauthToken instead
+	var req dto.DeleteAccountRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+		}
+	}
+
+	out, err := h.authUsecase.DeleteAccount(c.Context(), principal.AccountID, principal.SessionID, req)
+	if err != nil {
+		return credentialsError(c, err)
+	}
+
+	return c.Status(http.StatusAccepted).JSON(response.Base{
+		Data:    out,
+		Message: "Account scheduled for deletion. Sign in before then to cancel.",
+	})
+}
+
+func credentialsError(c *fiber.Ctx, err error) error {
+	var throttled *auth.ThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(throttled.RetryAfter.Seconds())))
+		return c.Status(http.StatusTooManyRequests).JSON(response.Base{Message: "Too many attempts. Please try again later."})
+	case errors.Is(err, entity.ErrInvalidCreds):
+		return c.Status(http.StatusUnauthorized).JSON(response.Base{Message: "Current password is incorrect."})
+	case errors.Is(err, auth.ErrInvalidMFACode):
+		return c.Status(http.StatusUnauthorized).JSON(response.Base{Message: "Invalid authentication code."})
+	case errors.Is(err, auth.ErrReauthRequired):
+		return c.Status(http.StatusForbidden).JSON(response.Base{Message: "Re-authentication required. Send an mfaCode, or a reauthToken from POST /api/v1/me/reauthenticate/:provider."})
+	case errors.Is(err, auth.ErrAccountExists):
+		return c.Status(http.StatusConflict).JSON(response.Base{Message: "Email already exists."})
+	case errors.Is(err, auth.ErrEmailUnchanged):
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: &validator.ValidationError{
+				Errors: map[string]string{"email": "New email must be different from the current one"},
+			}},
+		)
+	default:
+		return err
+	}
+}
@@ -1,12 +1,42 @@
 package http
 
 import (
+	"haphap/swimo-api/internal/middleware"
+
 	"github.com/gofiber/fiber/v2"
 )
 
-func Register(app *fiber.App, authHandler *AuthHandler) {
+func Register(app *fiber.App, authHandler *AuthHandler, authMw *middleware.AuthMiddleware) {
+	app.Get("/.well-known/jwks.json", authHandler.JWKS)
+
 	apiV1 := app.Group("/api/v1")
 	apiV1.Post("/sign-in", authHandler.SignIn)
+	apiV1.Post("/sign-in/mfa", authHandler.SignInMFA)
 	apiV1.Post("/sign-in-guest", authHandler.SignInGuest)
 	apiV1.Post("/sign-up", authHandler.SignUp)
+	apiV1.Post("/refresh", authHandler.Refresh)
+	apiV1.Post("/password/forgot", authHandler.ForgotPassword)
+	apiV1.Post("/password/reset", authHandler.ResetPassword)
+	apiV1.Post("/email/verify", authHandler.VerifyEmail)
+	apiV1.Post("/email/verify/resend", authHandler.ResendVerification)
+	apiV1.Post("/email/change/confirm", authHandler.ConfirmEmailChange)
+	apiV1.Post("/oauth/:provider/authorize", authHandler.StartOAuth)
+	apiV1.Post("/oauth/:provider/callback", authHandler.CompleteOAuth)
+
+	apiV1.Post("/sign-out", authMw.Require(middleware.GuestAllowed), authHandler.SignOut)
+	apiV1.Post("/sign-out-all", authMw.Require(middleware.UserOnly), authHandler.SignOutAll)
+	apiV1.Get("/sessions", authMw.Require(middleware.UserOnly), authHandler.ListSessions)
+	apiV1.Delete("/sessions/:id", authMw.Require(middleware.UserOnly), authHandler.RevokeSession)
+
+	apiV1.Post("/me/password", authMw.Require(middleware.UserOnly), authHandler.ChangePassword)
+	apiV1.Post("/me/email", authMw.Require(middleware.UserOnly), authHandler.RequestEmailChange)
+	apiV1.Delete("/me", authMw.Require(middleware.UserOnly), authHandler.DeleteAccount)
+	apiV1.Post("/me/reauthenticate/:provider", authMw.Require(middleware.UserOnly), authHandler.Reauthenticate)
+
+	apiV1.Post("/guest/upgrade", authMw.Require(middleware.GuestOnly), authHandler.UpgradeGuest)
+
+	mfa := apiV1.Group("/mfa/totp", authMw.Require(middleware.UserOnly))
+	mfa.Post("/setup", authHandler.SetupTOTP)
+	mfa.Post("/confirm", authHandler.ConfirmTOTP)
+	mfa.Post("/disable", authHandler.DisableTOTP)
 }
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"haphap/swimo-api/internal/app/auth/entity"
+	"time"
+)
+
+type (
+	DeleteAccountRequest struct {
+		Reauthentication
+	}
+
+	DeleteAccountResponse struct {
+		DeletionScheduledAt time.Time `json:"deletionScheduledAt"`
+	}
+
+	AccountExport struct {
+		Email               string     `json:"email"`
+		EmailVerifiedAt     *time.Time `json:"emailVerifiedAt"`
+		Roles               []string   `json:"roles"`
+		MFAEnabled          bool       `json:"mfaEnabled"`
+		DeletionScheduledAt *time.Time `json:"deletionScheduledAt"`
+	}
+
+	IdentityExport struct {
+		Provider    string    `json:"provider"`
+		Subject     string    `json:"subject"`
+		Email       *string   `json:"email"`
+		CreatedAt   time.Time `json:"createdAt"`
+		LastLoginAt time.Time `json:"lastLoginAt"`
+	}
+)
+
+func ToAccountExport(auth *entity.Auth) AccountExport {
+	return AccountExport{
+		Email:               auth.Email,
+		EmailVerifiedAt:     auth.EmailVerifiedAt,
+		Roles:               auth.Roles,
+		MFAEnabled:          auth.MFAEnabled,
+		DeletionScheduledAt: auth.DeletionAt,
+	}
+}
+
+func ToIdentityExport(identity *entity.Identity) IdentityExport {
+	return IdentityExport{
+		Provider:    identity.Provider,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		CreatedAt:   identity.CreatedAt,
+		LastLoginAt: identity.LastLoginAt,
+	}
+}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"haphap/swimo-api/pkg/validator"
+	"strings"
+)
+
+type (
+	// Reauthentication proves the caller owns the account before a credential change. Accounts
+	// with a password send it, password-less ones an authenticator code or a reauth token.
+	Reauthentication struct {
+		CurrentPassword string `json:"currentPassword"`
+		MFACode         string `json:"mfaCode"`
+		ReauthToken     string `json:"reauthToken"`
+	}
+
+	ChangePasswordRequest struct {
+		Reauthentication
+		Password        string `json:"password"`
+		ConfirmPassword string `json:"confirmPassword"`
+	}
+
+	ChangeEmailRequest struct {
+		Email string `json:"email"`
+		Reauthentication
+	}
+
+	ReauthResponse struct {
+		ReauthToken string `json:"reauthToken"`
+		ExpiresInMs int64  `json:"expiresInMs"`
+	}
+
+	ConfirmEmailChangeRequest struct {
+		Token string `json:"token"`
+	}
+)
+
+// Validate leaves currentPassword optional, accounts created through social login have none,
+// the usecase checks whichever re-authentication the account needs.
+func (r *ChangePasswordRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	validateNewPassword(errors, r.Password, r.ConfirmPassword)
+
+	if r.CurrentPassword != "" && r.CurrentPassword == r.Password {
+		errors["password"] = "New password must be different from the current one"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (r *ChangeEmailRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	sanitizedEmail := strings.TrimSpace(strings.ToLower(r.Email))
+	if sanitizedEmail == "" {
+		errors["email"] = "Email is required"
+	} else if !validator.EmailPattern.MatchString(sanitizedEmail) {
+		errors["email"] = "Email is not a valid format"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (r *ConfirmEmailChangeRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if strings.TrimSpace(r.Token) == "" {
+		errors["token"] = "Token is required"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
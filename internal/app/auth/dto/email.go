@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"haphap/swimo-api/pkg/validator"
+	"strings"
+)
+
+type (
+	VerifyEmailRequest struct {
+		Token string `json:"token"`
+	}
+
+	ResendVerificationRequest struct {
+		Email string `json:"email"`
+	}
+)
+
+func (r *VerifyEmailRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if strings.TrimSpace(r.Token) == "" {
+		errors["token"] = "Token is required"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (r *ResendVerificationRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	sanitizedEmail := strings.TrimSpace(strings.ToLower(r.Email))
+	if sanitizedEmail == "" {
+		errors["email"] = "Email is required"
+	} else if !validator.EmailPattern.MatchString(sanitizedEmail) {
+		errors["email"] = "Email is not a valid format"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
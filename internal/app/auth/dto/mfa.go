@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"haphap/swimo-api/pkg/validator"
+	"strings"
+)
+
+type (
+	TOTPSetupResponse struct {
+		Secret     string `json:"secret"`
+		OTPAuthURI string `json:"otpauthUri"`
+	}
+
+	TOTPConfirmRequest struct {
+		Code string `json:"code"`
+	}
+
+	TOTPConfirmResponse struct {
+		RecoveryCodes []string `json:"recoveryCodes"`
+	}
+
+	// TOTPDisableRequest re-authenticates with the password, or a reauth token for
+	// accounts without one: the code is the factor being removed.
+	TOTPDisableRequest struct {
+		Password    string `json:"password"`
+		ReauthToken string `json:"reauthToken"`
+		Code        string `json:"code"` // authenticator or recovery code
+	}
+
+	SignInMFARequest struct {
+		MFAToken     string `json:"mfaToken"`
+		Code         string `json:"code"`
+		RecoveryCode string `json:"recoveryCode"`
+		UserAgent    *string
+		IP           string
+	}
+)
+
+func (r *TOTPConfirmRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if strings.TrimSpace(r.Code) == "" {
+		errors["code"] = "Code is required"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (r *TOTPDisableRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if r.Password == "" && strings.TrimSpace(r.ReauthToken) == "" {
+		errors["password"] = "Password or reauth token is required"
+	}
+	if strings.TrimSpace(r.Code) == "" {
+		errors["code"] = "Code is required"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (r *SignInMFARequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if strings.TrimSpace(r.MFAToken) == "" {
+		errors["mfaToken"] = "MFA token is required"
+	}
+	if strings.TrimSpace(r.Code) == "" && strings.TrimSpace(r.RecoveryCode) == "" {
+		errors["code"] = "Code or recovery code is required"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"haphap/swimo-api/pkg/validator"
+	"strings"
+)
+
+type (
+	OAuthStartResponse struct {
+		AuthorizationURL string `json:"authorizationUrl"`
+		State            string `json:"state"`
+	}
+
+	OAuthCallbackRequest struct {
+		Code      string `json:"code"`
+		State     string `json:"state"`
+		UserAgent *string
+	}
+)
+
+func (r *OAuthCallbackRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if strings.TrimSpace(r.Code) == "" {
+		errors["code"] = "Code is required"
+	}
+	if strings.TrimSpace(r.State) == "" {
+		errors["state"] = "State is required"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
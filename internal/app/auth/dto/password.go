@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"haphap/swimo-api/pkg/validator"
+	"strings"
+)
+
+type (
+	ForgotPasswordRequest struct {
+		Email string `json:"email"`
+	}
+
+	ResetPasswordRequest struct {
+		Token           string `json:"token"`
+		Password        string `json:"password"`
+		ConfirmPassword string `json:"confirmPassword"`
+	}
+)
+
+func (r *ForgotPasswordRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	sanitizedEmail := strings.TrimSpace(strings.ToLower(r.Email))
+	if sanitizedEmail == "" {
+		errors["email"] = "Email is required"
+	} else if !validator.EmailPattern.MatchString(sanitizedEmail) {
+		errors["email"] = "Email is not a valid format"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (r *ResetPasswordRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if strings.TrimSpace(r.Token) == "" {
+		errors["token"] = "Token is required"
+	}
+
+	validateNewPassword(errors, r.Password, r.ConfirmPassword)
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func validateNewPassword(errors map[string]string, password, confirmPassword string) {
+	if password == "" {
+		errors["password"] = "Password is required"
+	} else if len(password) < 8 {
+		errors["password"] = "Password must be at least 8 characters"
+	}
+
+	if confirmPassword == "" {
+		errors["confirmPassword"] = "Confirm password is required"
+	}
+	if password != confirmPassword {
+		errors["confirmPassword"] = "Confirm passwords do not match"
+	}
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"haphap/swimo-api/pkg/validator"
+	"strings"
+)
+
+type (
+	RefreshRequest struct {
+		RefreshToken string `json:"refreshToken"`
+	}
+
+	RefreshResponse struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refreshToken"`
+		ExpiresInMs  int64  `json:"expiresIn"`
+	}
+)
+
+func (r *RefreshRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	r.RefreshToken = strings.TrimSpace(r.RefreshToken)
+	if r.RefreshToken == "" {
+		errors["refreshToken"] = "Refresh token is required"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
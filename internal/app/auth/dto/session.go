@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"haphap/swimo-api/internal/app/auth/entity"
+	"time"
+)
+
+type (
+	SessionResponse struct {
+		ID         string    `json:"id"`
+		UserAgent  *string   `json:"userAgent"`
+		CreatedAt  time.Time `json:"createdAt"`
+		LastSeenAt time.Time `json:"lastSeenAt"`
+		Current    bool      `json:"current"`
+	}
+)
+
+func ToSessionResponse(session *entity.Session, currentSessionID string) SessionResponse {
+	return SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		Current:    session.ID == currentSessionID,
+	}
+}
@@ -10,17 +10,26 @@ type (
 		Email     string `json:"email"`
 		Password  string `json:"password"`
 		UserAgent *string
+		IP        string
 	}
 
 	SignInResponse struct {
-		Name         string   `json:"name"`
-		Weight       *float64 `json:"weight"`
-		Height       *float64 `json:"height"`
-		Age          *int16   `json:"age"`
-		Email        string   `json:"email"`
-		Token        string   `json:"token"`
-		RefreshToken string   `json:"refreshToken"`
-		ExpiresInMs  int64    `json:"expiresIn"`
+		Name          string   `json:"name"`
+		Weight        *float64 `json:"weight"`
+		Height        *float64 `json:"height"`
+		Age           *int     `json:"age"`
+		Email         string   `json:"email"`
+		EmailVerified bool     `json:"emailVerified"`
+		Token         string   `json:"token"`
+		RefreshToken  string   `json:"refreshToken"`
+		ExpiresInMs   int64    `json:"expiresIn"`
+	}
+
+	// MFAChallengeResponse replaces the tokens when the account requires a second factor.
+	MFAChallengeResponse struct {
+		MFARequired bool   `json:"mfaRequired"`
+		MFAToken    string `json:"mfaToken"`
+		ExpiresInMs int64  `json:"expiresIn"`
 	}
 
 	SignInGuestResponse struct {
@@ -2,8 +2,10 @@ package dto
 
 import (
 	"haphap/swimo-api/internal/app/auth/entity"
+	"haphap/swimo-api/pkg/dates"
 	"haphap/swimo-api/pkg/validator"
 	"strings"
+	"time"
 )
 
 type (
@@ -14,7 +16,7 @@ type (
 		Name            string   `json:"name"`
 		Weight          *float64 `json:"weight"`
 		Height          *float64 `json:"height"`
-		Age             *int16   `json:"age"`
+		BirthDate       *string  `json:"birthDate"` // YYYY-MM-DD
 	}
 )
 
@@ -24,10 +26,22 @@ func (r *SignUpRequest) ToUserEntity(accountID string) *entity.User {
 		Name:      strings.TrimSpace(r.Name),
 		WeightKG:  r.Weight,
 		HeightCM:  r.Height,
-		AgeYears:  r.Age,
+		BirthDate: r.birthDate(),
 	}
 }
 
+func (r *SignUpRequest) birthDate() *time.Time {
+	if r.BirthDate == nil {
+		return nil
+	}
+
+	t, err := dates.Parse(*r.BirthDate)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
 func (r *SignUpRequest) Validate() error {
 	errors := make(map[string]string)
 
@@ -36,18 +50,7 @@ func (r *SignUpRequest) Validate() error {
 		errors["email"] = "Email is not a valid format"
 	}
 
-	if r.Password == "" {
-		errors["password"] = "Password is required"
-	} else if len(r.Password) < 8 {
-		errors["password"] = "Password must be at least 8 characters"
-	}
-
-	if r.ConfirmPassword == "" {
-		errors["confirmPassword"] = "Confirm password is required"
-	}
-	if r.Password != r.ConfirmPassword {
-		errors["confirmPassword"] = "Confirm passwords do not match"
-	}
+	validateNewPassword(errors, r.Password, r.ConfirmPassword)
 
 	if strings.TrimSpace(r.Name) == "" {
 		errors["name"] = "Name is required"
@@ -65,10 +68,10 @@ func (r *SignUpRequest) Validate() error {
 		errors["height"] = "Height cannot be negative"
 	}
 
-	if r.Age == nil {
-		errors["age"] = "Age is required"
-	} else if *r.Age < 0 {
-		errors["age"] = "Age cannot be negative"
+	if r.BirthDate == nil {
+		errors["birthDate"] = "Birth date is required"
+	} else if msg := validator.BirthDate(*r.BirthDate); msg != "" {
+		errors["birthDate"] = msg
 	}
 
 	if len(errors) > 0 {
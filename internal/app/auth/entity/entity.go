@@ -1,9 +1,12 @@
 package entity
 
 import (
+	"crypto/rand"
+	"encoding/base32"
 	"errors"
 	"haphap/swimo-api/config"
 	"haphap/swimo-api/pkg/security"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -13,6 +16,12 @@ var (
 	ErrInvalidCreds = errors.New("invalid email or passwords")
 )
 
+const (
+	TokenPurposePasswordReset = "password_reset"
+	TokenPurposeEmailVerify   = "email_verify"
+	TokenPurposeEmailChange   = "email_change"
+)
+
 type (
 	User struct {
 		ID        string
@@ -20,26 +29,71 @@ type (
 		Name      string
 		WeightKG  *float64
 		HeightCM  *float64
-		AgeYears  *int16
+		BirthDate *time.Time
 	}
 
 	Auth struct {
-		AccountID    string
-		Email        string
-		PasswordHash string
-		IsLocked     bool
-		Name         string
-		WeightKG     *float64
-		HeightCM     *float64
-		AgeYears     *int16
+		AccountID       string
+		Email           string
+		PasswordHash    string
+		IsLocked        bool
+		EmailVerifiedAt *time.Time
+		MFAEnabled      bool
+		Roles           []string
+		DeletionAt      *time.Time // scheduled purge, nil unless the user deleted the account
+		Name            string
+		WeightKG        *float64
+		HeightCM        *float64
+		BirthDate       *time.Time
 	}
 
 	Session struct {
+		ID               string
 		AccountID        *string
+		Kind             string
+		RefreshToken     string // plain token, only returned to the client and never stored
 		RefreshTokenHash *string
 		ExpiresAt        *time.Time
 		RefreshExpiresAt *time.Time
+		RevokedAt        *time.Time
 		UserAgent        *string
+		IsLocked         bool
+		CreatedAt        time.Time
+		LastSeenAt       time.Time
+	}
+
+	MFA struct {
+		AccountID    string
+		SecretEnc    []byte
+		EnabledAt    *time.Time
+		LastUsedStep *int64
+	}
+
+	OAuthState struct {
+		State        string // plain state, only sent to the client
+		StateHash    string
+		Provider     string
+		CodeVerifier string
+		Nonce        string
+		ExpiresAt    time.Time
+	}
+
+	Identity struct {
+		Provider    string
+		Subject     string
+		Email       *string
+		CreatedAt   time.Time
+		LastLoginAt time.Time
+	}
+
+	AccountToken struct {
+		ID        string
+		AccountID string
+		Purpose   string
+		Token     string // plain token, only sent to the user and never stored
+		TokenHash string
+		NewEmail  string // email_change only
+		ExpiresAt time.Time
 	}
 )
 
@@ -52,10 +106,23 @@ func (u *Auth) ComparePassword(password string) error {
 }
 
 func NewSession(cfg *config.Config, userAgent *string, accountId *string) (*Session, error) {
+	session := &Session{
+		AccountID: accountId,
+		UserAgent: userAgent,
+	}
+
+	if err := session.Rotate(cfg); err != nil {
+		return nil, err
+	}
 
+	return session, nil
+}
+
+// Rotate replaces the refresh token and extends the session windows.
+func (s *Session) Rotate(cfg *config.Config) error {
 	refreshToken, err := security.NewOpaqueRefreshToken(32)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	refreshTokenHash := security.SHA256Hex(refreshToken)
 
@@ -63,11 +130,72 @@ func NewSession(cfg *config.Config, userAgent *string, accountId *string) (*Sess
 	expiresAt := now.Add(cfg.Auth.JWTAccessTTL)
 	refreshExp := now.Add(cfg.Auth.JWTRefreshTTL)
 
-	return &Session{
-		AccountID:        accountId,
-		UserAgent:        userAgent,
-		ExpiresAt:        &expiresAt,
-		RefreshTokenHash: &refreshTokenHash,
-		RefreshExpiresAt: &refreshExp,
+	s.RefreshToken = refreshToken
+	s.RefreshTokenHash = &refreshTokenHash
+	s.ExpiresAt = &expiresAt
+	s.RefreshExpiresAt = &refreshExp
+
+	return nil
+}
+
+func (s *Session) IsRefreshExpired() bool {
+	return s.RefreshExpiresAt == nil || time.Now().After(*s.RefreshExpiresAt)
+}
+
+func NewAccountToken(accountID, purpose string, ttl time.Duration) (*AccountToken, error) {
+	token, err := security.NewOpaqueRefreshToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountToken{
+		AccountID: accountID,
+		Purpose:   purpose,
+		Token:     token,
+		TokenHash: security.SHA256Hex(token),
+		ExpiresAt: time.Now().Add(ttl),
 	}, nil
 }
+
+// LockoutDuration returns how long to lock after failures, doubling from base once threshold is reached.
+func LockoutDuration(failures, threshold int, base, max time.Duration) time.Duration {
+	if threshold <= 0 || failures < threshold {
+		return 0
+	}
+
+	d := base
+	for i := threshold; i < failures && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+
+	return d
+}
+
+func (m *MFA) IsEnabled() bool { return m != nil && m.EnabledAt != nil }
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewRecoveryCodes returns n plain codes (xxxxx-xxxxx) and their hashes.
+func NewRecoveryCodes(n int) (codes []string, hashes []string, err error) {
+	for i := 0; i < n; i++ {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+
+		raw := strings.ToLower(recoveryEncoding.EncodeToString(b))[:10]
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, HashRecoveryCode(raw))
+	}
+
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode ignores case, spaces and dashes so users can type codes loosely.
+func HashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+	return security.SHA256Hex(normalized)
+}
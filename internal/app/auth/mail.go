@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"fmt"
+	"haphap/swimo-api/pkg/mailer"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// link builds an app link carrying token, or returns "" when no app url is configured.
+func (uc *authUseCase) link(path, token string) string {
+	if uc.cfg.Mail.AppURL == "" {
+		return ""
+	}
+
+	return strings.TrimRight(uc.cfg.Mail.AppURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+func (uc *authUseCase) passwordResetMessage(email, token string, ttl time.Duration) mailer.Message {
+	var body strings.Builder
+	body.WriteString("We received a request to reset your Swimo password.\n\n")
+	if link := uc.link("/reset-password", token); link != "" {
+		fmt.Fprintf(&body, "Open this link to choose a new password:\n%s\n\n", link)
+	}
+	fmt.Fprintf(&body, "Reset code: %s\n\nThe code expires in %s. If you did not ask for this, you can ignore this email.\n", token, ttl)
+
+	return mailer.Message{To: email, Subject: "Reset your Swimo password", Body: body.String()}
+}
+
+func (uc *authUseCase) emailVerificationMessage(email, token string, ttl time.Duration) mailer.Message {
+	var body strings.Builder
+	body.WriteString("Welcome to Swimo! Please confirm your email address.\n\n")
+	if link := uc.link("/verify-email", token); link != "" {
+		fmt.Fprintf(&body, "Open this link to verify your email:\n%s\n\n", link)
+	}
+	fmt.Fprintf(&body, "Verification code: %s\n\nThe code expires in %s.\n", token, ttl)
+
+	return mailer.Message{To: email, Subject: "Verify your Swimo email", Body: body.String()}
+}
+
+func (uc *authUseCase) emailChangeMessage(email, token string, ttl time.Duration) mailer.Message {
+	var body strings.Builder
+	body.WriteString("Please confirm this is the new email address of your Swimo account.\n\n")
+	if link := uc.link("/confirm-email-change", token); link != "" {
+		fmt.Fprintf(&body, "Open this link to confirm the change:\n%s\n\n", link)
+	}
+	fmt.Fprintf(&body, "Confirmation code: %s\n\nThe code expires in %s. Your account keeps its current email until then.\n", token, ttl)
+
+	return mailer.Message{To: email, Subject: "Confirm your new Swimo email", Body: body.String()}
+}
+
+func (uc *authUseCase) emailChangeRequestedMessage(email, newEmail string) mailer.Message {
+	body := fmt.Sprintf("Someone asked to change the email of your Swimo account to %s.\n\n"+
+		"Nothing changes until the new address is confirmed. If this was not you, change your password now.\n", newEmail)
+
+	return mailer.Message{To: email, Subject: "Your Swimo email is about to change", Body: body}
+}
+
+func (uc *authUseCase) emailChangedMessage(email, newEmail string) mailer.Message {
+	body := fmt.Sprintf("The email of your Swimo account was changed to %s.\n\n"+
+		"If this was not you, contact support right away.\n", newEmail)
+
+	return mailer.Message{To: email, Subject: "Your Swimo email was changed", Body: body}
+}
+
+func (uc *authUseCase) passwordChangedMessage(email string) mailer.Message {
+	body := "The password of your Swimo account was changed and your other devices were signed out.\n\n" +
+		"If this was not you, reset your password right away.\n"
+
+	return mailer.Message{To: email, Subject: "Your Swimo password was changed", Body: body}
+}
+
+func (uc *authUseCase) accountDeletionMessage(email string, at time.Time) mailer.Message {
+	body := fmt.Sprintf("Your Swimo account and all of its data will be deleted on %s.\n\n"+
+		"Changed your mind? Sign in before then and the deletion is cancelled.\n", at.UTC().Format("2 January 2006 15:04 MST"))
+
+	return mailer.Message{To: email, Subject: "Your Swimo account will be deleted", Body: body}
+}
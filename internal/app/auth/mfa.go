@@ -0,0 +1,287 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/auth/dto"
+	"haphap/swimo-api/internal/app/auth/entity"
+	"haphap/swimo-api/pkg/security"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrMFAUnavailable    = errors.New("mfa encryption key not configured")
+	ErrMFAAlreadyEnabled = errors.New("mfa already enabled")
+	ErrInvalidMFAToken   = errors.New("invalid mfa token")
+	ErrInvalidMFACode    = errors.New("invalid mfa code")
+)
+
+const (
+	mfaSkewSteps      = 1
+	recoveryCodeCount = 10
+)
+
+func (uc *authUseCase) mfaKey() ([]byte, error) {
+	key, err := security.ParseKey(uc.cfg.Auth.MFAKey)
+	if err != nil {
+		return nil, ErrMFAUnavailable
+	}
+
+	return key, nil
+}
+
+func (uc *authUseCase) mfaIssuer() string {
+	if uc.cfg.Auth.MFAIssuer != "" {
+		return uc.cfg.Auth.MFAIssuer
+	}
+
+	return "Swimo"
+}
+
+// newMFAChallenge mints a short-lived token that only proves the password step succeeded.
+// Its own typ and audience keep it from ever passing as an access token.
+func (uc *authUseCase) newMFAChallenge(accountID string) (*dto.MFAChallengeResponse, error) {
+	token, exp, err := uc.keys.NewMFAToken(accountID, uc.cfg.Auth.MFAChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MFAChallengeResponse{
+		MFARequired: true,
+		MFAToken:    token,
+		ExpiresInMs: time.Until(exp).Milliseconds(),
+	}, nil
+}
+
+func (uc *authUseCase) SignInMFA(ctx context.Context, req dto.SignInMFARequest) (*dto.SignInResponse, error) {
+	claims, err := uc.keys.ParseMFAToken(strings.TrimSpace(req.MFAToken))
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+	accountID := claims.Sub
+
+	if err := uc.checkThrottleKeys(ctx, mfaThrottleKey(accountID)); err != nil {
+		return nil, err
+	}
+
+	auth, err := uc.authRepo.GetAuthByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidCreds) {
+			return nil, ErrInvalidMFAToken
+		}
+		return nil, err
+	}
+
+	if auth.IsLocked {
+		return nil, ErrLocked
+	}
+
+	if err := uc.verifySecondFactor(ctx, accountID, req.Code, req.RecoveryCode); err != nil {
+		return nil, err
+	}
+
+	return uc.issueUserSession(ctx, auth, req.UserAgent)
+}
+
+// verifySecondFactor accepts either an authenticator code or an unused recovery code.
+// Wrong codes count towards the mfa lockout of the account.
+func (uc *authUseCase) verifySecondFactor(ctx context.Context, accountID, code, recoveryCode string) error {
+	ok := false
+
+	if strings.TrimSpace(code) != "" {
+		mfa, err := uc.authRepo.GetMFA(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		if !mfa.IsEnabled() {
+			return ErrMFANotFound
+		}
+
+		secret, err := uc.decryptSecret(mfa.SecretEnc)
+		if err != nil {
+			return err
+		}
+
+		if step, valid := security.ValidateTOTP(secret, code, time.Now(), mfaSkewSteps); valid {
+			if ok, err = uc.authRepo.UseMFAStep(ctx, accountID, step); err != nil {
+				return err
+			}
+		}
+	} else if strings.TrimSpace(recoveryCode) != "" {
+		var err error
+		if ok, err = uc.authRepo.UseRecoveryCode(ctx, accountID, entity.HashRecoveryCode(recoveryCode)); err != nil {
+			return err
+		}
+		if ok {
+			slog.Info("mfa: recovery code used", slog.String("account_id", accountID))
+		}
+	}
+
+	if !ok {
+		uc.recordThrottleFailure(ctx, mfaThrottleKey(accountID), uc.cfg.Auth.LockoutThreshold)
+		return ErrInvalidMFACode
+	}
+
+	uc.clearThrottle(ctx, mfaThrottleKey(accountID))
+	return nil
+}
+
+func (uc *authUseCase) decryptSecret(secretEnc []byte) (string, error) {
+	key, err := uc.mfaKey()
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := security.Decrypt(key, secretEnc)
+	if err != nil {
+		return "", err
+	}
+
+	return string(secret), nil
+}
+
+func (uc *authUseCase) SetupTOTP(ctx context.Context, accountID string) (*dto.TOTPSetupResponse, error) {
+	key, err := uc.mfaKey()
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := uc.authRepo.GetMFA(ctx, accountID)
+	if err != nil && !errors.Is(err, ErrMFANotFound) {
+		return nil, err
+	}
+	if mfa.IsEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	auth, err := uc.authRepo.GetAuthByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := security.NewTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	secretEnc, err := security.Encrypt(key, []byte(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.authRepo.SavePendingMFA(ctx, accountID, secretEnc); err != nil {
+		return nil, err
+	}
+
+	return &dto.TOTPSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: security.TOTPURI(uc.mfaIssuer(), auth.Email, secret),
+	}, nil
+}
+
+func (uc *authUseCase) ConfirmTOTP(ctx context.Context, accountID string, req dto.TOTPConfirmRequest) (*dto.TOTPConfirmResponse, error) {
+	if err := uc.checkThrottleKeys(ctx, mfaThrottleKey(accountID)); err != nil {
+		return nil, err
+	}
+
+	mfa, err := uc.authRepo.GetMFA(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.IsEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := uc.decryptSecret(mfa.SecretEnc)
+	if err != nil {
+		return nil, err
+	}
+
+	step, valid := security.ValidateTOTP(secret, req.Code, time.Now(), mfaSkewSteps)
+	if !valid {
+		uc.recordThrottleFailure(ctx, mfaThrottleKey(accountID), uc.cfg.Auth.LockoutThreshold)
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, hashes, err := entity.NewRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := uc.authRepo.EnableMFA(ctx, tx, accountID, step); err != nil {
+		return nil, err
+	}
+
+	if err := uc.authRepo.ReplaceRecoveryCodes(ctx, tx, accountID, hashes); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("mfa confirm: commit transaction failed", slog.String("account_id", accountID), slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	uc.clearThrottle(ctx, mfaThrottleKey(accountID))
+
+	slog.Info("mfa enabled", slog.String("account_id", accountID))
+	return &dto.TOTPConfirmResponse{RecoveryCodes: codes}, nil
+}
+
+func (uc *authUseCase) DisableTOTP(ctx context.Context, accountID, sessionID string, req dto.TOTPDisableRequest) error {
+	if err := uc.checkThrottleKeys(ctx, mfaThrottleKey(accountID)); err != nil {
+		return err
+	}
+
+	auth, err := uc.authRepo.GetAuthByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	// the code is the factor being removed, so it cannot also stand in for the password
+	reauth := dto.Reauthentication{CurrentPassword: req.Password, ReauthToken: req.ReauthToken}
+	if err := uc.verifyCurrentPassword(ctx, auth, sessionID, reauth); err != nil {
+		return err
+	}
+
+	// Six digits is an authenticator code, anything else is treated as a recovery code
+	code, recoveryCode := req.Code, ""
+	if len(strings.TrimSpace(req.Code)) != 6 {
+		code, recoveryCode = "", req.Code
+	}
+
+	if err := uc.verifySecondFactor(ctx, accountID, code, recoveryCode); err != nil {
+		return err
+	}
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := uc.authRepo.DeleteMFA(ctx, tx, accountID); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("mfa disable: commit transaction failed", slog.String("account_id", accountID), slog.String("err", err.Error()))
+		return err
+	}
+
+	slog.Info("mfa disabled", slog.String("account_id", accountID))
+	return nil
+}
@@ -0,0 +1,259 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/auth/dto"
+	"haphap/swimo-api/internal/app/auth/entity"
+	"haphap/swimo-api/pkg/oidc"
+	"haphap/swimo-api/pkg/rbac"
+	"haphap/swimo-api/pkg/security"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrUnknownProvider      = errors.New("unknown identity provider")
+	ErrInvalidOAuthState    = errors.New("invalid or expired oauth state")
+	ErrOAuthFailed          = errors.New("identity provider sign in failed")
+	ErrOAuthEmailRequired   = errors.New("identity provider did not share an email")
+	ErrOAuthEmailUnverified = errors.New("email already registered, sign in with password to link")
+)
+
+// IdentityProvider is an external OpenID Connect provider, implemented by oidc.Client.
+type IdentityProvider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*oidc.IDTokenClaims, error)
+}
+
+func (uc *authUseCase) provider(name string) (IdentityProvider, error) {
+	p, ok := uc.providers[strings.ToLower(name)]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	return p, nil
+}
+
+// StartOAuth stores a pending authorization request and returns the provider URL to redirect to.
+// The PKCE verifier and nonce never leave the server, the client only carries the state back.
+func (uc *authUseCase) StartOAuth(ctx context.Context, providerName string) (*dto.OAuthStartResponse, error) {
+	provider, err := uc.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &entity.OAuthState{
+		Provider:  strings.ToLower(providerName),
+		ExpiresAt: time.Now().Add(uc.cfg.OIDC.StateTTL),
+	}
+	if state.State, err = oidc.NewRandomString(32); err != nil {
+		return nil, err
+	}
+	if state.CodeVerifier, err = oidc.NewRandomString(48); err != nil {
+		return nil, err
+	}
+	if state.Nonce, err = oidc.NewRandomString(24); err != nil {
+		return nil, err
+	}
+	state.StateHash = security.SHA256Hex(state.State)
+
+	authURL, err := provider.AuthCodeURL(ctx, state.State, state.Nonce, state.CodeVerifier)
+	if err != nil {
+		slog.Error("oauth: build authorization url failed", slog.String("provider", state.Provider), slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	if err := uc.authRepo.SaveOAuthState(ctx, state); err != nil {
+		return nil, err
+	}
+
+	return &dto.OAuthStartResponse{AuthorizationURL: authURL, State: state.State}, nil
+}
+
+// CompleteOAuth exchanges the authorization code and signs the linked account in.
+// Accounts are resolved by linked identity first, then by a provider-verified email,
+// and otherwise a new password-less account is created. Like SignIn, it refuses
+// unverified emails when they are required.
+func (uc *authUseCase) CompleteOAuth(ctx context.Context, providerName string, req dto.OAuthCallbackRequest) (*dto.SignInResponse, *dto.MFAChallengeResponse, error) {
+	providerName = strings.ToLower(providerName)
+
+	claims, err := uc.exchangeOAuthCode(ctx, providerName, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accountID, err := uc.resolveIdentity(ctx, providerName, claims)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	auth, err := uc.authRepo.GetAuthByID(ctx, accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if auth.IsLocked {
+		return nil, nil, ErrLocked
+	}
+
+	if uc.cfg.Auth.RequireVerified && auth.EmailVerifiedAt == nil {
+		return nil, nil, ErrEmailNotVerified
+	}
+
+	if auth.MFAEnabled {
+		challenge, err := uc.newMFAChallenge(auth.AccountID)
+		return nil, challenge, err
+	}
+
+	out, err := uc.issueUserSession(ctx, auth, req.UserAgent)
+	return out, nil, err
+}
+
+// exchangeOAuthCode consumes the pending state of a callback and exchanges its code for the ID token claims.
+func (uc *authUseCase) exchangeOAuthCode(ctx context.Context, providerName string, req dto.OAuthCallbackRequest) (*oidc.IDTokenClaims, error) {
+	provider, err := uc.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := uc.authRepo.ConsumeOAuthState(ctx, providerName, security.SHA256Hex(req.State))
+	if err != nil {
+		if errors.Is(err, ErrStateNotFound) {
+			return nil, ErrInvalidOAuthState
+		}
+		return nil, err
+	}
+
+	claims, err := provider.Exchange(ctx, req.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		slog.Warn("oauth: code exchange failed", slog.String("provider", providerName), slog.String("err", err.Error()))
+		return nil, ErrOAuthFailed
+	}
+
+	return claims, nil
+}
+
+// resolveIdentity returns the account linked to the external identity, linking or creating one when needed.
+func (uc *authUseCase) resolveIdentity(ctx context.Context, providerName string, claims *oidc.IDTokenClaims) (accountID string, err error) {
+	accountID, err = uc.authRepo.TouchIdentity(ctx, providerName, claims.Subject)
+	if err == nil {
+		return accountID, nil
+	}
+	if !errors.Is(err, ErrIdentityUnknown) {
+		return "", err
+	}
+
+	email := strings.TrimSpace(strings.ToLower(claims.Email))
+	if email == "" {
+		return "", ErrOAuthEmailRequired
+	}
+	emailVerified := bool(claims.EmailVerified)
+	created := false
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	existing, err := uc.authRepo.GetAuthByEmail(ctx, email)
+	switch {
+	case err == nil:
+		// Only trust the provider to claim an existing account when it vouches for the email
+		if !emailVerified {
+			return "", ErrOAuthEmailUnverified
+		}
+		accountID = existing.AccountID
+
+		// Nobody proved owning the address before: whoever registered it may not be the
+		// provider's user, so their password, MFA and sessions must not survive the link
+		if existing.EmailVerifiedAt == nil {
+			if err := uc.reclaimUnverifiedAccount(ctx, tx, accountID); err != nil {
+				return "", err
+			}
+		}
+	case errors.Is(err, entity.ErrInvalidCreds):
+		accountID, err = uc.createExternalAccount(ctx, tx, email, emailVerified, claims.Name)
+		if err != nil {
+			return "", err
+		}
+		created = true
+	default:
+		return "", err
+	}
+
+	if err := uc.authRepo.LinkIdentity(ctx, tx, accountID, providerName, claims.Subject, email); err != nil {
+		return "", err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("oauth: commit transaction failed", slog.String("provider", providerName), slog.String("err", err.Error()))
+		return "", err
+	}
+
+	slog.Info("oauth: identity linked", slog.String("provider", providerName), slog.String("account_id", accountID))
+
+	// the provider did not vouch for the address, it is verified like a sign up
+	if created && !emailVerified {
+		uc.sendEmailVerificationAsync(accountID, email)
+	}
+	return accountID, nil
+}
+
+// reclaimUnverifiedAccount hands an account with an unverified email over to the provider's user.
+func (uc *authUseCase) reclaimUnverifiedAccount(ctx context.Context, tx pgx.Tx, accountID string) error {
+	if err := uc.authRepo.ClearPassword(ctx, tx, accountID); err != nil {
+		return err
+	}
+
+	if err := uc.authRepo.DeleteMFA(ctx, tx, accountID); err != nil {
+		return err
+	}
+
+	if err := uc.authRepo.RevokeAllSessions(ctx, tx, accountID); err != nil {
+		return err
+	}
+
+	// a pending email change would hand the account back to whoever requested it
+	for _, purpose := range []string{entity.TokenPurposeEmailChange, entity.TokenPurposeEmailVerify, entity.TokenPurposePasswordReset} {
+		if err := uc.authRepo.InvalidateAccountTokens(ctx, tx, accountID, purpose); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.authRepo.MarkEmailVerified(ctx, tx, accountID); err != nil {
+		return err
+	}
+
+	slog.Warn("oauth: unverified account reclaimed, password and sessions dropped", slog.String("account_id", accountID))
+	return nil
+}
+
+// createExternalAccount inserts a password-less account and its user profile inside tx.
+func (uc *authUseCase) createExternalAccount(ctx context.Context, tx pgx.Tx, email string, emailVerified bool, name string) (accountID string, err error) {
+	accountID, err = uc.authRepo.CreateExternalAccount(ctx, tx, email, emailVerified)
+	if err != nil {
+		return "", err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+
+	if _, err = uc.authRepo.CreateUser(ctx, tx, &entity.User{AccountID: accountID, Name: name}); err != nil {
+		return "", err
+	}
+
+	if err = uc.authRepo.GrantRole(ctx, tx, accountID, rbac.RoleSwimmer); err != nil {
+		return "", err
+	}
+
+	return accountID, nil
+}
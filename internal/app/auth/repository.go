@@ -12,41 +12,109 @@ import (
 )
 
 var (
-	ErrAccountExists = errors.New("account already exists")
+	ErrAccountExists   = errors.New("account already exists")
+	ErrSessionNotFound = errors.New("session not found")
+	ErrTokenNotFound   = errors.New("token not found or expired")
+	ErrMFANotFound     = errors.New("mfa not configured")
+	ErrIdentityUnknown = errors.New("identity not linked")
+	ErrStateNotFound   = errors.New("oauth state not found or expired")
 )
 
 type AuthRepository interface {
 	GetAuthByEmail(ctx context.Context, email string) (*entity.Auth, error)
+	GetAuthByID(ctx context.Context, accountID string) (*entity.Auth, error)
 	CreateAccount(ctx context.Context, tx pgx.Tx, email, passwordHash string) (id string, err error)
 	CreateUser(ctx context.Context, tx pgx.Tx, user *entity.User) (id string, err error)
+	GrantRole(ctx context.Context, tx pgx.Tx, accountID, role string) error
+	GetAccountRoles(ctx context.Context, accountID string) ([]string, error)
 	CreateUserSession(ctx context.Context, session *entity.Session) (id string, err error)
 	CreateGuestSession(ctx context.Context, session *entity.Session) (id string, err error)
 	CountRecentGuestByUA(ctx context.Context, ua *string, since *time.Time) (count int, err error)
+	GetSessionByRefreshHash(ctx context.Context, tx pgx.Tx, refreshTokenHash string) (*entity.Session, error)
+	GetSessionIDByRotatedHash(ctx context.Context, tx pgx.Tx, refreshTokenHash string) (sessionID string, err error)
+	RotateSessionRefresh(ctx context.Context, tx pgx.Tx, previousHash string, session *entity.Session) error
+	RevokeSession(ctx context.Context, tx pgx.Tx, sessionID string) error
+	IsSessionActive(ctx context.Context, sessionID, kind string) (active bool, err error)
+	ListActiveSessions(ctx context.Context, accountID string) ([]entity.Session, error)
+	RevokeSessionByID(ctx context.Context, sessionID string) error
+	RevokeAccountSession(ctx context.Context, accountID, sessionID string) (revoked bool, err error)
+	RevokeAccountSessions(ctx context.Context, accountID, exceptSessionID string) (count int64, err error)
+	GetSessionByID(ctx context.Context, tx pgx.Tx, sessionID string) (*entity.Session, error)
+	ConvertGuestSession(ctx context.Context, tx pgx.Tx, sessionID, accountID string) error
+	CountActiveGuestSessions(ctx context.Context) (count int, err error)
+	CreateAccountToken(ctx context.Context, tx pgx.Tx, token *entity.AccountToken) error
+	CountRecentAccountTokens(ctx context.Context, accountID, purpose string, since time.Time) (count int, err error)
+	ConsumeAccountToken(ctx context.Context, tx pgx.Tx, purpose, tokenHash string) (accountID string, err error)
+	InvalidateAccountTokens(ctx context.Context, tx pgx.Tx, accountID, purpose string) error
+	UpdatePassword(ctx context.Context, tx pgx.Tx, accountID, passwordHash string) error
+	ClearPassword(ctx context.Context, tx pgx.Tx, accountID string) error
+	RevokeAllSessions(ctx context.Context, tx pgx.Tx, accountID string) error
+	GetLastAccountTokenAt(ctx context.Context, accountID, purpose string) (*time.Time, error)
+	MarkEmailVerified(ctx context.Context, tx pgx.Tx, accountID string) error
+	ConsumeEmailChangeToken(ctx context.Context, tx pgx.Tx, tokenHash string) (accountID, newEmail string, err error)
+	UpdateEmail(ctx context.Context, tx pgx.Tx, accountID, email string) (previous string, err error)
+	EmailExists(ctx context.Context, email string) (bool, error)
+	RevokeOtherSessions(ctx context.Context, tx pgx.Tx, accountID, exceptSessionID string) error
+	ScheduleDeletion(ctx context.Context, tx pgx.Tx, accountID string, at time.Time) error
+	CancelDeletion(ctx context.Context, accountID string) (cancelled bool, err error)
+	LockPurgeableAccounts(ctx context.Context, tx pgx.Tx, limit int) ([]string, error)
+	DeleteAccounts(ctx context.Context, tx pgx.Tx, accountIDs []string) (count int64, err error)
+	ListIdentities(ctx context.Context, accountID string) ([]entity.Identity, error)
+	GetThrottleLockedUntil(ctx context.Context, keys ...string) (*time.Time, error)
+	RecordSignInFailure(ctx context.Context, key string, window time.Duration) (failures int, err error)
+	LockThrottle(ctx context.Context, key string, until time.Time) error
+	ClearThrottle(ctx context.Context, key string) error
+	GetMFA(ctx context.Context, accountID string) (*entity.MFA, error)
+	SavePendingMFA(ctx context.Context, accountID string, secretEnc []byte) error
+	EnableMFA(ctx context.Context, tx pgx.Tx, accountID string, step int64) error
+	ReplaceRecoveryCodes(ctx context.Context, tx pgx.Tx, accountID string, hashes []string) error
+	UseMFAStep(ctx context.Context, accountID string, step int64) (ok bool, err error)
+	UseRecoveryCode(ctx context.Context, accountID, codeHash string) (ok bool, err error)
+	DeleteMFA(ctx context.Context, tx pgx.Tx, accountID string) error
+	CreateExternalAccount(ctx context.Context, tx pgx.Tx, email string, emailVerified bool) (id string, err error)
+	TouchIdentity(ctx context.Context, provider, subject string) (accountID string, err error)
+	LinkIdentity(ctx context.Context, tx pgx.Tx, accountID, provider, subject, email string) error
+	SaveOAuthState(ctx context.Context, state *entity.OAuthState) error
+	ConsumeOAuthState(ctx context.Context, provider, stateHash string) (*entity.OAuthState, error)
 }
 
 type authRepository struct{ db *pgxpool.Pool }
 
 func NewAuthRepository(db *pgxpool.Pool) AuthRepository { return &authRepository{db: db} }
 
+const authSelectSQL = `
+	SELECT
+	    a.id, a.email, COALESCE(a.password_hash, ''), a.is_locked, a.email_verified_at,
+		EXISTS (SELECT 1 FROM account_mfa AS m WHERE m.account_id = a.id AND m.enabled_at IS NOT NULL),
+		ARRAY(SELECT r.role FROM account_roles AS r WHERE r.account_id = a.id ORDER BY r.role),
+		a.deletion_scheduled_at,
+		u.name, u.weight_kg, u.height_cm, u.birth_date
+	FROM accounts AS a
+	JOIN users AS u ON a.id = u.account_id`
+
 func (r *authRepository) GetAuthByEmail(ctx context.Context, email string) (*entity.Auth, error) {
-	const sql = `
-		SELECT
-		    a.id, a.email, a.password_hash, a.is_locked, 
-			u.name, u.weight_kg, u.height_cm, u.age_years
-		FROM accounts AS a
-		JOIN users AS u ON a.id = u.account_id
-		WHERE a.email = $1`
+	return r.getAuth(ctx, authSelectSQL+` WHERE a.email = $1`, email)
+}
+
+func (r *authRepository) GetAuthByID(ctx context.Context, accountID string) (*entity.Auth, error) {
+	return r.getAuth(ctx, authSelectSQL+` WHERE a.id = $1`, accountID)
+}
 
+func (r *authRepository) getAuth(ctx context.Context, sql string, arg any) (*entity.Auth, error) {
 	var auth entity.Auth
-	if err := r.db.QueryRow(ctx, sql, email).Scan(
+	if err := r.db.QueryRow(ctx, sql, arg).Scan(
 		&auth.AccountID,
 		&auth.Email,
 		&auth.PasswordHash,
 		&auth.IsLocked,
+		&auth.EmailVerifiedAt,
+		&auth.MFAEnabled,
+		&auth.Roles,
+		&auth.DeletionAt,
 		&auth.Name,
 		&auth.WeightKG,
 		&auth.HeightCM,
-		&auth.AgeYears,
+		&auth.BirthDate,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, entity.ErrInvalidCreds
@@ -75,16 +143,34 @@ func (r *authRepository) CreateAccount(ctx context.Context, tx pgx.Tx, email, pa
 
 func (r *authRepository) CreateUser(ctx context.Context, tx pgx.Tx, user *entity.User) (id string, err error) {
 	const sql = `
-		INSERT INTO users (account_id, name, weight_kg, height_cm, age_years)
+		INSERT INTO users (account_id, name, weight_kg, height_cm, birth_date)
 		VALUES ($1,$2,$3,$4,$5)
 		RETURNING id`
 
-	if err = tx.QueryRow(ctx, sql, &user.AccountID, &user.Name, &user.WeightKG, &user.HeightCM, &user.AgeYears).Scan(&id); err != nil {
+	if err = tx.QueryRow(ctx, sql, &user.AccountID, &user.Name, &user.WeightKG, &user.HeightCM, &user.BirthDate).Scan(&id); err != nil {
 		return "", err
 	}
 	return id, nil
 }
 
+func (r *authRepository) GrantRole(ctx context.Context, tx pgx.Tx, accountID, role string) error {
+	const sql = `INSERT INTO account_roles (account_id, role) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	_, err := tx.Exec(ctx, sql, accountID, role)
+	return err
+}
+
+func (r *authRepository) GetAccountRoles(ctx context.Context, accountID string) ([]string, error) {
+	const sql = `SELECT ARRAY(SELECT role FROM account_roles WHERE account_id = $1 ORDER BY role)`
+
+	var roles []string
+	if err := r.db.QueryRow(ctx, sql, accountID).Scan(&roles); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
 func (r *authRepository) CreateUserSession(ctx context.Context, session *entity.Session) (id string, err error) {
 	const sql = `
 		INSERT INTO sessions (account_id, kind, user_agent, expires_at, refresh_token_hash, refresh_expires_at)
@@ -118,3 +204,653 @@ func (r *authRepository) CountRecentGuestByUA(ctx context.Context, ua *string, s
 
 	return count, err
 }
+
+func (r *authRepository) CountActiveGuestSessions(ctx context.Context) (count int, err error) {
+	err = r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM sessions
+		WHERE kind='guest' AND revoked_at IS NULL AND refresh_expires_at > now()`).Scan(&count)
+
+	return count, err
+}
+
+func (r *authRepository) GetSessionByRefreshHash(ctx context.Context, tx pgx.Tx, refreshTokenHash string) (*entity.Session, error) {
+	const sql = `
+		SELECT
+			s.id, s.account_id, s.kind, s.user_agent, s.expires_at,
+			s.refresh_token_hash, s.refresh_expires_at, s.revoked_at,
+			COALESCE(a.is_locked, false)
+		FROM sessions AS s
+		LEFT JOIN accounts AS a ON a.id = s.account_id
+		WHERE s.refresh_token_hash = $1
+		FOR UPDATE OF s`
+
+	var session entity.Session
+	if err := tx.QueryRow(ctx, sql, refreshTokenHash).Scan(
+		&session.ID,
+		&session.AccountID,
+		&session.Kind,
+		&session.UserAgent,
+		&session.ExpiresAt,
+		&session.RefreshTokenHash,
+		&session.RefreshExpiresAt,
+		&session.RevokedAt,
+		&session.IsLocked,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSessionNotFound
+		}
+
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *authRepository) GetSessionByID(ctx context.Context, tx pgx.Tx, sessionID string) (*entity.Session, error) {
+	const sql = `
+		SELECT id, account_id, kind, user_agent, expires_at, refresh_token_hash, refresh_expires_at, revoked_at
+		FROM sessions
+		WHERE id = $1
+		FOR UPDATE`
+
+	var session entity.Session
+	if err := tx.QueryRow(ctx, sql, sessionID).Scan(
+		&session.ID,
+		&session.AccountID,
+		&session.Kind,
+		&session.UserAgent,
+		&session.ExpiresAt,
+		&session.RefreshTokenHash,
+		&session.RefreshExpiresAt,
+		&session.RevokedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSessionNotFound
+		}
+
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (r *authRepository) GetSessionIDByRotatedHash(ctx context.Context, tx pgx.Tx, refreshTokenHash string) (sessionID string, err error) {
+	const sql = `SELECT session_id FROM session_refresh_tokens WHERE token_hash = $1`
+
+	if err = tx.QueryRow(ctx, sql, refreshTokenHash).Scan(&sessionID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrSessionNotFound
+		}
+
+		return "", err
+	}
+
+	return sessionID, nil
+}
+
+func (r *authRepository) RotateSessionRefresh(ctx context.Context, tx pgx.Tx, previousHash string, session *entity.Session) error {
+	const archiveSQL = `
+		INSERT INTO session_refresh_tokens (token_hash, session_id)
+		VALUES ($1, $2)
+		ON CONFLICT (token_hash) DO NOTHING`
+
+	// A session that never had a refresh token has nothing to archive
+	if previousHash != "" {
+		if _, err := tx.Exec(ctx, archiveSQL, previousHash, session.ID); err != nil {
+			return err
+		}
+	}
+
+	const updateSQL = `
+		UPDATE sessions
+		SET refresh_token_hash = $2, refresh_expires_at = $3, expires_at = $4, last_seen_at = now()
+		WHERE id = $1`
+
+	_, err := tx.Exec(ctx, updateSQL, session.ID, session.RefreshTokenHash, session.RefreshExpiresAt, session.ExpiresAt)
+	return err
+}
+
+func (r *authRepository) RevokeSession(ctx context.Context, tx pgx.Tx, sessionID string) error {
+	const sql = `UPDATE sessions SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL`
+
+	_, err := tx.Exec(ctx, sql, sessionID)
+	return err
+}
+
+// IsSessionActive also bumps last_seen_at, at most once per minute to keep writes cheap.
+func (r *authRepository) IsSessionActive(ctx context.Context, sessionID, kind string) (active bool, err error) {
+	const sql = `
+		WITH active AS (
+			SELECT id, last_seen_at FROM sessions
+			WHERE id = $1 AND kind = $2 AND revoked_at IS NULL
+			  AND (refresh_expires_at IS NULL OR refresh_expires_at > now())
+		), touched AS (
+			UPDATE sessions AS s SET last_seen_at = now()
+			FROM active AS a
+			WHERE s.id = a.id AND a.last_seen_at < now() - interval '1 minute'
+		)
+		SELECT EXISTS (SELECT 1 FROM active)`
+
+	err = r.db.QueryRow(ctx, sql, sessionID, kind).Scan(&active)
+	return active, err
+}
+
+func (r *authRepository) ListActiveSessions(ctx context.Context, accountID string) ([]entity.Session, error) {
+	const sql = `
+		SELECT id, account_id, kind, user_agent, created_at, last_seen_at
+		FROM sessions
+		WHERE account_id = $1 AND revoked_at IS NULL
+		  AND (refresh_expires_at IS NULL OR refresh_expires_at > now())
+		ORDER BY last_seen_at DESC`
+
+	rows, err := r.db.Query(ctx, sql, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]entity.Session, 0)
+	for rows.Next() {
+		var session entity.Session
+		if err := rows.Scan(
+			&session.ID,
+			&session.AccountID,
+			&session.Kind,
+			&session.UserAgent,
+			&session.CreatedAt,
+			&session.LastSeenAt,
+		); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, rows.Err()
+}
+
+func (r *authRepository) RevokeSessionByID(ctx context.Context, sessionID string) error {
+	const sql = `UPDATE sessions SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL`
+
+	_, err := r.db.Exec(ctx, sql, sessionID)
+	return err
+}
+
+func (r *authRepository) RevokeAccountSession(ctx context.Context, accountID, sessionID string) (revoked bool, err error) {
+	const sql = `UPDATE sessions SET revoked_at = now() WHERE id = $1 AND account_id = $2 AND revoked_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, sql, sessionID, accountID)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// RevokeAccountSessions revokes every session of the account, keeping exceptSessionID when it is not empty.
+func (r *authRepository) RevokeAccountSessions(ctx context.Context, accountID, exceptSessionID string) (count int64, err error) {
+	const sql = `
+		UPDATE sessions SET revoked_at = now()
+		WHERE account_id = $1 AND revoked_at IS NULL
+		  AND ($2 = '' OR id::text <> $2)`
+
+	tag, err := r.db.Exec(ctx, sql, accountID, exceptSessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *authRepository) ConvertGuestSession(ctx context.Context, tx pgx.Tx, sessionID, accountID string) error {
+	const sql = `UPDATE sessions SET kind = 'user', account_id = $2 WHERE id = $1 AND kind = 'guest'`
+
+	tag, err := tx.Exec(ctx, sql, sessionID, accountID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (r *authRepository) CreateAccountToken(ctx context.Context, tx pgx.Tx, token *entity.AccountToken) error {
+	const sql = `
+		INSERT INTO account_tokens (account_id, purpose, token_hash, expires_at, new_email)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		RETURNING id`
+
+	return tx.QueryRow(ctx, sql, token.AccountID, token.Purpose, token.TokenHash, token.ExpiresAt, token.NewEmail).Scan(&token.ID)
+}
+
+func (r *authRepository) CountRecentAccountTokens(ctx context.Context, accountID, purpose string, since time.Time) (count int, err error) {
+	err = r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM account_tokens
+		WHERE account_id = $1 AND purpose = $2 AND created_at >= $3`, accountID, purpose, since).Scan(&count)
+
+	return count, err
+}
+
+// ConsumeAccountToken marks a valid token as used and returns its account.
+func (r *authRepository) ConsumeAccountToken(ctx context.Context, tx pgx.Tx, purpose, tokenHash string) (accountID string, err error) {
+	const sql = `
+		UPDATE account_tokens SET used_at = now()
+		WHERE token_hash = $1 AND purpose = $2 AND used_at IS NULL AND expires_at > now()
+		RETURNING account_id`
+
+	if err = tx.QueryRow(ctx, sql, tokenHash, purpose).Scan(&accountID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrTokenNotFound
+		}
+
+		return "", err
+	}
+
+	return accountID, nil
+}
+
+func (r *authRepository) InvalidateAccountTokens(ctx context.Context, tx pgx.Tx, accountID, purpose string) error {
+	const sql = `UPDATE account_tokens SET used_at = now() WHERE account_id = $1 AND purpose = $2 AND used_at IS NULL`
+
+	_, err := tx.Exec(ctx, sql, accountID, purpose)
+	return err
+}
+
+func (r *authRepository) UpdatePassword(ctx context.Context, tx pgx.Tx, accountID, passwordHash string) error {
+	const sql = `UPDATE accounts SET password_hash = $2, updated_at = now() WHERE id = $1`
+
+	_, err := tx.Exec(ctx, sql, accountID, passwordHash)
+	return err
+}
+
+// ClearPassword turns the account into a password-less one, only linked identities can sign in.
+func (r *authRepository) ClearPassword(ctx context.Context, tx pgx.Tx, accountID string) error {
+	const sql = `UPDATE accounts SET password_hash = NULL, updated_at = now() WHERE id = $1`
+
+	_, err := tx.Exec(ctx, sql, accountID)
+	return err
+}
+
+func (r *authRepository) RevokeAllSessions(ctx context.Context, tx pgx.Tx, accountID string) error {
+	const sql = `UPDATE sessions SET revoked_at = now() WHERE account_id = $1 AND revoked_at IS NULL`
+
+	_, err := tx.Exec(ctx, sql, accountID)
+	return err
+}
+
+func (r *authRepository) GetLastAccountTokenAt(ctx context.Context, accountID, purpose string) (last *time.Time, err error) {
+	err = r.db.QueryRow(ctx, `
+		SELECT MAX(created_at) FROM account_tokens
+		WHERE account_id = $1 AND purpose = $2`, accountID, purpose).Scan(&last)
+
+	return last, err
+}
+
+func (r *authRepository) MarkEmailVerified(ctx context.Context, tx pgx.Tx, accountID string) error {
+	const sql = `
+		UPDATE accounts SET email_verified_at = COALESCE(email_verified_at, now()), updated_at = now()
+		WHERE id = $1`
+
+	_, err := tx.Exec(ctx, sql, accountID)
+	return err
+}
+
+// ConsumeEmailChangeToken marks a valid email change token as used and returns the address it confirms.
+func (r *authRepository) ConsumeEmailChangeToken(ctx context.Context, tx pgx.Tx, tokenHash string) (accountID, newEmail string, err error) {
+	const sql = `
+		UPDATE account_tokens SET used_at = now()
+		WHERE token_hash = $1 AND purpose = 'email_change' AND used_at IS NULL AND expires_at > now()
+		RETURNING account_id, new_email`
+
+	if err = tx.QueryRow(ctx, sql, tokenHash).Scan(&accountID, &newEmail); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", "", ErrTokenNotFound
+		}
+
+		return "", "", err
+	}
+
+	return accountID, newEmail, nil
+}
+
+// UpdateEmail switches the account to a confirmed address and returns the previous one.
+func (r *authRepository) UpdateEmail(ctx context.Context, tx pgx.Tx, accountID, email string) (previous string, err error) {
+	const selectSQL = `SELECT email FROM accounts WHERE id = $1 FOR UPDATE`
+	const updateSQL = `UPDATE accounts SET email = $2, email_verified_at = now(), updated_at = now() WHERE id = $1`
+
+	if err = tx.QueryRow(ctx, selectSQL, accountID).Scan(&previous); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrTokenNotFound
+		}
+		return "", err
+	}
+
+	if _, err = tx.Exec(ctx, updateSQL, accountID, email); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return "", ErrAccountExists
+		}
+
+		return "", err
+	}
+
+	return previous, nil
+}
+
+func (r *authRepository) EmailExists(ctx context.Context, email string) (exists bool, err error) {
+	err = r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM accounts WHERE email = $1)`, email).Scan(&exists)
+	return exists, err
+}
+
+func (r *authRepository) RevokeOtherSessions(ctx context.Context, tx pgx.Tx, accountID, exceptSessionID string) error {
+	const sql = `UPDATE sessions SET revoked_at = now() WHERE account_id = $1 AND revoked_at IS NULL AND id <> $2`
+
+	_, err := tx.Exec(ctx, sql, accountID, exceptSessionID)
+	return err
+}
+
+func (r *authRepository) ScheduleDeletion(ctx context.Context, tx pgx.Tx, accountID string, at time.Time) error {
+	const sql = `UPDATE accounts SET deletion_scheduled_at = $2, updated_at = now() WHERE id = $1`
+
+	_, err := tx.Exec(ctx, sql, accountID, at)
+	return err
+}
+
+func (r *authRepository) CancelDeletion(ctx context.Context, accountID string) (cancelled bool, err error) {
+	const sql = `
+		UPDATE accounts SET deletion_scheduled_at = NULL, updated_at = now()
+		WHERE id = $1 AND deletion_scheduled_at IS NOT NULL`
+
+	tag, err := r.db.Exec(ctx, sql, accountID)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// LockPurgeableAccounts locks up to limit accounts past their deletion grace period.
+// SKIP LOCKED lets several instances purge concurrently.
+func (r *authRepository) LockPurgeableAccounts(ctx context.Context, tx pgx.Tx, limit int) ([]string, error) {
+	const sql = `
+		SELECT id FROM accounts
+		WHERE deletion_scheduled_at <= now()
+		ORDER BY deletion_scheduled_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`
+
+	rows, err := tx.Query(ctx, sql, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// DeleteAccounts deletes the accounts, owned rows cascade.
+func (r *authRepository) DeleteAccounts(ctx context.Context, tx pgx.Tx, accountIDs []string) (count int64, err error) {
+	const sql = `DELETE FROM accounts WHERE id = ANY($1::uuid[])`
+
+	tag, err := tx.Exec(ctx, sql, accountIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *authRepository) ListIdentities(ctx context.Context, accountID string) ([]entity.Identity, error) {
+	const sql = `
+		SELECT provider, subject, email, created_at, last_login_at
+		FROM account_identities
+		WHERE account_id = $1
+		ORDER BY created_at`
+
+	rows, err := r.db.Query(ctx, sql, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	identities := make([]entity.Identity, 0)
+	for rows.Next() {
+		var identity entity.Identity
+		if err := rows.Scan(
+			&identity.Provider,
+			&identity.Subject,
+			&identity.Email,
+			&identity.CreatedAt,
+			&identity.LastLoginAt,
+		); err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, rows.Err()
+}
+
+// GetThrottleLockedUntil returns the latest active lockout among keys, or nil.
+func (r *authRepository) GetThrottleLockedUntil(ctx context.Context, keys ...string) (until *time.Time, err error) {
+	err = r.db.QueryRow(ctx, `
+		SELECT MAX(locked_until) FROM sign_in_throttles
+		WHERE key = ANY($1) AND locked_until > now()`, keys).Scan(&until)
+
+	return until, err
+}
+
+// RecordSignInFailure increments the counter of key, restarting it when the last failure is older than window.
+func (r *authRepository) RecordSignInFailure(ctx context.Context, key string, window time.Duration) (failures int, err error) {
+	const sql = `
+		INSERT INTO sign_in_throttles (key, failures, last_failure_at)
+		VALUES ($1, 1, now())
+		ON CONFLICT (key) DO UPDATE SET
+			failures = CASE
+				WHEN sign_in_throttles.last_failure_at < now() - ($2 * interval '1 second') THEN 1
+				ELSE sign_in_throttles.failures + 1
+			END,
+			last_failure_at = now()
+		RETURNING failures`
+
+	err = r.db.QueryRow(ctx, sql, key, int64(window.Seconds())).Scan(&failures)
+	return failures, err
+}
+
+func (r *authRepository) LockThrottle(ctx context.Context, key string, until time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE sign_in_throttles SET locked_until = $2 WHERE key = $1`, key, until)
+	return err
+}
+
+func (r *authRepository) ClearThrottle(ctx context.Context, key string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM sign_in_throttles WHERE key = $1`, key)
+	return err
+}
+
+func (r *authRepository) GetMFA(ctx context.Context, accountID string) (*entity.MFA, error) {
+	const sql = `SELECT account_id, totp_secret_enc, enabled_at, last_used_step FROM account_mfa WHERE account_id = $1`
+
+	var mfa entity.MFA
+	if err := r.db.QueryRow(ctx, sql, accountID).Scan(&mfa.AccountID, &mfa.SecretEnc, &mfa.EnabledAt, &mfa.LastUsedStep); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrMFANotFound
+		}
+
+		return nil, err
+	}
+
+	return &mfa, nil
+}
+
+// SavePendingMFA stores a new secret unless MFA is already enabled for the account.
+func (r *authRepository) SavePendingMFA(ctx context.Context, accountID string, secretEnc []byte) error {
+	const sql = `
+		INSERT INTO account_mfa (account_id, totp_secret_enc)
+		VALUES ($1, $2)
+		ON CONFLICT (account_id) DO UPDATE
+		SET totp_secret_enc = EXCLUDED.totp_secret_enc, last_used_step = NULL, updated_at = now()
+		WHERE account_mfa.enabled_at IS NULL`
+
+	_, err := r.db.Exec(ctx, sql, accountID, secretEnc)
+	return err
+}
+
+func (r *authRepository) EnableMFA(ctx context.Context, tx pgx.Tx, accountID string, step int64) error {
+	const sql = `
+		UPDATE account_mfa SET enabled_at = now(), last_used_step = $2, updated_at = now()
+		WHERE account_id = $1 AND enabled_at IS NULL`
+
+	tag, err := tx.Exec(ctx, sql, accountID, step)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrMFANotFound
+	}
+
+	return nil
+}
+
+func (r *authRepository) ReplaceRecoveryCodes(ctx context.Context, tx pgx.Tx, accountID string, hashes []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE account_id = $1`, accountID); err != nil {
+		return err
+	}
+
+	const sql = `
+		INSERT INTO mfa_recovery_codes (account_id, code_hash)
+		SELECT $1, unnest($2::text[])`
+
+	_, err := tx.Exec(ctx, sql, accountID, hashes)
+	return err
+}
+
+// UseMFAStep records step as used, failing when the same or a later step was already accepted.
+func (r *authRepository) UseMFAStep(ctx context.Context, accountID string, step int64) (ok bool, err error) {
+	const sql = `
+		UPDATE account_mfa SET last_used_step = $2, updated_at = now()
+		WHERE account_id = $1 AND enabled_at IS NOT NULL
+		  AND (last_used_step IS NULL OR last_used_step < $2)`
+
+	tag, err := r.db.Exec(ctx, sql, accountID, step)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *authRepository) UseRecoveryCode(ctx context.Context, accountID, codeHash string) (ok bool, err error) {
+	const sql = `
+		UPDATE mfa_recovery_codes SET used_at = now()
+		WHERE account_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	tag, err := r.db.Exec(ctx, sql, accountID, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *authRepository) DeleteMFA(ctx context.Context, tx pgx.Tx, accountID string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE account_id = $1`, accountID); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, `DELETE FROM account_mfa WHERE account_id = $1`, accountID)
+	return err
+}
+
+// CreateExternalAccount creates a password-less account for a social login.
+func (r *authRepository) CreateExternalAccount(ctx context.Context, tx pgx.Tx, email string, emailVerified bool) (id string, err error) {
+	const sql = `
+		INSERT INTO accounts (email, password_hash, email_verified_at)
+		VALUES ($1, NULL, CASE WHEN $2 THEN now() END)
+		RETURNING id`
+
+	if err = tx.QueryRow(ctx, sql, email, emailVerified).Scan(&id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return "", ErrAccountExists
+		}
+
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (r *authRepository) TouchIdentity(ctx context.Context, provider, subject string) (accountID string, err error) {
+	const sql = `
+		UPDATE account_identities SET last_login_at = now()
+		WHERE provider = $1 AND subject = $2
+		RETURNING account_id`
+
+	if err = r.db.QueryRow(ctx, sql, provider, subject).Scan(&accountID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrIdentityUnknown
+		}
+
+		return "", err
+	}
+
+	return accountID, nil
+}
+
+func (r *authRepository) LinkIdentity(ctx context.Context, tx pgx.Tx, accountID, provider, subject, email string) error {
+	const sql = `
+		INSERT INTO account_identities (account_id, provider, subject, email)
+		VALUES ($1, $2, $3, NULLIF($4, ''))`
+
+	_, err := tx.Exec(ctx, sql, accountID, provider, subject, email)
+	return err
+}
+
+func (r *authRepository) SaveOAuthState(ctx context.Context, state *entity.OAuthState) error {
+	const sql = `
+		INSERT INTO oauth_states (state_hash, provider, code_verifier, nonce, expires_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	// Opportunistic cleanup keeps the table small without a background job
+	if _, err := r.db.Exec(ctx, `DELETE FROM oauth_states WHERE expires_at < now()`); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(ctx, sql, state.StateHash, state.Provider, state.CodeVerifier, state.Nonce, state.ExpiresAt)
+	return err
+}
+
+// ConsumeOAuthState deletes and returns a pending state so it cannot be replayed.
+func (r *authRepository) ConsumeOAuthState(ctx context.Context, provider, stateHash string) (*entity.OAuthState, error) {
+	const sql = `
+		DELETE FROM oauth_states
+		WHERE state_hash = $1 AND provider = $2 AND expires_at > now()
+		RETURNING state_hash, provider, code_verifier, nonce, expires_at`
+
+	var state entity.OAuthState
+	if err := r.db.QueryRow(ctx, sql, stateHash, provider).Scan(
+		&state.StateHash,
+		&state.Provider,
+		&state.CodeVerifier,
+		&state.Nonce,
+		&state.ExpiresAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrStateNotFound
+		}
+
+		return nil, err
+	}
+
+	return &state, nil
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"haphap/swimo-api/internal/app/auth/entity"
+	"log/slog"
+	"time"
+)
+
+var (
+	ErrTooManyAttempts = errors.New("too many failed sign-in attempts")
+)
+
+// ThrottledError is returned while a temporary sign-in lockout is active.
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrTooManyAttempts, e.RetryAfter)
+}
+
+func (e *ThrottledError) Is(target error) bool { return target == ErrTooManyAttempts }
+
+func accountThrottleKey(email string) string { return "account:" + email }
+func ipThrottleKey(ip string) string         { return "ip:" + ip }
+
+func mfaThrottleKey(accountID string) string    { return "mfa:" + accountID }
+func reauthThrottleKey(accountID string) string { return "reauth:" + accountID }
+
+func (uc *authUseCase) checkThrottle(ctx context.Context, email, ip string) error {
+	keys := []string{accountThrottleKey(email)}
+	if ip != "" {
+		keys = append(keys, ipThrottleKey(ip))
+	}
+
+	return uc.checkThrottleKeys(ctx, keys...)
+}
+
+func (uc *authUseCase) checkThrottleKeys(ctx context.Context, keys ...string) error {
+	until, err := uc.authRepo.GetThrottleLockedUntil(ctx, keys...)
+	if err != nil {
+		return err
+	}
+	if until == nil {
+		return nil
+	}
+
+	retryAfter := time.Until(*until).Round(time.Second)
+	if retryAfter < time.Second {
+		retryAfter = time.Second
+	}
+	return &ThrottledError{RetryAfter: retryAfter}
+}
+
+// recordSignInFailure counts the failure against the email and the ip and starts a lockout once a threshold is hit.
+// Failures to persist are only logged, the caller still answers with invalid credentials.
+func (uc *authUseCase) recordSignInFailure(ctx context.Context, email, ip string) {
+	auth := uc.cfg.Auth
+
+	uc.recordThrottleFailure(ctx, accountThrottleKey(email), auth.LockoutThreshold)
+	if ip != "" {
+		uc.recordThrottleFailure(ctx, ipThrottleKey(ip), auth.IPLockoutThreshold)
+	}
+}
+
+func (uc *authUseCase) recordThrottleFailure(ctx context.Context, key string, threshold int) {
+	auth := uc.cfg.Auth
+
+	failures, err := uc.authRepo.RecordSignInFailure(ctx, key, auth.FailureWindow)
+	if err != nil {
+		slog.Error("signin: record failure failed", slog.String("key", key), slog.String("err", err.Error()))
+		return
+	}
+
+	lockout := entity.LockoutDuration(failures, threshold, auth.LockoutBase, auth.LockoutMax)
+	if lockout == 0 {
+		return
+	}
+
+	if err := uc.authRepo.LockThrottle(ctx, key, time.Now().Add(lockout)); err != nil {
+		slog.Error("signin: lock throttle failed", slog.String("key", key), slog.String("err", err.Error()))
+		return
+	}
+
+	slog.Warn("signin: temporary lockout", slog.String("key", key), slog.Int("failures", failures), slog.Duration("lockout", lockout))
+}
+
+func (uc *authUseCase) clearSignInFailures(ctx context.Context, email string) {
+	uc.clearThrottle(ctx, accountThrottleKey(email))
+}
+
+func (uc *authUseCase) clearThrottle(ctx context.Context, key string) {
+	if err := uc.authRepo.ClearThrottle(ctx, key); err != nil {
+		slog.Error("signin: clear throttle failed", slog.String("key", key), slog.String("err", err.Error()))
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"haphap/swimo-api/config"
+	"haphap/swimo-api/internal/app/appconfig"
 	"haphap/swimo-api/internal/app/auth/dto"
 	"haphap/swimo-api/internal/app/auth/entity"
+	"haphap/swimo-api/pkg/dates"
+	"haphap/swimo-api/pkg/mailer"
+	"haphap/swimo-api/pkg/rbac"
 	"haphap/swimo-api/pkg/security"
 	"log/slog"
 	"strings"
@@ -17,47 +21,86 @@ import (
 )
 
 var (
-	ErrGuestDisabled = errors.New("guest sign in disabled")
-	ErrGuestLimited  = errors.New("guest sign in rate limited")
-	ErrLocked        = errors.New("account locked")
+	ErrGuestDisabled    = errors.New("guest sign in disabled")
+	ErrGuestLimited     = errors.New("guest sign in rate limited")
+	ErrLocked           = errors.New("account locked")
+	ErrEmailNotVerified = errors.New("email not verified")
+
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+)
+
+const (
+	passwordResetPerHour = 5
+	emailVerifyPerHour   = 5
+	emailVerifyCooldown  = time.Minute
+	emailChangePerHour   = 3
+	mailTimeout          = 30 * time.Second
 )
 
 type AuthUseCase interface {
 	SignUp(ctx context.Context, req dto.SignUpRequest) error
-	SignIn(ctx context.Context, req dto.SignInRequest) (*dto.SignInResponse, error)
+	SignIn(ctx context.Context, req dto.SignInRequest) (*dto.SignInResponse, *dto.MFAChallengeResponse, error)
+	SignInMFA(ctx context.Context, req dto.SignInMFARequest) (*dto.SignInResponse, error)
 	SignInGuest(ctx context.Context, req dto.SignInRequest) (*dto.SignInGuestResponse, error)
+	Refresh(ctx context.Context, req dto.RefreshRequest) (*dto.RefreshResponse, error)
+	SignOut(ctx context.Context, sessionID string) error
+	SignOutAll(ctx context.Context, accountID string) error
+	ListSessions(ctx context.Context, accountID, currentSessionID string) ([]dto.SessionResponse, error)
+	RevokeSession(ctx context.Context, accountID, sessionID string) error
+	UpgradeGuest(ctx context.Context, sessionID string, req dto.SignUpRequest) (*dto.SignInResponse, error)
+	ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error
+	VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) error
+	ResendVerification(ctx context.Context, req dto.ResendVerificationRequest) error
+	SetupTOTP(ctx context.Context, accountID string) (*dto.TOTPSetupResponse, error)
+	ConfirmTOTP(ctx context.Context, accountID string, req dto.TOTPConfirmRequest) (*dto.TOTPConfirmResponse, error)
+	DisableTOTP(ctx context.Context, accountID, sessionID string, req dto.TOTPDisableRequest) error
+	StartOAuth(ctx context.Context, provider string) (*dto.OAuthStartResponse, error)
+	CompleteOAuth(ctx context.Context, provider string, req dto.OAuthCallbackRequest) (*dto.SignInResponse, *dto.MFAChallengeResponse, error)
+	Reauthenticate(ctx context.Context, accountID, sessionID, provider string, req dto.OAuthCallbackRequest) (*dto.ReauthResponse, error)
+	JWKS() security.JWKS
+	ChangePassword(ctx context.Context, accountID, sessionID string, req dto.ChangePasswordRequest) error
+	RequestEmailChange(ctx context.Context, accountID, sessionID string, req dto.ChangeEmailRequest) error
+	ConfirmEmailChange(ctx context.Context, req dto.ConfirmEmailChangeRequest) error
+	DeleteAccount(ctx context.Context, accountID, sessionID string, req dto.DeleteAccountRequest) (*dto.DeleteAccountResponse, error)
+	ExportAccountData(ctx context.Context, accountID string) (map[string]any, error)
+}
+
+// GuestDataMigrator moves records owned by a guest session to the account it was upgraded into.
+type GuestDataMigrator interface {
+	MigrateGuestData(ctx context.Context, tx pgx.Tx, sessionID, accountID string) error
 }
 
 type authUseCase struct {
-	cfg      *config.Config
-	pool     *pgxpool.Pool
-	authRepo AuthRepository
+	cfg            *config.Config
+	pool           *pgxpool.Pool
+	authRepo       AuthRepository
+	runtimeCfg     appconfig.Provider
+	mailer         mailer.Mailer
+	keys           *security.KeySet
+	providers      map[string]IdentityProvider
+	guestMigrators []GuestDataMigrator
 }
 
-func NewAuthUseCase(cfg *config.Config, pool *pgxpool.Pool, authRepo AuthRepository) AuthUseCase {
-	return &authUseCase{cfg, pool, authRepo}
+func NewAuthUseCase(cfg *config.Config, pool *pgxpool.Pool, authRepo AuthRepository, runtimeCfg appconfig.Provider, mailer mailer.Mailer, keys *security.KeySet, providers map[string]IdentityProvider, guestMigrators ...GuestDataMigrator) AuthUseCase {
+	return &authUseCase{cfg, pool, authRepo, runtimeCfg, mailer, keys, providers, guestMigrators}
 }
 
-func (uc *authUseCase) SignUp(ctx context.Context, req dto.SignUpRequest) error {
+// createAccount inserts the account and its user profile inside tx.
+func (uc *authUseCase) createAccount(ctx context.Context, tx pgx.Tx, req dto.SignUpRequest) (accountID string, err error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	// Transaction Start
-	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback(ctx)
-
 	// Create account
 	email := strings.TrimSpace(strings.ToLower(req.Email))
 
-	accountID, err := uc.authRepo.CreateAccount(ctx, tx, email, string(hash))
+	accountID, err = uc.authRepo.CreateAccount(ctx, tx, email, string(hash))
 	if err != nil {
 		slog.Warn("signup: create account failed, rolling back", slog.String("email", email), slog.String("err", err.Error()))
-		return err
+		return "", err
 	}
 
 	// Create user profile
@@ -66,7 +109,30 @@ func (uc *authUseCase) SignUp(ctx context.Context, req dto.SignUpRequest) error
 	_, err = uc.authRepo.CreateUser(ctx, tx, user)
 	if err != nil {
 		slog.Warn("signup: create user failed, rolling back", slog.String("account_id", accountID), slog.String("err", err.Error()))
-		return err // tx rollback by defer
+		return "", err // tx rollback by defer
+	}
+
+	// Everyone signs up as a swimmer, coach and admin are granted by an admin
+	if err = uc.authRepo.GrantRole(ctx, tx, accountID, rbac.RoleSwimmer); err != nil {
+		return "", err
+	}
+
+	return accountID, nil
+}
+
+func (uc *authUseCase) SignUp(ctx context.Context, req dto.SignUpRequest) error {
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	email := strings.TrimSpace(strings.ToLower(req.Email))
+
+	accountID, err := uc.createAccount(ctx, tx, req)
+	if err != nil {
+		return err
 	}
 
 	// Commit transaction
@@ -76,27 +142,61 @@ func (uc *authUseCase) SignUp(ctx context.Context, req dto.SignUpRequest) error
 	}
 
 	slog.Info("signup success", slog.String("email", email))
+
+	uc.sendEmailVerificationAsync(accountID, email)
 	return nil
 }
 
-func (uc *authUseCase) SignIn(ctx context.Context, req dto.SignInRequest) (*dto.SignInResponse, error) {
+// SignIn returns a challenge instead of tokens when the account has MFA enabled.
+func (uc *authUseCase) SignIn(ctx context.Context, req dto.SignInRequest) (*dto.SignInResponse, *dto.MFAChallengeResponse, error) {
 	email := strings.TrimSpace(strings.ToLower(req.Email))
 
+	if err := uc.checkThrottle(ctx, email, req.IP); err != nil {
+		return nil, nil, err
+	}
+
 	auth, err := uc.authRepo.GetAuthByEmail(ctx, email)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, entity.ErrInvalidCreds) {
+			uc.recordSignInFailure(ctx, email, req.IP)
+		}
+		return nil, nil, err
 	}
 
 	if auth.IsLocked {
-		return nil, ErrLocked
+		return nil, nil, ErrLocked
 	}
 
 	if err = auth.ComparePassword(req.Password); err != nil {
-		return nil, err
+		uc.recordSignInFailure(ctx, email, req.IP)
+		return nil, nil, err
+	}
+
+	uc.clearSignInFailures(ctx, email)
+
+	if uc.cfg.Auth.RequireVerified && auth.EmailVerifiedAt == nil {
+		return nil, nil, ErrEmailNotVerified
+	}
+
+	if auth.MFAEnabled {
+		challenge, err := uc.newMFAChallenge(auth.AccountID)
+		return nil, challenge, err
+	}
+
+	out, err := uc.issueUserSession(ctx, auth, req.UserAgent)
+	return out, nil, err
+}
+
+// issueUserSession creates a session for an authenticated account and mints its tokens.
+func (uc *authUseCase) issueUserSession(ctx context.Context, auth *entity.Auth, userAgent *string) (*dto.SignInResponse, error) {
+	if auth.DeletionAt != nil {
+		if err := uc.cancelDeletion(ctx, auth.AccountID); err != nil {
+			return nil, err
+		}
 	}
 
 	// Create session with refresh token
-	session, err := entity.NewSession(uc.cfg, req.UserAgent, &auth.AccountID)
+	session, err := entity.NewSession(uc.cfg, userAgent, &auth.AccountID)
 	if err != nil {
 		return nil, err
 	}
@@ -106,28 +206,40 @@ func (uc *authUseCase) SignIn(ctx context.Context, req dto.SignInRequest) (*dto.
 		return nil, err
 	}
 
-	accessToken, exp, err := security.NewAccessToken(uc.cfg.Auth.JWTSecret, "user", auth.AccountID, sessionId, uc.cfg.Auth.JWTAccessTTL)
+	accessToken, exp, err := uc.keys.NewAccessToken("user", auth.AccountID, sessionId, auth.Roles, uc.cfg.Auth.JWTAccessTTL)
 	if err != nil {
 		return nil, err
 	}
 
 	return &dto.SignInResponse{
-		Name:         auth.Name,
-		Weight:       auth.WeightKG,
-		Height:       auth.HeightCM,
-		Age:          auth.AgeYears,
-		Email:        auth.Email,
-		Token:        accessToken,
-		RefreshToken: *session.RefreshTokenHash,
-		ExpiresInMs:  time.Until(exp).Milliseconds(),
+		Name:          auth.Name,
+		Weight:        auth.WeightKG,
+		Height:        auth.HeightCM,
+		Age:           dates.AgePtr(auth.BirthDate),
+		Email:         auth.Email,
+		EmailVerified: auth.EmailVerifiedAt != nil,
+		Token:         accessToken,
+		RefreshToken:  session.RefreshToken,
+		ExpiresInMs:   time.Until(exp).Milliseconds(),
 	}, nil
 }
 
 func (uc *authUseCase) SignInGuest(ctx context.Context, req dto.SignInRequest) (*dto.SignInGuestResponse, error) {
-	if !uc.cfg.Auth.GuestEnabled {
+	runtimeCfg := uc.runtimeCfg.Current()
+	if !uc.cfg.Auth.GuestEnabled || !runtimeCfg.GuestSignInEnabled {
 		return nil, ErrGuestDisabled
 	}
 
+	if runtimeCfg.GuestActiveLimit > 0 {
+		cnt, err := uc.authRepo.CountActiveGuestSessions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if cnt >= runtimeCfg.GuestActiveLimit {
+			return nil, ErrGuestLimited
+		}
+	}
+
 	if uc.cfg.Auth.GuestRatePerMinute > 0 {
 		since := time.Now().UTC().Add(-1 * time.Minute)
 		if cnt, err := uc.authRepo.CountRecentGuestByUA(ctx, req.UserAgent, &since); err == nil && cnt >= uc.cfg.Auth.GuestRatePerMinute {
@@ -146,7 +258,7 @@ func (uc *authUseCase) SignInGuest(ctx context.Context, req dto.SignInRequest) (
 		return nil, err
 	}
 
-	access, exp, err := security.NewAccessToken(uc.cfg.Auth.JWTSecret, "guest", "", sessionId, uc.cfg.Auth.JWTAccessTTL)
+	access, exp, err := uc.keys.NewAccessToken("guest", "", sessionId, nil, uc.cfg.Auth.JWTAccessTTL)
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +268,450 @@ func (uc *authUseCase) SignInGuest(ctx context.Context, req dto.SignInRequest) (
 		Height:       nil,
 		Age:          nil,
 		Token:        access,
-		RefreshToken: *session.RefreshTokenHash,
+		RefreshToken: session.RefreshToken,
+		ExpiresInMs:  time.Until(exp).Milliseconds(),
+	}, nil
+}
+
+func (uc *authUseCase) Refresh(ctx context.Context, req dto.RefreshRequest) (*dto.RefreshResponse, error) {
+	hash := security.SHA256Hex(req.RefreshToken)
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	session, err := uc.authRepo.GetSessionByRefreshHash(ctx, tx, hash)
+	if err != nil {
+		if !errors.Is(err, ErrSessionNotFound) {
+			return nil, err
+		}
+
+		// Not the current token, check whether it was already rotated away
+		sessionID, err := uc.authRepo.GetSessionIDByRotatedHash(ctx, tx, hash)
+		if err != nil {
+			if errors.Is(err, ErrSessionNotFound) {
+				return nil, ErrInvalidRefreshToken
+			}
+			return nil, err
+		}
+
+		if err := uc.authRepo.RevokeSession(ctx, tx, sessionID); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+
+		slog.Warn("refresh: rotated token reused, session revoked", slog.String("session_id", sessionID))
+		return nil, ErrRefreshTokenReused
+	}
+
+	if session.RevokedAt != nil || session.IsRefreshExpired() {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if session.IsLocked {
+		return nil, ErrLocked
+	}
+
+	if err := session.Rotate(uc.cfg); err != nil {
+		return nil, err
+	}
+
+	if err := uc.authRepo.RotateSessionRefresh(ctx, tx, hash, session); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("refresh: commit transaction failed", slog.String("session_id", session.ID), slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	accountID := ""
+	var roles []string
+	if session.AccountID != nil {
+		accountID = *session.AccountID
+
+		// Roles are re-read so grants apply from the next refresh
+		if roles, err = uc.authRepo.GetAccountRoles(ctx, accountID); err != nil {
+			return nil, err
+		}
+	}
+
+	accessToken, exp, err := uc.keys.NewAccessToken(session.Kind, accountID, session.ID, roles, uc.cfg.Auth.JWTAccessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.RefreshResponse{
+		Token:        accessToken,
+		RefreshToken: session.RefreshToken,
 		ExpiresInMs:  time.Until(exp).Milliseconds(),
 	}, nil
 }
+
+func (uc *authUseCase) SignOut(ctx context.Context, sessionID string) error {
+	if err := uc.authRepo.RevokeSessionByID(ctx, sessionID); err != nil {
+		return err
+	}
+
+	slog.Info("signout success", slog.String("session_id", sessionID))
+	return nil
+}
+
+func (uc *authUseCase) SignOutAll(ctx context.Context, accountID string) error {
+	count, err := uc.authRepo.RevokeAccountSessions(ctx, accountID, "")
+	if err != nil {
+		return err
+	}
+
+	slog.Info("signout all success", slog.String("account_id", accountID), slog.Int64("revoked", count))
+	return nil
+}
+
+func (uc *authUseCase) ListSessions(ctx context.Context, accountID, currentSessionID string) ([]dto.SessionResponse, error) {
+	sessions, err := uc.authRepo.ListActiveSessions(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]dto.SessionResponse, 0, len(sessions))
+	for i := range sessions {
+		out = append(out, dto.ToSessionResponse(&sessions[i], currentSessionID))
+	}
+
+	return out, nil
+}
+
+func (uc *authUseCase) RevokeSession(ctx context.Context, accountID, sessionID string) error {
+	revoked, err := uc.authRepo.RevokeAccountSession(ctx, accountID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrSessionNotFound
+	}
+
+	slog.Info("session revoked", slog.String("account_id", accountID), slog.String("session_id", sessionID))
+	return nil
+}
+
+// UpgradeGuest returns no tokens when verified emails are required, the guest signs in once verified.
+func (uc *authUseCase) UpgradeGuest(ctx context.Context, sessionID string, req dto.SignUpRequest) (*dto.SignInResponse, error) {
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	session, err := uc.authRepo.GetSessionByID(ctx, tx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Kind != "guest" || session.RevokedAt != nil {
+		return nil, ErrSessionNotFound
+	}
+
+	accountID, err := uc.createAccount(ctx, tx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.authRepo.ConvertGuestSession(ctx, tx, sessionID, accountID); err != nil {
+		return nil, err
+	}
+
+	for _, migrator := range uc.guestMigrators {
+		if err := migrator.MigrateGuestData(ctx, tx, sessionID, accountID); err != nil {
+			slog.Warn("guest upgrade: migrate guest data failed, rolling back", slog.String("session_id", sessionID), slog.String("err", err.Error()))
+			return nil, err
+		}
+	}
+
+	// Like sign in, an unverified account gets no session: the guest data is kept
+	// on the new account but the guest session ends here
+	requireVerified := uc.cfg.Auth.RequireVerified
+	if requireVerified {
+		if err := uc.authRepo.RevokeSession(ctx, tx, sessionID); err != nil {
+			return nil, err
+		}
+	} else {
+		// Guest refresh token is archived so it can no longer be exchanged
+		previousHash := ""
+		if session.RefreshTokenHash != nil {
+			previousHash = *session.RefreshTokenHash
+		}
+		if err := session.Rotate(uc.cfg); err != nil {
+			return nil, err
+		}
+		if err := uc.authRepo.RotateSessionRefresh(ctx, tx, previousHash, session); err != nil {
+			return nil, err
+		}
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("guest upgrade: commit transaction failed", slog.String("session_id", sessionID), slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	if requireVerified {
+		slog.Info("guest upgrade success, awaiting email verification", slog.String("account_id", accountID), slog.String("session_id", sessionID))
+		uc.sendEmailVerificationAsync(accountID, strings.TrimSpace(strings.ToLower(req.Email)))
+		return nil, nil
+	}
+
+	accessToken, exp, err := uc.keys.NewAccessToken("user", accountID, sessionID, []string{rbac.RoleSwimmer}, uc.cfg.Auth.JWTAccessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("guest upgrade success", slog.String("account_id", accountID), slog.String("session_id", sessionID))
+
+	uc.sendEmailVerificationAsync(accountID, strings.TrimSpace(strings.ToLower(req.Email)))
+
+	user := req.ToUserEntity(accountID)
+	return &dto.SignInResponse{
+		Name:         user.Name,
+		Weight:       user.WeightKG,
+		Height:       user.HeightCM,
+		Age:          dates.AgePtr(user.BirthDate),
+		Email:        strings.TrimSpace(strings.ToLower(req.Email)),
+		Token:        accessToken,
+		RefreshToken: session.RefreshToken,
+		ExpiresInMs:  time.Until(exp).Milliseconds(),
+	}, nil
+}
+
+// ForgotPassword never reports whether the email exists, the work happens in the background
+// so the response time does not leak it either.
+func (uc *authUseCase) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error {
+	email := strings.TrimSpace(strings.ToLower(req.Email))
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+
+		if err := uc.sendPasswordReset(ctx, email); err != nil {
+			slog.Error("forgot password: send reset failed", slog.String("email", email), slog.String("err", err.Error()))
+		}
+	}()
+
+	return nil
+}
+
+func (uc *authUseCase) sendPasswordReset(ctx context.Context, email string) error {
+	auth, err := uc.authRepo.GetAuthByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidCreds) {
+			slog.Info("forgot password: unknown email", slog.String("email", email))
+			return nil
+		}
+		return err
+	}
+
+	if auth.IsLocked {
+		slog.Info("forgot password: account locked", slog.String("account_id", auth.AccountID))
+		return nil
+	}
+
+	since := time.Now().Add(-1 * time.Hour)
+	cnt, err := uc.authRepo.CountRecentAccountTokens(ctx, auth.AccountID, entity.TokenPurposePasswordReset, since)
+	if err != nil {
+		return err
+	}
+	if cnt >= passwordResetPerHour {
+		slog.Warn("forgot password: too many requests", slog.String("account_id", auth.AccountID))
+		return nil
+	}
+
+	token, err := entity.NewAccountToken(auth.AccountID, entity.TokenPurposePasswordReset, uc.cfg.Auth.PasswordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := uc.authRepo.CreateAccountToken(ctx, tx, token); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("forgot password: commit transaction failed", slog.String("account_id", auth.AccountID), slog.String("err", err.Error()))
+		return err
+	}
+
+	return uc.mailer.Send(ctx, uc.passwordResetMessage(auth.Email, token.Token, uc.cfg.Auth.PasswordResetTTL))
+}
+
+func (uc *authUseCase) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	accountID, err := uc.authRepo.ConsumeAccountToken(ctx, tx, entity.TokenPurposePasswordReset, security.SHA256Hex(strings.TrimSpace(req.Token)))
+	if err != nil {
+		return err
+	}
+
+	if err := uc.authRepo.UpdatePassword(ctx, tx, accountID, string(hash)); err != nil {
+		return err
+	}
+
+	// Any other outstanding reset link is now stale
+	if err := uc.authRepo.InvalidateAccountTokens(ctx, tx, accountID, entity.TokenPurposePasswordReset); err != nil {
+		return err
+	}
+
+	if err := uc.authRepo.RevokeAllSessions(ctx, tx, accountID); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("reset password: commit transaction failed", slog.String("account_id", accountID), slog.String("err", err.Error()))
+		return err
+	}
+
+	slog.Info("reset password success", slog.String("account_id", accountID))
+	return nil
+}
+
+func (uc *authUseCase) sendEmailVerificationAsync(accountID, email string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+
+		if err := uc.sendEmailVerification(ctx, accountID, email); err != nil {
+			slog.Error("email verification: send failed", slog.String("account_id", accountID), slog.String("err", err.Error()))
+		}
+	}()
+}
+
+func (uc *authUseCase) sendEmailVerification(ctx context.Context, accountID, email string) error {
+	last, err := uc.authRepo.GetLastAccountTokenAt(ctx, accountID, entity.TokenPurposeEmailVerify)
+	if err != nil {
+		return err
+	}
+	if last != nil && time.Since(*last) < emailVerifyCooldown {
+		slog.Info("email verification: throttled", slog.String("account_id", accountID))
+		return nil
+	}
+
+	since := time.Now().Add(-1 * time.Hour)
+	cnt, err := uc.authRepo.CountRecentAccountTokens(ctx, accountID, entity.TokenPurposeEmailVerify, since)
+	if err != nil {
+		return err
+	}
+	if cnt >= emailVerifyPerHour {
+		slog.Warn("email verification: too many requests", slog.String("account_id", accountID))
+		return nil
+	}
+
+	token, err := entity.NewAccountToken(accountID, entity.TokenPurposeEmailVerify, uc.cfg.Auth.EmailVerifyTTL)
+	if err != nil {
+		return err
+	}
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := uc.authRepo.CreateAccountToken(ctx, tx, token); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("email verification: commit transaction failed", slog.String("account_id", accountID), slog.String("err", err.Error()))
+		return err
+	}
+
+	return uc.mailer.Send(ctx, uc.emailVerificationMessage(email, token.Token, uc.cfg.Auth.EmailVerifyTTL))
+}
+
+func (uc *authUseCase) VerifyEmail(ctx context.Context, req dto.VerifyEmailRequest) error {
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	accountID, err := uc.authRepo.ConsumeAccountToken(ctx, tx, entity.TokenPurposeEmailVerify, security.SHA256Hex(strings.TrimSpace(req.Token)))
+	if err != nil {
+		return err
+	}
+
+	if err := uc.authRepo.MarkEmailVerified(ctx, tx, accountID); err != nil {
+		return err
+	}
+
+	if err := uc.authRepo.InvalidateAccountTokens(ctx, tx, accountID, entity.TokenPurposeEmailVerify); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("verify email: commit transaction failed", slog.String("account_id", accountID), slog.String("err", err.Error()))
+		return err
+	}
+
+	slog.Info("verify email success", slog.String("account_id", accountID))
+	return nil
+}
+
+// ResendVerification answers the same way whether or not the email exists.
+func (uc *authUseCase) ResendVerification(ctx context.Context, req dto.ResendVerificationRequest) error {
+	email := strings.TrimSpace(strings.ToLower(req.Email))
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+
+		auth, err := uc.authRepo.GetAuthByEmail(ctx, email)
+		if err != nil {
+			if !errors.Is(err, entity.ErrInvalidCreds) {
+				slog.Error("resend verification: lookup failed", slog.String("email", email), slog.String("err", err.Error()))
+			}
+			return
+		}
+
+		if auth.IsLocked || auth.EmailVerifiedAt != nil {
+			return
+		}
+
+		if err := uc.sendEmailVerification(ctx, auth.AccountID, auth.Email); err != nil {
+			slog.Error("resend verification: send failed", slog.String("account_id", auth.AccountID), slog.String("err", err.Error()))
+		}
+	}()
+
+	return nil
+}
+
+// JWKS publishes the public signing keys so other services can verify access tokens.
+func (uc *authUseCase) JWKS() security.JWKS {
+	return uc.keys.JWKS()
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"errors"
+	"haphap/swimo-api/internal/app/pace"
+	"haphap/swimo-api/internal/app/pace/dto"
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/validator"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PaceHandler struct {
+	paceUsecase pace.PaceUseCase
+}
+
+func NewPaceHandler(paceUsecase pace.PaceUseCase) *PaceHandler {
+	return &PaceHandler{paceUsecase}
+}
+
+func (h *PaceHandler) RecordTest(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var req dto.CSSTestRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.paceUsecase.RecordTest(c.Context(), principal.AccountID, req)
+	if err != nil {
+		return paceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.Base{
+		Data:    out,
+		Message: "CSS test recorded successfully.",
+	})
+}
+
+func (h *PaceHandler) ListTests(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var query dto.ListCSSTestsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid query parameters."})
+	}
+
+	out, total, err := h.paceUsecase.ListTests(c.Context(), principal.AccountID, query)
+	if err != nil {
+		return err
+	}
+
+	limit, offset := response.NormalizePage(query.Limit, query.Offset)
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    response.Page{Items: out, Total: total, Limit: limit, Offset: offset},
+		Message: "CSS tests retrieved successfully.",
+	})
+}
+
+func (h *PaceHandler) GetTest(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	testID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(testID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "CSS test not found."})
+	}
+
+	out, err := h.paceUsecase.GetTest(c.Context(), principal.AccountID, testID)
+	if err != nil {
+		return paceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "CSS test retrieved successfully.",
+	})
+}
+
+func (h *PaceHandler) DeleteTest(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	testID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(testID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "CSS test not found."})
+	}
+
+	if err := h.paceUsecase.DeleteTest(c.Context(), principal.AccountID, testID); err != nil {
+		return paceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "CSS test deleted successfully."})
+}
+
+func (h *PaceHandler) GetZones(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	out, err := h.paceUsecase.GetZones(c.Context(), principal.AccountID)
+	if err != nil {
+		return paceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Pace zones retrieved successfully.",
+	})
+}
+
+func paceError(c *fiber.Ctx, err error) error {
+	var validationErr *validator.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: validationErr},
+		)
+	case errors.Is(err, pace.ErrTestNotFound):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "CSS test not found."})
+	case errors.Is(err, pace.ErrNoThresholdPace):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "No threshold pace yet, record a CSS test first."})
+	default:
+		return err
+	}
+}
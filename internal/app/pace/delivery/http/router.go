@@ -0,0 +1,20 @@
+package http
+
+import (
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/rbac"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func Register(app *fiber.App, paceHandler *PaceHandler, authMw *middleware.AuthMiddleware) {
+	paces := app.Group("/api/v1/pace",
+		authMw.Require(middleware.UserOnly),
+		middleware.RequirePermission(rbac.PermManageOwnData),
+	)
+	paces.Get("/zones", paceHandler.GetZones)
+	paces.Get("/tests", paceHandler.ListTests)
+	paces.Post("/tests", paceHandler.RecordTest)
+	paces.Get("/tests/:id", paceHandler.GetTest)
+	paces.Delete("/tests/:id", paceHandler.DeleteTest)
+}
@@ -0,0 +1,156 @@
+package dto
+
+import (
+	"fmt"
+	"haphap/swimo-api/internal/app/pace/entity"
+	"haphap/swimo-api/pkg/dates"
+	"haphap/swimo-api/pkg/swim"
+	"haphap/swimo-api/pkg/units"
+	"haphap/swimo-api/pkg/validator"
+	"math"
+	"time"
+)
+
+// maxTrialSec bounds a single time trial, a 400 in an hour is already a walk.
+const maxTrialSec = 60 * 60
+
+type (
+	// CSSTestRequest takes the trial times in seconds, both swum in the user's distance unit.
+	CSSTestRequest struct {
+		Date           string  `json:"date"` // YYYY-MM-DD
+		Time400Seconds float64 `json:"time400Seconds"`
+		Time200Seconds float64 `json:"time200Seconds"`
+	}
+
+	ListCSSTestsQuery struct {
+		Limit  int `query:"limit"`
+		Offset int `query:"offset"`
+	}
+
+	CSSTestResponse struct {
+		ID             string         `json:"id"`
+		Date           string         `json:"date"`
+		DistanceUnit   string         `json:"distanceUnit"`
+		Time400Seconds float64        `json:"time400Seconds"`
+		Time200Seconds float64        `json:"time200Seconds"`
+		CSSPace        float64        `json:"cssPace"` // seconds per 100 of distanceUnit
+		Zones          []ZoneResponse `json:"zones"`
+		CreatedAt      time.Time      `json:"createdAt"`
+	}
+
+	// ZonesResponse are the paces to train at, per 100 of DistanceUnit.
+	ZonesResponse struct {
+		DistanceUnit string         `json:"distanceUnit"`
+		CSSPace      float64        `json:"cssPace"`
+		Zones        []ZoneResponse `json:"zones"`
+	}
+
+	ZoneResponse struct {
+		Name       string  `json:"name"`
+		FastPace   float64 `json:"fastPace"`
+		SlowPace   float64 `json:"slowPace"`
+		TargetPace float64 `json:"targetPace"`
+		Clock      string  `json:"clock"` // ex: 1:38-1:43
+	}
+)
+
+func (r *CSSTestRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if date, err := dates.Parse(r.Date); err != nil {
+		errors["date"] = "Date must be formatted as YYYY-MM-DD"
+	} else if date.After(time.Now().Add(24 * time.Hour)) {
+		// one day of slack for clients ahead of UTC
+		errors["date"] = "Date cannot be in the future"
+	}
+
+	if r.Time400Seconds <= 0 || r.Time400Seconds > maxTrialSec {
+		errors["time400Seconds"] = "400 time must be between 1 second and 1 hour"
+	}
+	if r.Time200Seconds <= 0 || r.Time200Seconds > maxTrialSec {
+		errors["time200Seconds"] = "200 time must be between 1 second and 1 hour"
+	}
+
+	if len(errors) == 0 {
+		// the 400 is always swum slower per 100 than the 200
+		if r.Time400Seconds <= 2*r.Time200Seconds {
+			errors["time400Seconds"] = "400 time must be more than twice the 200 time"
+		}
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+// ToEntity computes the CSS of a validated request, the pace bounds depend on the unit.
+func (r *CSSTestRequest) ToEntity(accountID string, pref units.Preference) (*entity.CSSTest, *validator.ValidationError) {
+	date, _ := dates.Parse(r.Date)
+
+	pace := pref.PaceToSecPer100m(swim.CSSPace(r.Time400Seconds, r.Time200Seconds))
+	if pace < swim.MinThresholdPace || pace > swim.MaxThresholdPace {
+		return nil, &validator.ValidationError{Errors: map[string]string{
+			"time400Seconds": "CSS must be between 0:30 and 10:00 per 100m",
+		}}
+	}
+
+	return &entity.CSSTest{
+		AccountID:    accountID,
+		Date:         date,
+		DistanceUnit: pref.Distance,
+		Time400Sec:   r.Time400Seconds,
+		Time200Sec:   r.Time200Seconds,
+		CSSPaceSec:   pace,
+	}, nil
+}
+
+// ToCSSTestResponse shows the pace and zones in the unit the trials were swum in.
+func ToCSSTestResponse(test *entity.CSSTest) CSSTestResponse {
+	pref := units.Preference{Distance: test.DistanceUnit}
+	pace := pref.PaceFromSecPer100m(test.CSSPaceSec)
+
+	return CSSTestResponse{
+		ID:             test.ID,
+		Date:           test.Date.Format(dates.Layout),
+		DistanceUnit:   test.DistanceUnit,
+		Time400Seconds: test.Time400Sec,
+		Time200Seconds: test.Time200Sec,
+		CSSPace:        pace,
+		Zones:          toZoneResponses(pace),
+		CreatedAt:      test.CreatedAt,
+	}
+}
+
+func ToZonesResponse(thresholdPaceSec float64, pref units.Preference) ZonesResponse {
+	pace := pref.PaceFromSecPer100m(thresholdPaceSec)
+
+	return ZonesResponse{
+		DistanceUnit: pref.Distance,
+		CSSPace:      pace,
+		Zones:        toZoneResponses(pace),
+	}
+}
+
+func toZoneResponses(cssPace float64) []ZoneResponse {
+	out := make([]ZoneResponse, 0, len(swim.Zones()))
+	for _, zone := range swim.Zones() {
+		fast := units.Round(cssPace+zone.FastOffset, 1)
+		slow := units.Round(cssPace+zone.SlowOffset, 1)
+		out = append(out, ZoneResponse{
+			Name:       zone.Name,
+			FastPace:   fast,
+			SlowPace:   slow,
+			TargetPace: units.Round(cssPace+zone.Target(), 1),
+			Clock:      formatClock(fast) + "-" + formatClock(slow),
+		})
+	}
+	return out
+}
+
+// formatClock prints seconds as m:ss, rounded to the second.
+func formatClock(sec float64) string {
+	s := int(math.Round(sec))
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
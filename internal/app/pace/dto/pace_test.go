@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"haphap/swimo-api/pkg/units"
+	"testing"
+)
+
+func TestCSSTestToEntity(t *testing.T) {
+	tests := []struct {
+		name    string
+		unit    string
+		req     CSSTestRequest
+		want    float64 // seconds per 100m
+		wantErr bool
+	}{
+		{"meters", units.DistanceMeters, CSSTestRequest{Date: "2026-01-05", Time400Seconds: 360, Time200Seconds: 170}, 95, false},
+		{"yards", units.DistanceYards, CSSTestRequest{Date: "2026-01-05", Time400Seconds: 360, Time200Seconds: 170}, 103.89, false},
+		{"faster than the bounds", units.DistanceMeters, CSSTestRequest{Date: "2026-01-05", Time400Seconds: 100, Time200Seconds: 60}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test, verr := tt.req.ToEntity("account-1", units.Preference{Distance: tt.unit})
+			if tt.wantErr {
+				if verr == nil {
+					t.Fatalf("ToEntity() = %+v, want a validation error", test)
+				}
+				return
+			}
+			if verr != nil {
+				t.Fatalf("ToEntity: %v", verr.Errors)
+			}
+			if test.CSSPaceSec != tt.want {
+				t.Fatalf("CSSPaceSec = %v, want %v", test.CSSPaceSec, tt.want)
+			}
+		})
+	}
+}
+
+func TestToZonesResponse(t *testing.T) {
+	got := ToZonesResponse(103.89, units.Preference{Distance: units.DistanceYards})
+
+	if got.CSSPace != 95 {
+		t.Fatalf("CSSPace = %v, want 95 per 100yd", got.CSSPace)
+	}
+
+	want := map[string]ZoneResponse{
+		"recovery":  {Name: "recovery", FastPace: 107, SlowPace: 115, TargetPace: 111, Clock: "1:47-1:55"},
+		"endurance": {Name: "endurance", FastPace: 100, SlowPace: 105, TargetPace: 102.5, Clock: "1:40-1:45"},
+		"threshold": {Name: "threshold", FastPace: 94, SlowPace: 98, TargetPace: 96, Clock: "1:34-1:38"},
+		"vo2max":    {Name: "vo2max", FastPace: 89, SlowPace: 93, TargetPace: 91, Clock: "1:29-1:33"},
+	}
+	if len(got.Zones) != len(want) {
+		t.Fatalf("%d zones, want %d", len(got.Zones), len(want))
+	}
+	for _, zone := range got.Zones {
+		if zone != want[zone.Name] {
+			t.Fatalf("zone %q = %+v, want %+v", zone.Name, zone, want[zone.Name])
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"haphap/swimo-api/pkg/units"
+	"time"
+)
+
+type (
+	// CSSTest is a 400 and a 200 time trial swum in DistanceUnit, CSSPaceSec is
+	// the Critical Swim Speed they give in seconds per 100m.
+	CSSTest struct {
+		ID           string
+		AccountID    string
+		Date         time.Time
+		DistanceUnit string
+		Time400Sec   float64
+		Time200Sec   float64
+		CSSPaceSec   float64
+		CreatedAt    time.Time
+	}
+
+	// Swimmer is the users row as pace zones need it.
+	Swimmer struct {
+		DistanceUnit     string
+		ThresholdPaceSec *float64 // per 100m, set by the latest CSS test or the profile
+	}
+)
+
+func (s *Swimmer) Units() units.Preference {
+	return units.Preference{Distance: s.DistanceUnit}
+}
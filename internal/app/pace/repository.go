@@ -0,0 +1,153 @@
+package pace
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/pace/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrTestNotFound = errors.New("css test not found")
+)
+
+type PaceRepository interface {
+	GetSwimmer(ctx context.Context, accountID string) (*entity.Swimmer, error)
+	CreateTest(ctx context.Context, tx pgx.Tx, test *entity.CSSTest) error
+	GetTest(ctx context.Context, accountID, testID string) (*entity.CSSTest, error)
+	ListTests(ctx context.Context, accountID string, limit, offset int) ([]entity.CSSTest, int, error)
+	DeleteTest(ctx context.Context, tx pgx.Tx, accountID, testID string) error
+	SyncThresholdPace(ctx context.Context, tx pgx.Tx, accountID string) error
+}
+
+type paceRepository struct{ db *pgxpool.Pool }
+
+func NewPaceRepository(db *pgxpool.Pool) PaceRepository { return &paceRepository{db: db} }
+
+func (r *paceRepository) GetSwimmer(ctx context.Context, accountID string) (*entity.Swimmer, error) {
+	const sql = `SELECT distance_unit, threshold_pace_sec FROM users WHERE account_id = $1`
+
+	var swimmer entity.Swimmer
+	if err := r.db.QueryRow(ctx, sql, accountID).Scan(&swimmer.DistanceUnit, &swimmer.ThresholdPaceSec); err != nil {
+		return nil, err
+	}
+
+	return &swimmer, nil
+}
+
+func (r *paceRepository) CreateTest(ctx context.Context, tx pgx.Tx, test *entity.CSSTest) error {
+	const sql = `
+		INSERT INTO css_tests (account_id, tested_on, distance_unit, time_400_sec, time_200_sec, css_pace_sec)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	return tx.QueryRow(ctx, sql,
+		test.AccountID,
+		test.Date,
+		test.DistanceUnit,
+		test.Time400Sec,
+		test.Time200Sec,
+		test.CSSPaceSec,
+	).Scan(&test.ID, &test.CreatedAt)
+}
+
+func (r *paceRepository) GetTest(ctx context.Context, accountID, testID string) (*entity.CSSTest, error) {
+	const sql = `
+		SELECT id, account_id, tested_on, distance_unit, time_400_sec, time_200_sec, css_pace_sec, created_at
+		FROM css_tests
+		WHERE id = $1 AND account_id = $2`
+
+	var test entity.CSSTest
+	if err := r.db.QueryRow(ctx, sql, testID, accountID).Scan(
+		&test.ID,
+		&test.AccountID,
+		&test.Date,
+		&test.DistanceUnit,
+		&test.Time400Sec,
+		&test.Time200Sec,
+		&test.CSSPaceSec,
+		&test.CreatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTestNotFound
+		}
+		return nil, err
+	}
+
+	return &test, nil
+}
+
+// ListTests returns a page of the test history, newest first, and the total count.
+func (r *paceRepository) ListTests(ctx context.Context, accountID string, limit, offset int) ([]entity.CSSTest, int, error) {
+	const sql = `
+		SELECT
+			id, account_id, tested_on, distance_unit, time_400_sec, time_200_sec, css_pace_sec, created_at,
+			COUNT(*) OVER ()
+		FROM css_tests
+		WHERE account_id = $1
+		ORDER BY tested_on DESC, created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(ctx, sql, accountID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	total := 0
+	tests := make([]entity.CSSTest, 0)
+	for rows.Next() {
+		var test entity.CSSTest
+		if err := rows.Scan(
+			&test.ID,
+			&test.AccountID,
+			&test.Date,
+			&test.DistanceUnit,
+			&test.Time400Sec,
+			&test.Time200Sec,
+			&test.CSSPaceSec,
+			&test.CreatedAt,
+			&total,
+		); err != nil {
+			return nil, 0, err
+		}
+		tests = append(tests, test)
+	}
+
+	return tests, total, rows.Err()
+}
+
+func (r *paceRepository) DeleteTest(ctx context.Context, tx pgx.Tx, accountID, testID string) error {
+	const sql = `DELETE FROM css_tests WHERE id = $1 AND account_id = $2`
+
+	tag, err := tx.Exec(ctx, sql, testID, accountID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTestNotFound
+	}
+
+	return nil
+}
+
+// SyncThresholdPace copies the CSS of the latest test to the profile. Without tests
+// the profile keeps its pace, it may have been entered by hand.
+func (r *paceRepository) SyncThresholdPace(ctx context.Context, tx pgx.Tx, accountID string) error {
+	const sql = `
+		UPDATE users AS u
+		SET threshold_pace_sec = t.css_pace_sec, updated_at = now()
+		FROM (
+			SELECT css_pace_sec
+			FROM css_tests
+			WHERE account_id = $1
+			ORDER BY tested_on DESC, created_at DESC
+			LIMIT 1
+		) AS t
+		WHERE u.account_id = $1`
+
+	_, err := tx.Exec(ctx, sql, accountID)
+	return err
+}
@@ -0,0 +1,161 @@
+package pace
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/pace/dto"
+	"haphap/swimo-api/pkg/response"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrNoThresholdPace = errors.New("threshold pace is not known")
+)
+
+type PaceUseCase interface {
+	RecordTest(ctx context.Context, accountID string, req dto.CSSTestRequest) (*dto.CSSTestResponse, error)
+	GetTest(ctx context.Context, accountID, testID string) (*dto.CSSTestResponse, error)
+	ListTests(ctx context.Context, accountID string, query dto.ListCSSTestsQuery) ([]dto.CSSTestResponse, int, error)
+	DeleteTest(ctx context.Context, accountID, testID string) error
+	GetZones(ctx context.Context, accountID string) (*dto.ZonesResponse, error)
+	ExportAccountData(ctx context.Context, accountID string) (map[string]any, error)
+}
+
+type paceUseCase struct {
+	pool     *pgxpool.Pool
+	paceRepo PaceRepository
+}
+
+func NewPaceUseCase(pool *pgxpool.Pool, paceRepo PaceRepository) PaceUseCase {
+	return &paceUseCase{pool, paceRepo}
+}
+
+// RecordTest stores a CSS test, the latest test by date becomes the threshold pace.
+func (uc *paceUseCase) RecordTest(ctx context.Context, accountID string, req dto.CSSTestRequest) (*dto.CSSTestResponse, error) {
+	swimmer, err := uc.paceRepo.GetSwimmer(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	test, verr := req.ToEntity(accountID, swimmer.Units())
+	if verr != nil {
+		return nil, verr
+	}
+
+	err = uc.changeTests(ctx, accountID, func(tx pgx.Tx) error {
+		return uc.paceRepo.CreateTest(ctx, tx, test)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("css test recorded", slog.String("account_id", accountID), slog.String("test_id", test.ID))
+
+	out := dto.ToCSSTestResponse(test)
+	return &out, nil
+}
+
+func (uc *paceUseCase) GetTest(ctx context.Context, accountID, testID string) (*dto.CSSTestResponse, error) {
+	test, err := uc.paceRepo.GetTest(ctx, accountID, testID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := dto.ToCSSTestResponse(test)
+	return &out, nil
+}
+
+func (uc *paceUseCase) ListTests(ctx context.Context, accountID string, query dto.ListCSSTestsQuery) ([]dto.CSSTestResponse, int, error) {
+	limit, offset := response.NormalizePage(query.Limit, query.Offset)
+
+	tests, total, err := uc.paceRepo.ListTests(ctx, accountID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]dto.CSSTestResponse, 0, len(tests))
+	for i := range tests {
+		out = append(out, dto.ToCSSTestResponse(&tests[i]))
+	}
+
+	return out, total, nil
+}
+
+// DeleteTest removes a test, the threshold pace falls back to the previous one.
+func (uc *paceUseCase) DeleteTest(ctx context.Context, accountID, testID string) error {
+	err := uc.changeTests(ctx, accountID, func(tx pgx.Tx) error {
+		return uc.paceRepo.DeleteTest(ctx, tx, accountID, testID)
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.Info("css test deleted", slog.String("account_id", accountID), slog.String("test_id", testID))
+	return nil
+}
+
+// GetZones derives the pace zones from the current threshold pace.
+func (uc *paceUseCase) GetZones(ctx context.Context, accountID string) (*dto.ZonesResponse, error) {
+	swimmer, err := uc.paceRepo.GetSwimmer(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if swimmer.ThresholdPaceSec == nil {
+		return nil, ErrNoThresholdPace
+	}
+
+	out := dto.ToZonesResponse(*swimmer.ThresholdPaceSec, swimmer.Units())
+	return &out, nil
+}
+
+// ExportAccountData contributes the CSS test history to the account export.
+func (uc *paceUseCase) ExportAccountData(ctx context.Context, accountID string) (map[string]any, error) {
+	out := make([]dto.CSSTestResponse, 0)
+	offset := 0
+	for {
+		tests, total, err := uc.paceRepo.ListTests(ctx, accountID, response.MaxPageLimit, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range tests {
+			out = append(out, dto.ToCSSTestResponse(&tests[i]))
+		}
+
+		offset += len(tests)
+		if len(tests) == 0 || offset >= total {
+			break
+		}
+	}
+
+	return map[string]any{"cssTests": out}, nil
+}
+
+// changeTests runs change and moves the threshold pace to the latest test in one transaction.
+func (uc *paceUseCase) changeTests(ctx context.Context, accountID string, change func(tx pgx.Tx) error) error {
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := change(tx); err != nil {
+		return err
+	}
+
+	if err := uc.paceRepo.SyncThresholdPace(ctx, tx, accountID); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("css tests: commit transaction failed", slog.String("account_id", accountID), slog.String("err", err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"errors"
+	"haphap/swimo-api/internal/app/plan"
+	"haphap/swimo-api/internal/app/plan/dto"
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/validator"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type PlanHandler struct {
+	planUsecase plan.PlanUseCase
+}
+
+func NewPlanHandler(planUsecase plan.PlanUseCase) *PlanHandler {
+	return &PlanHandler{planUsecase}
+}
+
+func (h *PlanHandler) CreatePlan(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var req dto.PlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.planUsecase.CreatePlan(c.Context(), principal.AccountID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.Base{
+		Data:    out,
+		Message: "Plan created successfully.",
+	})
+}
+
+func (h *PlanHandler) ListPlans(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var query dto.ListPlansQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid query parameters."})
+	}
+
+	out, total, err := h.planUsecase.ListPlans(c.Context(), principal.AccountID, query)
+	if err != nil {
+		return err
+	}
+
+	limit, offset := response.NormalizePage(query.Limit, query.Offset)
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    response.Page{Items: out, Total: total, Limit: limit, Offset: offset},
+		Message: "Plans retrieved successfully.",
+	})
+}
+
+func (h *PlanHandler) GetPlan(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	planID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(planID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Plan not found."})
+	}
+
+	out, err := h.planUsecase.GetPlan(c.Context(), principal.AccountID, planID)
+	if err != nil {
+		return planError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Plan retrieved successfully.",
+	})
+}
+
+func (h *PlanHandler) UpdatePlan(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	planID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(planID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Plan not found."})
+	}
+
+	var req dto.PlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.planUsecase.UpdatePlan(c.Context(), principal.AccountID, planID, req)
+	if err != nil {
+		return planError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Plan updated successfully.",
+	})
+}
+
+func (h *PlanHandler) DuplicatePlan(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	planID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(planID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Plan not found."})
+	}
+
+	out, err := h.planUsecase.DuplicatePlan(c.Context(), principal.AccountID, planID)
+	if err != nil {
+		return planError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.Base{
+		Data:    out,
+		Message: "Plan duplicated successfully.",
+	})
+}
+
+func (h *PlanHandler) DeletePlan(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	planID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(planID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Plan not found."})
+	}
+
+	if err := h.planUsecase.DeletePlan(c.Context(), principal.AccountID, planID); err != nil {
+		return planError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Plan deleted successfully."})
+}
+
+// ParsePlan previews shorthand as blocks without saving anything.
+func (h *PlanHandler) ParsePlan(c *fiber.Ctx) error {
+	var req dto.ParsePlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	blocks, err := req.Validate()
+	if err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    dto.ToParsePlanResponse(blocks),
+		Message: "Plan parsed successfully.",
+	})
+}
+
+func planError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, plan.ErrPlanNotFound):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Plan not found."})
+	case errors.Is(err, plan.ErrPlanInUse):
+		return c.Status(http.StatusConflict).JSON(response.Base{Message: "Plan is used by a training program."})
+	default:
+		return err
+	}
+}
@@ -0,0 +1,22 @@
+package http
+
+import (
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/rbac"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func Register(app *fiber.App, planHandler *PlanHandler, authMw *middleware.AuthMiddleware) {
+	plans := app.Group("/api/v1/plans",
+		authMw.Require(middleware.UserOnly),
+		middleware.RequirePermission(rbac.PermManageOwnData),
+	)
+	plans.Get("", planHandler.ListPlans)
+	plans.Post("", planHandler.CreatePlan)
+	plans.Post("/parse", planHandler.ParsePlan)
+	plans.Get("/:id", planHandler.GetPlan)
+	plans.Put("/:id", planHandler.UpdatePlan)
+	plans.Delete("/:id", planHandler.DeletePlan)
+	plans.Post("/:id/duplicate", planHandler.DuplicatePlan)
+}
@@ -0,0 +1,302 @@
+package dto
+
+import (
+	"fmt"
+	"haphap/swimo-api/internal/app/plan/entity"
+	"haphap/swimo-api/pkg/swim"
+	"haphap/swimo-api/pkg/units"
+	"haphap/swimo-api/pkg/validator"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	maxNameLength   = 120
+	maxTextLength   = 2000
+	maxSteps        = 200
+	maxRepeat       = 20
+	maxReps         = 100
+	maxStepDistance = 10000
+	maxStepSeconds  = 60 * 60
+)
+
+type (
+	// PlanRequest takes the plan either as shorthand Text or as Blocks.
+	// DistanceUnit defaults to the user's unit on create and is kept on update.
+	PlanRequest struct {
+		Name         string         `json:"name"`
+		Description  *string        `json:"description"`
+		DistanceUnit *string        `json:"distanceUnit"`
+		Text         *string        `json:"text"`
+		Blocks       []entity.Block `json:"blocks"`
+	}
+
+	ParsePlanRequest struct {
+		Text string `json:"text"`
+	}
+
+	ListPlansQuery struct {
+		Limit  int `query:"limit"`
+		Offset int `query:"offset"`
+	}
+
+	PlanResponse struct {
+		ID            string         `json:"id"`
+		Name          string         `json:"name"`
+		Description   *string        `json:"description"`
+		DistanceUnit  string         `json:"distanceUnit"`
+		TotalDistance float64        `json:"totalDistance"`
+		Blocks        []entity.Block `json:"blocks"`
+		Text          string         `json:"text"`
+		// CSSPace and the resolved plan are set when steps are swum at a pace and
+		// the user has a CSS, the pace is in seconds per 100 of DistanceUnit.
+		CSSPace        *float64       `json:"cssPace,omitempty"`
+		ResolvedBlocks []entity.Block `json:"resolvedBlocks,omitempty"`
+		ResolvedText   string         `json:"resolvedText,omitempty"`
+		CreatedAt      time.Time      `json:"createdAt"`
+		UpdatedAt      time.Time      `json:"updatedAt"`
+	}
+
+	PlanSummaryResponse struct {
+		ID            string    `json:"id"`
+		Name          string    `json:"name"`
+		Description   *string   `json:"description"`
+		DistanceUnit  string    `json:"distanceUnit"`
+		TotalDistance float64   `json:"totalDistance"`
+		CreatedAt     time.Time `json:"createdAt"`
+		UpdatedAt     time.Time `json:"updatedAt"`
+	}
+
+	ParsePlanResponse struct {
+		Blocks        []entity.Block `json:"blocks"`
+		TotalDistance float64        `json:"totalDistance"`
+		Text          string         `json:"text"` // canonical shorthand
+	}
+)
+
+// Validate also parses Text into Blocks when the plan is sent as shorthand.
+func (r *PlanRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	name := strings.TrimSpace(r.Name)
+	if name == "" {
+		errors["name"] = "Name is required"
+	} else if len(name) > maxNameLength {
+		errors["name"] = fmt.Sprintf("Name cannot be longer than %d characters", maxNameLength)
+	}
+
+	if r.Description != nil && len(*r.Description) > maxTextLength {
+		errors["description"] = fmt.Sprintf("Description cannot be longer than %d characters", maxTextLength)
+	}
+
+	if r.DistanceUnit != nil && !units.IsValidDistance(*r.DistanceUnit) {
+		errors["distanceUnit"] = "Distance unit must be m or yd"
+	}
+
+	switch {
+	case r.Text != nil && len(r.Blocks) > 0:
+		errors["text"] = "Send either text or blocks, not both"
+	case r.Text != nil:
+		blocks, err := parseText(*r.Text)
+		if err != "" {
+			errors["text"] = err
+			break
+		}
+		r.Blocks = blocks
+		validateBlocks(errors, "text", r.Blocks)
+	default:
+		validateBlocks(errors, "blocks", r.Blocks)
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+// Apply copies a validated request into plan.
+func (r *PlanRequest) Apply(plan *entity.Plan) {
+	plan.Name = strings.TrimSpace(r.Name)
+	plan.Description = nil
+	if r.Description != nil {
+		if description := strings.TrimSpace(*r.Description); description != "" {
+			plan.Description = &description
+		}
+	}
+	if r.DistanceUnit != nil {
+		plan.DistanceUnit = *r.DistanceUnit
+	}
+	plan.Blocks = r.Blocks
+	plan.TotalDistance = units.Round(entity.TotalDistance(r.Blocks), 2)
+}
+
+// Validate parses the shorthand, the result is returned by Blocks.
+func (r *ParsePlanRequest) Validate() ([]entity.Block, *validator.ValidationError) {
+	errors := make(map[string]string)
+
+	blocks, err := parseText(r.Text)
+	if err != "" {
+		errors["text"] = err
+	} else {
+		validateBlocks(errors, "text", blocks)
+	}
+
+	if len(errors) > 0 {
+		return nil, &validator.ValidationError{Errors: errors}
+	}
+
+	return blocks, nil
+}
+
+func parseText(text string) ([]entity.Block, string) {
+	if strings.TrimSpace(text) == "" {
+		return nil, "Text is required"
+	}
+	if len(text) > 20*maxTextLength {
+		return nil, "Text is too long"
+	}
+
+	blocks, err := entity.ParseShorthand(text)
+	if err != nil {
+		return nil, err.Error()
+	}
+
+	return blocks, ""
+}
+
+// validateBlocks reports problems of the tree keyed by path, ex: blocks[1].steps[0].reps.
+// Errors of parsed shorthand are all reported under field.
+func validateBlocks(errors map[string]string, field string, blocks []entity.Block) {
+	if len(blocks) == 0 {
+		errors[field] = "A plan needs at least one block"
+		return
+	}
+
+	path := func(p string) string {
+		if field == "text" {
+			return field
+		}
+		return p
+	}
+
+	count := 0
+	var validateStep func(p string, step *entity.Step, depth int)
+	validateStep = func(p string, step *entity.Step, depth int) {
+		count++
+
+		if step.IsGroup() {
+			if depth >= entity.MaxGroupDepth {
+				errors[path(p)] = fmt.Sprintf("Repeat groups cannot be nested more than %d deep", entity.MaxGroupDepth)
+				return
+			}
+			if step.Repeat < 1 || step.Repeat > maxRepeat {
+				errors[path(p+".repeat")] = fmt.Sprintf("Repeat must be between 1 and %d", maxRepeat)
+			}
+			for i := range step.Steps {
+				validateStep(fmt.Sprintf("%s.steps[%d]", p, i), &step.Steps[i], depth+1)
+			}
+			return
+		}
+
+		if step.Reps < 1 || step.Reps > maxReps {
+			errors[path(p+".reps")] = fmt.Sprintf("Reps must be between 1 and %d", maxReps)
+		}
+		if step.Distance <= 0 || step.Distance > maxStepDistance {
+			errors[path(p+".distance")] = fmt.Sprintf("Distance must be between 1 and %d", maxStepDistance)
+		}
+		if step.Stroke != "" && !swim.IsValidStroke(step.Stroke) {
+			errors[path(p+".stroke")] = "Stroke must be one of " + strings.Join(swim.Strokes(), ", ")
+		}
+		if step.SendOffSec < 0 || step.SendOffSec > maxStepSeconds {
+			errors[path(p+".sendOffSec")] = "Send-off must be between 0 and 60 minutes"
+		}
+		if step.Pace != "" {
+			if pace, ok := entity.ParsePace(step.Pace); !ok {
+				errors[path(p+".pace")] = "Pace must be CSS, CSS+5, CSS-3 or one of " + strings.Join(swim.ZoneNames(), ", ")
+			} else if step.SendOffSec > 0 {
+				errors[path(p+".pace")] = "Use either a send-off or a pace"
+			} else {
+				step.Pace = pace
+			}
+		}
+		if step.RestSec < 0 || step.RestSec > maxStepSeconds {
+			errors[path(p+".restSec")] = "Rest must be between 0 and 60 minutes"
+		}
+		for _, item := range step.Equipment {
+			if !slices.Contains(entity.Equipment(), item) {
+				errors[path(p+".equipment")] = "Equipment must be among " + strings.Join(entity.Equipment(), ", ")
+			}
+		}
+		step.Description = strings.Join(strings.Fields(step.Description), " ")
+		step.Note = strings.Join(strings.Fields(step.Note), " ")
+		if len(step.Description) > maxNameLength || len(step.Note) > maxNameLength {
+			errors[path(p)] = fmt.Sprintf("Description and note cannot be longer than %d characters", maxNameLength)
+		} else if !entity.ShorthandRoundTrips(step) {
+			// every plan is printed back as shorthand, it has to read the same
+			errors[path(p+".description")] = "Description cannot contain #, w/, @ or a rest like r:20, nor start with a stroke when the step has none"
+		}
+	}
+
+	for i := range blocks {
+		p := fmt.Sprintf("%s[%d]", field, i)
+		if !slices.Contains(entity.Sections(), blocks[i].Section) {
+			errors[path(p+".section")] = "Section must be one of " + strings.Join(entity.Sections(), ", ")
+		}
+		if len(blocks[i].Steps) == 0 {
+			errors[path(p+".steps")] = "A block needs at least one step"
+		}
+		for j := range blocks[i].Steps {
+			validateStep(fmt.Sprintf("%s.steps[%d]", p, j), &blocks[i].Steps[j], 0)
+		}
+	}
+
+	if count > maxSteps {
+		errors[field] = fmt.Sprintf("A plan cannot have more than %d steps", maxSteps)
+	}
+}
+
+func ToPlanResponse(plan *entity.Plan) PlanResponse {
+	return PlanResponse{
+		ID:            plan.ID,
+		Name:          plan.Name,
+		Description:   plan.Description,
+		DistanceUnit:  plan.DistanceUnit,
+		TotalDistance: plan.TotalDistance,
+		Blocks:        plan.Blocks,
+		Text:          entity.FormatShorthand(plan.Blocks),
+		CreatedAt:     plan.CreatedAt,
+		UpdatedAt:     plan.UpdatedAt,
+	}
+}
+
+// Resolve adds the plan with concrete send-offs for a swimmer whose CSS is
+// thresholdPace seconds per 100m.
+func (r *PlanResponse) Resolve(thresholdPace float64) {
+	pace := units.Preference{Distance: r.DistanceUnit}.PaceFromSecPer100m(thresholdPace)
+
+	r.CSSPace = &pace
+	r.ResolvedBlocks = entity.ResolvePaces(r.Blocks, pace)
+	r.ResolvedText = entity.FormatShorthand(r.ResolvedBlocks)
+}
+
+func ToPlanSummaryResponse(plan *entity.Plan) PlanSummaryResponse {
+	return PlanSummaryResponse{
+		ID:            plan.ID,
+		Name:          plan.Name,
+		Description:   plan.Description,
+		DistanceUnit:  plan.DistanceUnit,
+		TotalDistance: plan.TotalDistance,
+		CreatedAt:     plan.CreatedAt,
+		UpdatedAt:     plan.UpdatedAt,
+	}
+}
+
+func ToParsePlanResponse(blocks []entity.Block) ParsePlanResponse {
+	return ParsePlanResponse{
+		Blocks:        blocks,
+		TotalDistance: units.Round(entity.TotalDistance(blocks), 2),
+		Text:          entity.FormatShorthand(blocks),
+	}
+}
@@ -0,0 +1,91 @@
+package entity
+
+import "time"
+
+const (
+	SectionWarmup   = "warmup"
+	SectionMain     = "main"
+	SectionCooldown = "cooldown"
+)
+
+const (
+	EquipmentFins      = "fins"
+	EquipmentPullBuoy  = "pull_buoy"
+	EquipmentPaddles   = "paddles"
+	EquipmentSnorkel   = "snorkel"
+	EquipmentKickboard = "kickboard"
+)
+
+// MaxGroupDepth is how deep repeat groups may nest, ex: 3x { 2x { 4x50 } }.
+const MaxGroupDepth = 2
+
+type (
+	// Plan is a reusable workout. Distances are in DistanceUnit, shorthand like
+	// "10x100" means yards in a yards plan.
+	Plan struct {
+		ID            string
+		AccountID     string
+		Name          string
+		Description   *string
+		DistanceUnit  string
+		Blocks        []Block // stored as JSONB
+		TotalDistance float64
+		CreatedAt     time.Time
+		UpdatedAt     time.Time
+	}
+
+	// Block is a section of a plan (warm-up, main set, cool-down).
+	Block struct {
+		Section string `json:"section"`
+		Steps   []Step `json:"steps"`
+	}
+
+	// Step is an interval, or a group of steps swum Repeat times when Steps is set.
+	Step struct {
+		Repeat      int      `json:"repeat,omitempty"`
+		Steps       []Step   `json:"steps,omitempty"`
+		Reps        int      `json:"reps,omitempty"`
+		Distance    float64  `json:"distance,omitempty"` // per rep
+		Stroke      string   `json:"stroke,omitempty"`
+		Description string   `json:"description,omitempty"` // ex: drill name, "easy"
+		SendOffSec  int      `json:"sendOffSec,omitempty"`  // @1:40, leave every 100s
+		Pace        string   `json:"pace,omitempty"`        // @CSS+5 or @endurance, see ResolvePaces
+		RestSec     int      `json:"restSec,omitempty"`     // fixed rest after each rep
+		Equipment   []string `json:"equipment,omitempty"`
+		Note        string   `json:"note,omitempty"`
+	}
+)
+
+var (
+	sections  = []string{SectionWarmup, SectionMain, SectionCooldown}
+	equipment = []string{EquipmentFins, EquipmentPullBuoy, EquipmentPaddles, EquipmentSnorkel, EquipmentKickboard}
+)
+
+func Sections() []string { return sections }
+
+func Equipment() []string { return equipment }
+
+func (s *Step) IsGroup() bool { return len(s.Steps) > 0 }
+
+// TotalDistance is the distance swum in the step, groups included.
+func (s *Step) TotalDistance() float64 {
+	if !s.IsGroup() {
+		return float64(s.Reps) * s.Distance
+	}
+
+	total := 0.0
+	for i := range s.Steps {
+		total += s.Steps[i].TotalDistance()
+	}
+	return float64(s.Repeat) * total
+}
+
+func TotalDistance(blocks []Block) float64 {
+	total := 0.0
+	for _, block := range blocks {
+		for i := range block.Steps {
+			total += block.Steps[i].TotalDistance()
+		}
+	}
+	return total
+}
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"fmt"
+	"haphap/swimo-api/pkg/swim"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sendOffStep rounds resolved send-offs up, pace clocks show multiples of 5 seconds.
+const sendOffStep = 5
+
+var (
+	pacePattern = regexp.MustCompile(`^(?i)css(?:([+-])(\d{1,2})s?)?$`)
+	// cssSpacePattern joins "CSS + 5s" into one field
+	cssSpacePattern = regexp.MustCompile(`(?i)\bcss\s*([+-])\s*(\d)`)
+)
+
+// ParsePace reads a pace relative to the swimmer's CSS, ex: CSS, CSS+5s, css-3 or a
+// zone name like endurance, and returns it in canonical form.
+func ParsePace(s string) (string, bool) {
+	offset, ok := paceOffset(s)
+	if !ok {
+		return "", false
+	}
+	if _, isZone := swim.ZoneByName(strings.ToLower(s)); isZone {
+		return strings.ToLower(s), true
+	}
+
+	switch {
+	case offset == 0:
+		return "CSS", true
+	case offset > 0:
+		return fmt.Sprintf("CSS+%d", int(offset)), true
+	default:
+		return fmt.Sprintf("CSS%d", int(offset)), true
+	}
+}
+
+// paceOffset returns how many seconds per 100 the pace is slower than CSS.
+func paceOffset(s string) (float64, bool) {
+	if zone, ok := swim.ZoneByName(strings.ToLower(s)); ok {
+		return zone.Target(), true
+	}
+
+	m := pacePattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	if m[1] == "" {
+		return 0, true
+	}
+
+	n, _ := strconv.Atoi(m[2])
+	if m[1] == "-" {
+		n = -n
+	}
+	return float64(n), true
+}
+
+// HasPaces reports whether any step is swum at a pace relative to CSS.
+func HasPaces(blocks []Block) bool {
+	var walk func(steps []Step) bool
+	walk = func(steps []Step) bool {
+		for i := range steps {
+			if steps[i].Pace != "" || walk(steps[i].Steps) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, block := range blocks {
+		if walk(block.Steps) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolvePaces returns a copy of blocks where steps swum at a pace get a concrete
+// send-off: the swim time at that pace plus the rest after it, rounded up to 5
+// seconds. cssPace is in seconds per 100 of the plan's distance unit.
+func ResolvePaces(blocks []Block, cssPace float64) []Block {
+	var resolve func(steps []Step) []Step
+	resolve = func(steps []Step) []Step {
+		out := make([]Step, len(steps))
+		for i, step := range steps {
+			if step.IsGroup() {
+				step.Steps = resolve(step.Steps)
+			} else if offset, ok := paceOffset(step.Pace); ok && step.Pace != "" {
+				swimSec := (cssPace + offset) * step.Distance / 100
+				step.SendOffSec = int(math.Ceil((swimSec+float64(step.RestSec))/sendOffStep)) * sendOffStep
+				step.RestSec = 0 // part of the send-off now
+			}
+			out[i] = step
+		}
+		return out
+	}
+
+	out := make([]Block, len(blocks))
+	for i, block := range blocks {
+		out[i] = Block{Section: block.Section, Steps: resolve(block.Steps)}
+	}
+	return out
+}
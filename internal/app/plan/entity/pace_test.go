@@ -0,0 +1,53 @@
+package entity
+
+import "testing"
+
+func TestParsePace(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{"CSS", "CSS", true},
+		{"css+5s", "CSS+5", true},
+		{"CSS-3", "CSS-3", true},
+		{"css+0", "CSS", true},
+		{"Endurance", "endurance", true},
+		{"CSS+100", "", false},
+		{"fast", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := ParsePace(tt.in)
+			if got != tt.want || ok != tt.wantOK {
+				t.Fatalf("ParsePace(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestResolvePaces(t *testing.T) {
+	blocks := []Block{{Section: SectionMain, Steps: []Step{
+		{Reps: 8, Distance: 100, Pace: "CSS+5", RestSec: 10},
+		{Repeat: 2, Steps: []Step{{Reps: 4, Distance: 50, Pace: "threshold"}}},
+		{Reps: 1, Distance: 200, RestSec: 30},
+	}}}
+
+	got := ResolvePaces(blocks, 90)[0].Steps
+
+	// (90+5)s per 100 plus 10s rest, rounded up to 5s
+	if got[0].SendOffSec != 105 || got[0].RestSec != 0 {
+		t.Fatalf("CSS+5 step = %+v, want a 105s send-off and no rest", got[0])
+	}
+	// threshold targets CSS+1: 45.5s per 50
+	if inner := got[1].Steps[0]; inner.SendOffSec != 50 {
+		t.Fatalf("threshold step = %+v, want a 50s send-off", inner)
+	}
+	if got[2].SendOffSec != 0 || got[2].RestSec != 30 {
+		t.Fatalf("step without a pace changed: %+v", got[2])
+	}
+	if blocks[0].Steps[0].SendOffSec != 0 {
+		t.Fatal("ResolvePaces modified its input")
+	}
+}
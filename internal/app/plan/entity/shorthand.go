@@ -0,0 +1,391 @@
+package entity
+
+import (
+	"fmt"
+	"haphap/swimo-api/pkg/swim"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Coach shorthand, one step per line:
+//
+//	Warm-up
+//	  400 free
+//	  4x50 kick @1:00 w/ fins
+//	Main set
+//	  3x {
+//	    10x100 free @1:40
+//	    8x100 @CSS+5s r:15
+//	    200 pull r:30 w/ pull buoy, paddles # negative split
+//	  }
+//	Cool-down
+//	  200 choice
+//
+// Steps before the first section header belong to the main set.
+
+// ShorthandError points at the offending line, 1-based.
+type ShorthandError struct {
+	Line    int
+	Message string
+}
+
+func (e *ShorthandError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+var (
+	sectionAliases = map[string]string{
+		"warm-up": SectionWarmup, "warmup": SectionWarmup, "warm up": SectionWarmup, "wu": SectionWarmup,
+		"main": SectionMain, "main set": SectionMain, "mainset": SectionMain, "ms": SectionMain,
+		"cool-down": SectionCooldown, "cooldown": SectionCooldown, "cool down": SectionCooldown, "cd": SectionCooldown,
+	}
+	sectionTitles = map[string]string{
+		SectionWarmup:   "Warm-up",
+		SectionMain:     "Main set",
+		SectionCooldown: "Cool-down",
+	}
+
+	strokeAliases = map[string]string{
+		"free": swim.StrokeFreestyle, "freestyle": swim.StrokeFreestyle, "fr": swim.StrokeFreestyle,
+		"back": swim.StrokeBackstroke, "backstroke": swim.StrokeBackstroke, "bk": swim.StrokeBackstroke,
+		"breast": swim.StrokeBreaststroke, "breaststroke": swim.StrokeBreaststroke, "br": swim.StrokeBreaststroke,
+		"fly": swim.StrokeButterfly, "butterfly": swim.StrokeButterfly,
+		"im":     swim.StrokeIM,
+		"kick":   swim.StrokeKick,
+		"pull":   swim.StrokePull,
+		"drill":  swim.StrokeDrill,
+		"choice": swim.StrokeChoice, "ch": swim.StrokeChoice,
+	}
+	strokeShort = map[string]string{
+		swim.StrokeFreestyle:    "free",
+		swim.StrokeBackstroke:   "back",
+		swim.StrokeBreaststroke: "breast",
+		swim.StrokeButterfly:    "fly",
+		swim.StrokeIM:           "IM",
+		swim.StrokeKick:         "kick",
+		swim.StrokePull:         "pull",
+		swim.StrokeDrill:        "drill",
+		swim.StrokeChoice:       "choice",
+	}
+
+	equipmentAliases = map[string]string{
+		"fins": EquipmentFins, "fin": EquipmentFins,
+		"pull buoy": EquipmentPullBuoy, "pullbuoy": EquipmentPullBuoy, "buoy": EquipmentPullBuoy, "pb": EquipmentPullBuoy,
+		"paddles": EquipmentPaddles, "paddle": EquipmentPaddles,
+		"snorkel":   EquipmentSnorkel,
+		"kickboard": EquipmentKickboard, "board": EquipmentKickboard,
+	}
+	equipmentNames = map[string]string{
+		EquipmentFins:      "fins",
+		EquipmentPullBuoy:  "pull buoy",
+		EquipmentPaddles:   "paddles",
+		EquipmentSnorkel:   "snorkel",
+		EquipmentKickboard: "kickboard",
+	}
+
+	groupOpenPattern = regexp.MustCompile(`^(\d+)\s*[x×]\s*[{(]$`)
+	repsPattern      = regexp.MustCompile(`(\d+)\s*[x×]\s*(\d)`)
+	distancePattern  = regexp.MustCompile(`^(?i)(?:(\d+)x)?(\d+(?:\.\d+)?)(?:m|y|yd|yds)?$`)
+	restPattern      = regexp.MustCompile(`^(?i)r:?(\d+(?::\d{2})?)s?$`)
+	clockPattern     = regexp.MustCompile(`^(\d+)(?::(\d{2}))?s?$`)
+)
+
+// ParseShorthand turns coach shorthand into plan blocks.
+func ParseShorthand(text string) ([]Block, error) {
+	var (
+		blocks []Block
+		// open groups, innermost last; the block's steps are the root
+		stack []*Step
+		root  *Step
+	)
+
+	current := func() *Step {
+		if len(stack) > 0 {
+			return stack[len(stack)-1]
+		}
+		return root
+	}
+	openBlock := func(section string) {
+		blocks = append(blocks, Block{Section: section})
+		root = &Step{}
+	}
+	closeBlock := func() {
+		if root != nil {
+			blocks[len(blocks)-1].Steps = root.Steps
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, raw := range lines {
+		lineNo := i + 1
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+
+		if section, ok := sectionAliases[strings.ToLower(strings.TrimSuffix(line, ":"))]; ok {
+			if len(stack) > 0 {
+				return nil, &ShorthandError{lineNo, "section header inside a repeat group, missing }"}
+			}
+			closeBlock()
+			openBlock(section)
+			continue
+		}
+
+		if root == nil {
+			openBlock(SectionMain)
+		}
+
+		if m := groupOpenPattern.FindStringSubmatch(line); m != nil {
+			if len(stack) >= MaxGroupDepth {
+				return nil, &ShorthandError{lineNo, fmt.Sprintf("repeat groups cannot be nested more than %d deep", MaxGroupDepth)}
+			}
+			repeat, _ := strconv.Atoi(m[1])
+			stack = append(stack, &Step{Repeat: repeat})
+			continue
+		}
+
+		if line == "}" || line == ")" {
+			if len(stack) == 0 {
+				return nil, &ShorthandError{lineNo, "unexpected }, no repeat group is open"}
+			}
+			group := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !group.IsGroup() {
+				return nil, &ShorthandError{lineNo, "repeat group is empty"}
+			}
+			parent := current()
+			parent.Steps = append(parent.Steps, *group)
+			continue
+		}
+
+		step, msg := parseInterval(line)
+		if msg != "" {
+			return nil, &ShorthandError{lineNo, msg}
+		}
+		parent := current()
+		parent.Steps = append(parent.Steps, step)
+	}
+
+	if len(stack) > 0 {
+		return nil, &ShorthandError{len(lines), "repeat group is not closed, missing }"}
+	}
+	closeBlock()
+
+	// drop headers without steps, ex: a trailing "Cool-down"
+	out := make([]Block, 0, len(blocks))
+	for _, block := range blocks {
+		if len(block.Steps) > 0 {
+			out = append(out, block)
+		}
+	}
+	if len(out) == 0 {
+		return nil, &ShorthandError{1, "plan has no steps"}
+	}
+
+	return out, nil
+}
+
+// parseInterval parses one step line, returning an error message on failure.
+func parseInterval(line string) (Step, string) {
+	step := Step{Reps: 1}
+
+	if idx := strings.Index(line, "#"); idx >= 0 {
+		step.Note = strings.TrimSpace(line[idx+1:])
+		line = line[:idx]
+	}
+
+	if idx := strings.Index(strings.ToLower(line), "w/"); idx >= 0 {
+		for _, name := range strings.FieldsFunc(line[idx+2:], func(r rune) bool { return r == ',' || r == '+' || r == '&' }) {
+			name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+			item, ok := equipmentAliases[name]
+			if !ok {
+				return step, fmt.Sprintf("unknown equipment %q", name)
+			}
+			step.Equipment = append(step.Equipment, item)
+		}
+		line = line[:idx]
+	}
+
+	line = cssSpacePattern.ReplaceAllString(line, "CSS$1$2")
+	fields := strings.Fields(repsPattern.ReplaceAllString(line, "${1}x$2"))
+	words := make([]string, 0, len(fields))
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		switch {
+		case strings.HasPrefix(field, "@"):
+			value := strings.TrimPrefix(field, "@")
+			if value == "" && i+1 < len(fields) {
+				i++
+				value = fields[i]
+			}
+			if pace, ok := ParsePace(value); ok {
+				step.Pace, step.SendOffSec = pace, 0
+				break
+			}
+			sec, ok := parseClock(value)
+			if !ok {
+				return step, fmt.Sprintf("invalid send-off %q, use @1:40 or @CSS+5s", field)
+			}
+			step.SendOffSec, step.Pace = sec, ""
+
+		case restPattern.MatchString(field):
+			sec, _ := parseClock(restPattern.FindStringSubmatch(field)[1])
+			step.RestSec = sec
+
+		case strings.EqualFold(field, "rest") && i+1 < len(fields):
+			i++
+			sec, ok := parseClock(fields[i])
+			if !ok {
+				return step, fmt.Sprintf("invalid rest %q, use r:20", fields[i])
+			}
+			step.RestSec = sec
+
+		default:
+			words = append(words, field)
+		}
+	}
+
+	if len(words) == 0 {
+		return step, "missing distance"
+	}
+
+	m := distancePattern.FindStringSubmatch(words[0])
+	if m == nil {
+		return step, fmt.Sprintf("expected a distance like 200 or 10x100, got %q", words[0])
+	}
+	if m[1] != "" {
+		step.Reps, _ = strconv.Atoi(m[1])
+	}
+	step.Distance, _ = strconv.ParseFloat(m[2], 64)
+	words = words[1:]
+
+	if len(words) > 0 {
+		if stroke, ok := strokeAliases[strings.ToLower(words[0])]; ok {
+			step.Stroke = stroke
+			words = words[1:]
+		}
+	}
+	step.Description = strings.Join(words, " ")
+
+	return step, ""
+}
+
+// parseClock reads "1:40" or "90" as seconds.
+func parseClock(s string) (int, bool) {
+	m := clockPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+
+	n, _ := strconv.Atoi(m[1])
+	if m[2] == "" {
+		return n, true
+	}
+	sec, _ := strconv.Atoi(m[2])
+	if sec >= 60 {
+		return 0, false
+	}
+	return n*60 + sec, true
+}
+
+// FormatShorthand prints blocks as coach shorthand, ParseShorthand reads it back.
+func FormatShorthand(blocks []Block) string {
+	var sb strings.Builder
+	for i, block := range blocks {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		title, ok := sectionTitles[block.Section]
+		if !ok {
+			title = block.Section
+		}
+		sb.WriteString(title)
+		sb.WriteString("\n")
+
+		for j := range block.Steps {
+			formatStep(&sb, &block.Steps[j], 1)
+		}
+	}
+
+	return sb.String()
+}
+
+func formatStep(sb *strings.Builder, step *Step, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	if step.IsGroup() {
+		fmt.Fprintf(sb, "%s%dx {\n", indent, step.Repeat)
+		for i := range step.Steps {
+			formatStep(sb, &step.Steps[i], depth+1)
+		}
+		sb.WriteString(indent + "}\n")
+		return
+	}
+
+	distance := strconv.FormatFloat(step.Distance, 'f', -1, 64)
+	parts := []string{distance}
+	if step.Reps > 1 {
+		parts[0] = fmt.Sprintf("%dx%s", step.Reps, distance)
+	}
+	if step.Stroke != "" {
+		parts = append(parts, strokeShort[step.Stroke])
+	}
+	if step.Description != "" {
+		parts = append(parts, step.Description)
+	}
+	if step.SendOffSec > 0 {
+		parts = append(parts, "@"+FormatClock(step.SendOffSec))
+	} else if step.Pace != "" {
+		parts = append(parts, "@"+step.Pace)
+	}
+	if step.RestSec > 0 {
+		parts = append(parts, "r:"+formatRest(step.RestSec))
+	}
+	if len(step.Equipment) > 0 {
+		names := make([]string, 0, len(step.Equipment))
+		for _, item := range step.Equipment {
+			names = append(names, equipmentNames[item])
+		}
+		parts = append(parts, "w/ "+strings.Join(names, ", "))
+	}
+	if step.Note != "" {
+		parts = append(parts, "# "+step.Note)
+	}
+
+	sb.WriteString(indent + strings.Join(parts, " ") + "\n")
+}
+
+// ShorthandRoundTrips reports whether the step's stroke, description and note read back unchanged
+// from FormatShorthand: a description with #, w/, @ or a rest like r:20 would be parsed as more
+// fields, as would one starting with a stroke name on a step without stroke.
+func ShorthandRoundTrips(step *Step) bool {
+	if strings.ContainsAny(step.Description+step.Note, "\r\n") {
+		return false
+	}
+
+	text := Step{Reps: 1, Distance: 1, Stroke: step.Stroke, Description: step.Description, Note: step.Note}
+	var sb strings.Builder
+	formatStep(&sb, &text, 0)
+
+	got, msg := parseInterval(strings.TrimSpace(sb.String()))
+	return msg == "" &&
+		got.Reps == 1 && got.Distance == 1 &&
+		got.Stroke == text.Stroke && got.Description == text.Description && got.Note == text.Note &&
+		got.SendOffSec == 0 && got.Pace == "" && got.RestSec == 0 && len(got.Equipment) == 0
+}
+
+// FormatClock prints seconds as m:ss.
+func FormatClock(sec int) string {
+	return fmt.Sprintf("%d:%02d", sec/60, sec%60)
+}
+
+// formatRest keeps short rests in seconds, r:20 reads better than r:0:20.
+func formatRest(sec int) string {
+	if sec < 60 {
+		return strconv.Itoa(sec)
+	}
+	return FormatClock(sec)
+}
@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"errors"
+	"haphap/swimo-api/pkg/swim"
+	"reflect"
+	"testing"
+)
+
+func TestParseShorthandErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		wantLine int
+	}{
+		{"empty", "", 1},
+		{"headers only", "Warm-up\nCool-down", 1},
+		{"missing distance", "Warm-up\n  @1:40", 2},
+		{"invalid distance", "Main set\n  400 free\n  lots free", 3},
+		{"unknown equipment", "200 kick w/ fins, parachute", 1},
+		{"invalid send-off", "Main set\n  4x100 @fast", 2},
+		{"invalid rest", "4x100 free rest soon", 1},
+		{"unexpected close", "400 free\n}", 2},
+		{"empty group", "Main set\n  3x {\n  }", 3},
+		{"unclosed group", "3x {\n  100 free\n\n", 4},
+		{"section inside a group", "3x {\n  100 free\nCool-down\n  200 choice\n}", 3},
+		{"groups too deep", "2x {\n  2x {\n    2x {\n      50 free\n    }\n  }\n}", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseShorthand(tt.text)
+
+			var shorthandErr *ShorthandError
+			if !errors.As(err, &shorthandErr) {
+				t.Fatalf("ParseShorthand error = %v, want a ShorthandError", err)
+			}
+			if shorthandErr.Line != tt.wantLine {
+				t.Fatalf("ParseShorthand error on line %d, want line %d: %v", shorthandErr.Line, tt.wantLine, err)
+			}
+		})
+	}
+}
+
+func TestParseShorthand(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Block
+	}{
+		{
+			name: "steps without header belong to the main set",
+			text: "400 free\n4 x 50 kick @1:00 w/ fins",
+			want: []Block{{Section: SectionMain, Steps: []Step{
+				{Reps: 1, Distance: 400, Stroke: swim.StrokeFreestyle},
+				{Reps: 4, Distance: 50, Stroke: swim.StrokeKick, SendOffSec: 60, Equipment: []string{EquipmentFins}},
+			}}},
+		},
+		{
+			name: "nested groups",
+			text: "Main set\n  3x {\n    2x (\n      100 free r:15\n    )\n    200 pull r:30 w/ pull buoy, paddles # negative split\n  }",
+			want: []Block{{Section: SectionMain, Steps: []Step{
+				{Repeat: 3, Steps: []Step{
+					{Repeat: 2, Steps: []Step{{Reps: 1, Distance: 100, Stroke: swim.StrokeFreestyle, RestSec: 15}}},
+					{Reps: 1, Distance: 200, Stroke: swim.StrokePull, RestSec: 30, Equipment: []string{EquipmentPullBuoy, EquipmentPaddles}, Note: "negative split"},
+				}},
+			}}},
+		},
+		{
+			name: "CSS paces",
+			text: "WU\n  200 choice easy\nMS\n  8x100 @CSS + 5s r:15\n  4x50 fly @ css-3\n  400 @endurance",
+			want: []Block{
+				{Section: SectionWarmup, Steps: []Step{{Reps: 1, Distance: 200, Stroke: swim.StrokeChoice, Description: "easy"}}},
+				{Section: SectionMain, Steps: []Step{
+					{Reps: 8, Distance: 100, Pace: "CSS+5", RestSec: 15},
+					{Reps: 4, Distance: 50, Stroke: swim.StrokeButterfly, Pace: "CSS-3"},
+					{Reps: 1, Distance: 400, Pace: "endurance"},
+				}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseShorthand(tt.text)
+			if err != nil {
+				t.Fatalf("ParseShorthand: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseShorthand() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatShorthandRoundTrip(t *testing.T) {
+	blocks := []Block{
+		{Section: SectionWarmup, Steps: []Step{
+			{Reps: 1, Distance: 400, Stroke: swim.StrokeFreestyle},
+			{Reps: 4, Distance: 50, Stroke: swim.StrokeKick, SendOffSec: 60, Equipment: []string{EquipmentFins}},
+		}},
+		{Section: SectionMain, Steps: []Step{
+			{Repeat: 3, Steps: []Step{
+				{Reps: 10, Distance: 100, Stroke: swim.StrokeFreestyle, SendOffSec: 100},
+				{Repeat: 2, Steps: []Step{{Reps: 8, Distance: 25, Stroke: swim.StrokeDrill, Description: "catch-up", RestSec: 90}}},
+				{Reps: 8, Distance: 100, Pace: "CSS+5", RestSec: 15},
+				{Reps: 1, Distance: 200, Stroke: swim.StrokePull, RestSec: 30, Equipment: []string{EquipmentPullBuoy, EquipmentPaddles}, Note: "negative split # hold form"},
+			}},
+		}},
+		{Section: SectionCooldown, Steps: []Step{
+			{Reps: 1, Distance: 200.5, Stroke: swim.StrokeChoice, Description: "easy", Pace: "recovery"},
+		}},
+	}
+
+	text := FormatShorthand(blocks)
+	got, err := ParseShorthand(text)
+	if err != nil {
+		t.Fatalf("ParseShorthand(FormatShorthand()): %v\n%s", err, text)
+	}
+	if !reflect.DeepEqual(got, blocks) {
+		t.Fatalf("round trip = %+v, want %+v\n%s", got, blocks, text)
+	}
+}
+
+func TestShorthandRoundTrips(t *testing.T) {
+	tests := []struct {
+		name string
+		step Step
+		want bool
+	}{
+		{"plain description", Step{Stroke: swim.StrokeDrill, Description: "catch-up 6-3-6"}, true},
+		{"note with a hash", Step{Description: "easy", Note: "#1 focus"}, true},
+		{"stroke name after the stroke", Step{Stroke: swim.StrokeKick, Description: "free"}, true},
+		{"hash", Step{Description: "drill #2"}, false},
+		{"equipment", Step{Description: "easy w/ snorkel"}, false},
+		{"send-off", Step{Description: "fast @ the end"}, false},
+		{"rest", Step{Description: "build r:20"}, false},
+		{"rest word", Step{Description: "rest 30"}, false},
+		{"stroke name without stroke", Step{Description: "back easy"}, false},
+		{"line break", Step{Description: "easy", Note: "first\nsecond"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShorthandRoundTrips(&tt.step); got != tt.want {
+				t.Fatalf("ShorthandRoundTrips(%+v) = %v, want %v", tt.step, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/plan/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrPlanNotFound = errors.New("plan not found")
+	ErrPlanInUse    = errors.New("plan is used by a program")
+)
+
+type PlanRepository interface {
+	GetDistanceUnit(ctx context.Context, accountID string) (string, error)
+	GetThresholdPace(ctx context.Context, accountID string) (*float64, error)
+	CreatePlan(ctx context.Context, plan *entity.Plan) error
+	UpdatePlan(ctx context.Context, plan *entity.Plan) error
+	GetPlan(ctx context.Context, accountID, planID string) (*entity.Plan, error)
+	ListPlans(ctx context.Context, accountID string, limit, offset int) ([]entity.Plan, int, error)
+	DeletePlan(ctx context.Context, accountID, planID string) error
+}
+
+type planRepository struct{ db *pgxpool.Pool }
+
+func NewPlanRepository(db *pgxpool.Pool) PlanRepository { return &planRepository{db: db} }
+
+// GetDistanceUnit returns the user's distance unit, the default for new plans.
+func (r *planRepository) GetDistanceUnit(ctx context.Context, accountID string) (string, error) {
+	const sql = `SELECT distance_unit FROM users WHERE account_id = $1`
+
+	var unit string
+	if err := r.db.QueryRow(ctx, sql, accountID).Scan(&unit); err != nil {
+		return "", err
+	}
+
+	return unit, nil
+}
+
+// GetThresholdPace returns the user's CSS in seconds per 100m, nil until it is known.
+func (r *planRepository) GetThresholdPace(ctx context.Context, accountID string) (*float64, error) {
+	const sql = `SELECT threshold_pace_sec FROM users WHERE account_id = $1`
+
+	var pace *float64
+	if err := r.db.QueryRow(ctx, sql, accountID).Scan(&pace); err != nil {
+		return nil, err
+	}
+
+	return pace, nil
+}
+
+func (r *planRepository) CreatePlan(ctx context.Context, plan *entity.Plan) error {
+	const sql = `
+		INSERT INTO workout_plans (account_id, name, description, distance_unit, blocks, total_distance)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`
+
+	return r.db.QueryRow(ctx, sql,
+		plan.AccountID,
+		plan.Name,
+		plan.Description,
+		plan.DistanceUnit,
+		plan.Blocks,
+		plan.TotalDistance,
+	).Scan(&plan.ID, &plan.CreatedAt, &plan.UpdatedAt)
+}
+
+func (r *planRepository) UpdatePlan(ctx context.Context, plan *entity.Plan) error {
+	const sql = `
+		UPDATE workout_plans
+		SET name = $3, description = $4, distance_unit = $5, blocks = $6, total_distance = $7, updated_at = now()
+		WHERE id = $1 AND account_id = $2
+		RETURNING created_at, updated_at`
+
+	if err := r.db.QueryRow(ctx, sql,
+		plan.ID,
+		plan.AccountID,
+		plan.Name,
+		plan.Description,
+		plan.DistanceUnit,
+		plan.Blocks,
+		plan.TotalDistance,
+	).Scan(&plan.CreatedAt, &plan.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrPlanNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *planRepository) GetPlan(ctx context.Context, accountID, planID string) (*entity.Plan, error) {
+	const sql = `
+		SELECT id, account_id, name, description, distance_unit, blocks, total_distance, created_at, updated_at
+		FROM workout_plans
+		WHERE id = $1 AND account_id = $2`
+
+	var plan entity.Plan
+	if err := r.db.QueryRow(ctx, sql, planID, accountID).Scan(
+		&plan.ID,
+		&plan.AccountID,
+		&plan.Name,
+		&plan.Description,
+		&plan.DistanceUnit,
+		&plan.Blocks,
+		&plan.TotalDistance,
+		&plan.CreatedAt,
+		&plan.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPlanNotFound
+		}
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+// ListPlans returns a page of plans without their blocks, recently edited first.
+func (r *planRepository) ListPlans(ctx context.Context, accountID string, limit, offset int) ([]entity.Plan, int, error) {
+	const sql = `
+		SELECT
+			id, account_id, name, description, distance_unit, total_distance, created_at, updated_at,
+			COUNT(*) OVER ()
+		FROM workout_plans
+		WHERE account_id = $1
+		ORDER BY updated_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(ctx, sql, accountID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	total := 0
+	plans := make([]entity.Plan, 0)
+	for rows.Next() {
+		var plan entity.Plan
+		if err := rows.Scan(
+			&plan.ID,
+			&plan.AccountID,
+			&plan.Name,
+			&plan.Description,
+			&plan.DistanceUnit,
+			&plan.TotalDistance,
+			&plan.CreatedAt,
+			&plan.UpdatedAt,
+			&total,
+		); err != nil {
+			return nil, 0, err
+		}
+		plans = append(plans, plan)
+	}
+
+	return plans, total, rows.Err()
+}
+
+// DeletePlan refuses plans scheduled by a program. Purging the author's account
+// detaches them instead, see program.BeforeAccountPurge.
+func (r *planRepository) DeletePlan(ctx context.Context, accountID, planID string) error {
+	const sql = `
+		WITH deleted AS (
+			DELETE FROM workout_plans AS p
+			WHERE p.id = $1 AND p.account_id = $2
+			  AND NOT EXISTS (SELECT 1 FROM program_workouts AS w WHERE w.plan_id = p.id)
+			RETURNING p.id
+		)
+		SELECT
+			EXISTS (SELECT 1 FROM deleted),
+			EXISTS (SELECT 1 FROM workout_plans WHERE id = $1 AND account_id = $2)`
+
+	var deleted, exists bool
+	if err := r.db.QueryRow(ctx, sql, planID, accountID).Scan(&deleted, &exists); err != nil {
+		return err
+	}
+
+	switch {
+	case deleted:
+		return nil
+	case exists:
+		return ErrPlanInUse
+	default:
+		return ErrPlanNotFound
+	}
+}
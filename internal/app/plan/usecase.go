@@ -0,0 +1,161 @@
+package plan
+
+import (
+	"context"
+	"haphap/swimo-api/internal/app/plan/dto"
+	"haphap/swimo-api/internal/app/plan/entity"
+	"haphap/swimo-api/pkg/response"
+	"log/slog"
+)
+
+const copySuffix = " (copy)"
+
+type PlanUseCase interface {
+	CreatePlan(ctx context.Context, accountID string, req dto.PlanRequest) (*dto.PlanResponse, error)
+	GetPlan(ctx context.Context, accountID, planID string) (*dto.PlanResponse, error)
+	ListPlans(ctx context.Context, accountID string, query dto.ListPlansQuery) ([]dto.PlanSummaryResponse, int, error)
+	UpdatePlan(ctx context.Context, accountID, planID string, req dto.PlanRequest) (*dto.PlanResponse, error)
+	DuplicatePlan(ctx context.Context, accountID, planID string) (*dto.PlanResponse, error)
+	DeletePlan(ctx context.Context, accountID, planID string) error
+	ExportAccountData(ctx context.Context, accountID string) (map[string]any, error)
+}
+
+type planUseCase struct {
+	planRepo PlanRepository
+}
+
+func NewPlanUseCase(planRepo PlanRepository) PlanUseCase {
+	return &planUseCase{planRepo}
+}
+
+func (uc *planUseCase) CreatePlan(ctx context.Context, accountID string, req dto.PlanRequest) (*dto.PlanResponse, error) {
+	plan := &entity.Plan{AccountID: accountID}
+	if req.DistanceUnit == nil {
+		unit, err := uc.planRepo.GetDistanceUnit(ctx, accountID)
+		if err != nil {
+			return nil, err
+		}
+		plan.DistanceUnit = unit
+	}
+	req.Apply(plan)
+
+	if err := uc.planRepo.CreatePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	slog.Info("plan created", slog.String("account_id", accountID), slog.String("plan_id", plan.ID))
+
+	return uc.toResponse(ctx, accountID, plan)
+}
+
+func (uc *planUseCase) GetPlan(ctx context.Context, accountID, planID string) (*dto.PlanResponse, error) {
+	plan, err := uc.planRepo.GetPlan(ctx, accountID, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.toResponse(ctx, accountID, plan)
+}
+
+func (uc *planUseCase) ListPlans(ctx context.Context, accountID string, query dto.ListPlansQuery) ([]dto.PlanSummaryResponse, int, error) {
+	limit, offset := response.NormalizePage(query.Limit, query.Offset)
+
+	plans, total, err := uc.planRepo.ListPlans(ctx, accountID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]dto.PlanSummaryResponse, 0, len(plans))
+	for i := range plans {
+		out = append(out, dto.ToPlanSummaryResponse(&plans[i]))
+	}
+
+	return out, total, nil
+}
+
+func (uc *planUseCase) UpdatePlan(ctx context.Context, accountID, planID string, req dto.PlanRequest) (*dto.PlanResponse, error) {
+	plan, err := uc.planRepo.GetPlan(ctx, accountID, planID)
+	if err != nil {
+		return nil, err
+	}
+	req.Apply(plan)
+
+	if err := uc.planRepo.UpdatePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	slog.Info("plan updated", slog.String("account_id", accountID), slog.String("plan_id", planID))
+
+	return uc.toResponse(ctx, accountID, plan)
+}
+
+// DuplicatePlan copies a plan under a new name so it can be edited as a template.
+func (uc *planUseCase) DuplicatePlan(ctx context.Context, accountID, planID string) (*dto.PlanResponse, error) {
+	plan, err := uc.planRepo.GetPlan(ctx, accountID, planID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Name += copySuffix
+	if err := uc.planRepo.CreatePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	slog.Info("plan duplicated", slog.String("account_id", accountID), slog.String("source_id", planID), slog.String("plan_id", plan.ID))
+
+	return uc.toResponse(ctx, accountID, plan)
+}
+
+func (uc *planUseCase) DeletePlan(ctx context.Context, accountID, planID string) error {
+	if err := uc.planRepo.DeletePlan(ctx, accountID, planID); err != nil {
+		return err
+	}
+
+	slog.Info("plan deleted", slog.String("account_id", accountID), slog.String("plan_id", planID))
+	return nil
+}
+
+// toResponse resolves paces relative to CSS for the user reading the plan.
+func (uc *planUseCase) toResponse(ctx context.Context, accountID string, plan *entity.Plan) (*dto.PlanResponse, error) {
+	out := dto.ToPlanResponse(plan)
+	if !entity.HasPaces(plan.Blocks) {
+		return &out, nil
+	}
+
+	pace, err := uc.planRepo.GetThresholdPace(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if pace != nil {
+		out.Resolve(*pace)
+	}
+
+	return &out, nil
+}
+
+// ExportAccountData contributes every plan, with its blocks, to the account export.
+func (uc *planUseCase) ExportAccountData(ctx context.Context, accountID string) (map[string]any, error) {
+	out := make([]dto.PlanResponse, 0)
+	offset := 0
+	for {
+		plans, total, err := uc.planRepo.ListPlans(ctx, accountID, response.MaxPageLimit, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range plans {
+			plan, err := uc.planRepo.GetPlan(ctx, accountID, plans[i].ID)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, dto.ToPlanResponse(plan))
+		}
+
+		offset += len(plans)
+		if len(plans) == 0 || offset >= total {
+			break
+		}
+	}
+
+	return map[string]any{"plans": out}, nil
+}
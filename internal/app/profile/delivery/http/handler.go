@@ -0,0 +1,146 @@
+package http
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"haphap/swimo-api/internal/app/profile"
+	"haphap/swimo-api/internal/app/profile/dto"
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/validator"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProfileHandler struct {
+	profileUsecase profile.ProfileUseCase
+}
+
+func NewProfileHandler(profileUsecase profile.ProfileUseCase) *ProfileHandler {
+	return &ProfileHandler{profileUsecase}
+}
+
+func (h *ProfileHandler) GetProfile(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	out, err := h.profileUsecase.GetProfile(c.Context(), principal.AccountID)
+	if err != nil {
+		if errors.Is(err, profile.ErrProfileNotFound) {
+			return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Profile not found."})
+		}
+
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Profile retrieved successfully.",
+	})
+}
+
+func (h *ProfileHandler) UpdateProfile(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var req dto.UpdateProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.profileUsecase.UpdateProfile(c.Context(), principal.AccountID, req)
+	if err != nil {
+		var validationErr *validator.ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			return c.Status(http.StatusUnprocessableEntity).JSON(
+				response.ValidationError{Message: "Validation Error", Errors: validationErr},
+			)
+		case errors.Is(err, profile.ErrProfileNotFound):
+			return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Profile not found."})
+		default:
+			return err
+		}
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Profile updated successfully.",
+	})
+}
+
+// Export returns a ZIP with one JSON file per section, or the plain bundle with ?format=json.
+func (h *ProfileHandler) Export(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	format := c.Query("format", "zip")
+	if format != "zip" && format != "json" {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Format must be zip or json."})
+	}
+
+	bundle, err := h.profileUsecase.Export(c.Context(), principal.AccountID)
+	if err != nil {
+		if errors.Is(err, profile.ErrProfileNotFound) {
+			return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Profile not found."})
+		}
+
+		return err
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	if format == "json" {
+		return c.Status(http.StatusOK).JSON(response.Base{
+			Data:    bundle,
+			Message: "Account data exported successfully.",
+		})
+	}
+
+	archive, err := zipSections(bundle)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="swimo-export-%s.zip"`, time.Now().UTC().Format("20060102")))
+	return c.Status(http.StatusOK).Send(archive)
+}
+
+func zipSections(bundle map[string]any) ([]byte, error) {
+	names := make([]string, 0, len(bundle))
+	for name := range bundle {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range names {
+		w, err := zw.Create(name + ".json")
+		if err != nil {
+			return nil, err
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(bundle[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
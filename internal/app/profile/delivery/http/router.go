@@ -0,0 +1,14 @@
+package http
+
+import (
+	"haphap/swimo-api/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func Register(app *fiber.App, profileHandler *ProfileHandler, authMw *middleware.AuthMiddleware) {
+	me := app.Group("/api/v1/me", authMw.Require(middleware.UserOnly))
+	me.Get("", profileHandler.GetProfile)
+	me.Patch("", profileHandler.UpdateProfile)
+	me.Get("/export", profileHandler.Export)
+}
@@ -0,0 +1,193 @@
+package dto
+
+import (
+	"haphap/swimo-api/internal/app/profile/entity"
+	"haphap/swimo-api/pkg/dates"
+	"haphap/swimo-api/pkg/swim"
+	"haphap/swimo-api/pkg/units"
+	"haphap/swimo-api/pkg/validator"
+	"strings"
+	"time"
+)
+
+const (
+	maxWeightKG = 500
+	maxHeightCM = 300
+)
+
+type (
+	ProfileResponse struct {
+		Email         string    `json:"email"`
+		EmailVerified bool      `json:"emailVerified"`
+		Roles         []string  `json:"roles"`
+		Name          string    `json:"name"`
+		Weight        *float64  `json:"weight"`
+		WeightUnit    string    `json:"weightUnit"`
+		Height        *float64  `json:"height"`
+		HeightUnit    string    `json:"heightUnit"`
+		BirthDate     *string   `json:"birthDate"`
+		Age           *int      `json:"age"`
+		UnitSystem    string    `json:"unitSystem"`
+		DistanceUnit  string    `json:"distanceUnit"`
+		SkillLevel    *string   `json:"skillLevel"`
+		ThresholdPace *float64  `json:"thresholdPace"` // seconds per 100 of the distance unit
+		UpdatedAt     time.Time `json:"updatedAt"`
+	}
+
+	// UpdateProfileRequest takes weight and height in the unit system the profile
+	// has after the update, so units and values can be changed in one request.
+	UpdateProfileRequest struct {
+		Name         *string  `json:"name"`
+		Weight       *float64 `json:"weight"`
+		Height       *float64 `json:"height"`
+		BirthDate    *string  `json:"birthDate"` // YYYY-MM-DD
+		UnitSystem   *string  `json:"unitSystem"`
+		DistanceUnit *string  `json:"distanceUnit"`
+		SkillLevel   *string  `json:"skillLevel"`
+		// ThresholdPace is in seconds per 100 of the distance unit, ex: 95 for 1:35/100m
+		ThresholdPace *float64 `json:"thresholdPace"`
+	}
+)
+
+func ToProfileResponse(profile *entity.Profile) ProfileResponse {
+	pref := profile.Units()
+
+	out := ProfileResponse{
+		Email:         profile.Email,
+		EmailVerified: profile.EmailVerifiedAt != nil,
+		Roles:         profile.Roles,
+		Name:          profile.Name,
+		WeightUnit:    pref.WeightUnit(),
+		HeightUnit:    pref.HeightUnit(),
+		Age:           dates.AgePtr(profile.BirthDate),
+		UnitSystem:    profile.UnitSystem,
+		DistanceUnit:  profile.DistanceUnit,
+		SkillLevel:    profile.SkillLevel,
+		UpdatedAt:     profile.UpdatedAt,
+	}
+	if profile.WeightKG != nil {
+		weight := pref.WeightFromKG(*profile.WeightKG)
+		out.Weight = &weight
+	}
+	if profile.HeightCM != nil {
+		height := pref.HeightFromCM(*profile.HeightCM)
+		out.Height = &height
+	}
+	if profile.BirthDate != nil {
+		birthDate := profile.BirthDate.Format(dates.Layout)
+		out.BirthDate = &birthDate
+	}
+	if profile.ThresholdPace != nil {
+		pace := pref.PaceFromSecPer100m(*profile.ThresholdPace)
+		out.ThresholdPace = &pace
+	}
+
+	return out
+}
+
+func (r *UpdateProfileRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if r.Name == nil && r.Weight == nil && r.Height == nil && r.BirthDate == nil && r.UnitSystem == nil && r.DistanceUnit == nil &&
+		r.SkillLevel == nil && r.ThresholdPace == nil {
+		errors["body"] = "At least one field must be provided"
+	}
+
+	if r.Name != nil && strings.TrimSpace(*r.Name) == "" {
+		errors["name"] = "Name cannot be empty"
+	}
+
+	if r.Weight != nil && *r.Weight < 0 {
+		errors["weight"] = "Weight cannot be negative"
+	}
+
+	if r.Height != nil && *r.Height < 0 {
+		errors["height"] = "Height cannot be negative"
+	}
+
+	if r.ThresholdPace != nil && *r.ThresholdPace <= 0 {
+		errors["thresholdPace"] = "Threshold pace must be positive"
+	}
+
+	if r.BirthDate != nil {
+		if msg := validator.BirthDate(*r.BirthDate); msg != "" {
+			errors["birthDate"] = msg
+		}
+	}
+
+	if r.UnitSystem != nil && !units.IsValidSystem(*r.UnitSystem) {
+		errors["unitSystem"] = "Unit system must be metric or imperial"
+	}
+
+	if r.DistanceUnit != nil && !units.IsValidDistance(*r.DistanceUnit) {
+		errors["distanceUnit"] = "Distance unit must be m or yd"
+	}
+
+	if r.SkillLevel != nil && !swim.IsValidSkillLevel(*r.SkillLevel) {
+		errors["skillLevel"] = "Skill level must be one of " + strings.Join(swim.SkillLevels(), ", ")
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+// Apply merges the provided fields into profile, converting measurements to metric.
+// Range checks happen here because the bounds depend on the resulting unit system.
+func (r *UpdateProfileRequest) Apply(profile *entity.Profile) *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if r.UnitSystem != nil {
+		profile.UnitSystem = *r.UnitSystem
+	}
+	if r.DistanceUnit != nil {
+		profile.DistanceUnit = *r.DistanceUnit
+	}
+	pref := profile.Units()
+
+	if r.Name != nil {
+		profile.Name = strings.TrimSpace(*r.Name)
+	}
+
+	if r.Weight != nil {
+		weight := pref.WeightToKG(*r.Weight)
+		if weight > maxWeightKG {
+			errors["weight"] = "Weight is too high"
+		}
+		profile.WeightKG = &weight
+	}
+
+	if r.Height != nil {
+		height := pref.HeightToCM(*r.Height)
+		if height > maxHeightCM {
+			errors["height"] = "Height is too high"
+		}
+		profile.HeightCM = &height
+	}
+
+	if r.ThresholdPace != nil {
+		pace := pref.PaceToSecPer100m(*r.ThresholdPace)
+		if pace < swim.MinThresholdPace || pace > swim.MaxThresholdPace {
+			errors["thresholdPace"] = "Threshold pace must be between 0:30 and 10:00 per 100m"
+		}
+		profile.ThresholdPace = &pace
+	}
+
+	if r.SkillLevel != nil {
+		profile.SkillLevel = r.SkillLevel
+	}
+
+	if r.BirthDate != nil {
+		if birthDate, err := dates.Parse(*r.BirthDate); err == nil {
+			profile.BirthDate = &birthDate
+		}
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
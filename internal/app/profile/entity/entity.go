@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"haphap/swimo-api/pkg/units"
+	"time"
+)
+
+type (
+	// Profile is the users row with the account fields shown alongside it.
+	// Measurements are always metric here, see units.Preference for display.
+	Profile struct {
+		AccountID       string
+		Email           string
+		EmailVerifiedAt *time.Time
+		Roles           []string
+		Name            string
+		WeightKG        *float64
+		HeightCM        *float64
+		BirthDate       *time.Time
+		UnitSystem      string
+		DistanceUnit    string
+		SkillLevel      *string  // swim.Skill*, nil until the user picks one
+		ThresholdPace   *float64 // seconds per 100m
+		CreatedAt       time.Time
+		UpdatedAt       time.Time
+	}
+)
+
+func (p *Profile) Units() units.Preference {
+	return units.Preference{System: p.UnitSystem, Distance: p.DistanceUnit}
+}
@@ -0,0 +1,39 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"haphap/swimo-api/internal/app/profile/dto"
+	"log/slog"
+)
+
+// DataExporter is implemented by modules holding account data, each returns its export sections.
+type DataExporter interface {
+	ExportAccountData(ctx context.Context, accountID string) (map[string]any, error)
+}
+
+// Export gathers every section of the account data, keyed by section name.
+func (uc *profileUseCase) Export(ctx context.Context, accountID string) (map[string]any, error) {
+	profile, err := uc.profileRepo.GetProfile(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := map[string]any{"profile": dto.ToProfileResponse(profile)}
+	for _, exporter := range uc.exporters {
+		sections, err := exporter.ExportAccountData(ctx, accountID)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, section := range sections {
+			if _, dup := bundle[name]; dup {
+				return nil, fmt.Errorf("export section %q registered twice", name)
+			}
+			bundle[name] = section
+		}
+	}
+
+	slog.Info("account data exported", slog.String("account_id", accountID))
+	return bundle, nil
+}
@@ -0,0 +1,89 @@
+package profile
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/profile/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrProfileNotFound = errors.New("profile not found")
+)
+
+type ProfileRepository interface {
+	GetProfile(ctx context.Context, accountID string) (*entity.Profile, error)
+	UpdateProfile(ctx context.Context, profile *entity.Profile) error
+}
+
+type profileRepository struct{ db *pgxpool.Pool }
+
+func NewProfileRepository(db *pgxpool.Pool) ProfileRepository { return &profileRepository{db: db} }
+
+func (r *profileRepository) GetProfile(ctx context.Context, accountID string) (*entity.Profile, error) {
+	const sql = `
+		SELECT
+			a.id, a.email, a.email_verified_at,
+			ARRAY(SELECT r.role FROM account_roles AS r WHERE r.account_id = a.id ORDER BY r.role),
+			u.name, u.weight_kg, u.height_cm, u.birth_date, u.unit_system, u.distance_unit,
+			u.skill_level, u.threshold_pace_sec, u.created_at, u.updated_at
+		FROM accounts AS a
+		JOIN users AS u ON u.account_id = a.id
+		WHERE a.id = $1`
+
+	var profile entity.Profile
+	if err := r.db.QueryRow(ctx, sql, accountID).Scan(
+		&profile.AccountID,
+		&profile.Email,
+		&profile.EmailVerifiedAt,
+		&profile.Roles,
+		&profile.Name,
+		&profile.WeightKG,
+		&profile.HeightCM,
+		&profile.BirthDate,
+		&profile.UnitSystem,
+		&profile.DistanceUnit,
+		&profile.SkillLevel,
+		&profile.ThresholdPace,
+		&profile.CreatedAt,
+		&profile.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProfileNotFound
+		}
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (r *profileRepository) UpdateProfile(ctx context.Context, profile *entity.Profile) error {
+	const sql = `
+		UPDATE users
+		SET name = $2, weight_kg = $3, height_cm = $4, birth_date = $5,
+		    unit_system = $6, distance_unit = $7, skill_level = $8,
+		    threshold_pace_sec = $9, updated_at = now()
+		WHERE account_id = $1
+		RETURNING updated_at`
+
+	if err := r.db.QueryRow(ctx, sql,
+		profile.AccountID,
+		profile.Name,
+		profile.WeightKG,
+		profile.HeightCM,
+		profile.BirthDate,
+		profile.UnitSystem,
+		profile.DistanceUnit,
+		profile.SkillLevel,
+		profile.ThresholdPace,
+	).Scan(&profile.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrProfileNotFound
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package profile
+
+import (
+	"context"
+	"haphap/swimo-api/internal/app/profile/dto"
+	"log/slog"
+)
+
+type ProfileUseCase interface {
+	GetProfile(ctx context.Context, accountID string) (*dto.ProfileResponse, error)
+	UpdateProfile(ctx context.Context, accountID string, req dto.UpdateProfileRequest) (*dto.ProfileResponse, error)
+	Export(ctx context.Context, accountID string) (map[string]any, error)
+}
+
+type profileUseCase struct {
+	profileRepo ProfileRepository
+	exporters   []DataExporter
+}
+
+// NewProfileUseCase takes the exporters of every module owning account data, see Export.
+func NewProfileUseCase(profileRepo ProfileRepository, exporters ...DataExporter) ProfileUseCase {
+	return &profileUseCase{profileRepo, exporters}
+}
+
+func (uc *profileUseCase) GetProfile(ctx context.Context, accountID string) (*dto.ProfileResponse, error) {
+	profile, err := uc.profileRepo.GetProfile(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := dto.ToProfileResponse(profile)
+	return &out, nil
+}
+
+// UpdateProfile may return a *validator.ValidationError when a converted value is out of range.
+func (uc *profileUseCase) UpdateProfile(ctx context.Context, accountID string, req dto.UpdateProfileRequest) (*dto.ProfileResponse, error) {
+	profile, err := uc.profileRepo.GetProfile(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := req.Apply(profile); err != nil {
+		return nil, err
+	}
+
+	if err := uc.profileRepo.UpdateProfile(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	slog.Info("profile updated", slog.String("account_id", accountID))
+
+	out := dto.ToProfileResponse(profile)
+	return &out, nil
+}
@@ -0,0 +1,371 @@
+package http
+
+import (
+	"errors"
+	"haphap/swimo-api/internal/app/program"
+	"haphap/swimo-api/internal/app/program/dto"
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/rbac"
+	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/validator"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProgramHandler struct {
+	programUsecase program.ProgramUseCase
+}
+
+func NewProgramHandler(programUsecase program.ProgramUseCase) *ProgramHandler {
+	return &ProgramHandler{programUsecase}
+}
+
+func (h *ProgramHandler) ListPrograms(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var query dto.ListProgramsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid query parameters."})
+	}
+
+	// content managers also see drafts
+	out, total, err := h.programUsecase.ListPrograms(c.Context(), query, principal.Can(rbac.PermManageContent))
+	if err != nil {
+		return err
+	}
+
+	limit, offset := response.NormalizePage(query.Limit, query.Offset)
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    response.Page{Items: out, Total: total, Limit: limit, Offset: offset},
+		Message: "Programs retrieved successfully.",
+	})
+}
+
+func (h *ProgramHandler) GetProgram(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	programID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(programID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Program not found."})
+	}
+
+	out, err := h.programUsecase.GetProgram(c.Context(), programID, principal.Can(rbac.PermManageContent))
+	if err != nil {
+		return programError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Program retrieved successfully.",
+	})
+}
+
+func (h *ProgramHandler) CreateProgram(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var req dto.ProgramRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.programUsecase.CreateProgram(c.Context(), principal.AccountID, req)
+	if err != nil {
+		return programError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.Base{
+		Data:    out,
+		Message: "Program created successfully.",
+	})
+}
+
+func (h *ProgramHandler) UpdateProgram(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	programID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(programID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Program not found."})
+	}
+
+	var req dto.ProgramRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.programUsecase.UpdateProgram(c.Context(), principal.AccountID, programID, req)
+	if err != nil {
+		return programError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Program updated successfully.",
+	})
+}
+
+func (h *ProgramHandler) DeleteProgram(c *fiber.Ctx) error {
+	programID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(programID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Program not found."})
+	}
+
+	if err := h.programUsecase.DeleteProgram(c.Context(), programID); err != nil {
+		return programError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Program deleted successfully."})
+}
+
+func (h *ProgramHandler) Enroll(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	programID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(programID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Program not found."})
+	}
+
+	var req dto.EnrollRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.programUsecase.Enroll(c.Context(), principal.AccountID, programID, req)
+	if err != nil {
+		return programError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.Base{
+		Data:    out,
+		Message: "Enrolled successfully.",
+	})
+}
+
+func (h *ProgramHandler) ListEnrollments(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	out, err := h.programUsecase.ListEnrollments(c.Context(), principal.AccountID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Enrollments retrieved successfully.",
+	})
+}
+
+func (h *ProgramHandler) GetEnrollment(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	enrollmentID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(enrollmentID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Enrollment not found."})
+	}
+
+	out, err := h.programUsecase.GetEnrollment(c.Context(), principal.AccountID, enrollmentID)
+	if err != nil {
+		return programError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Enrollment retrieved successfully.",
+	})
+}
+
+func (h *ProgramHandler) CancelEnrollment(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	enrollmentID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(enrollmentID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Enrollment not found."})
+	}
+
+	if err := h.programUsecase.CancelEnrollment(c.Context(), principal.AccountID, enrollmentID); err != nil {
+		return programError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Enrollment cancelled successfully."})
+}
+
+func (h *ProgramHandler) ListSchedule(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var query dto.ScheduleQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid query parameters."})
+	}
+
+	if err := query.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.programUsecase.ListSchedule(c.Context(), principal.AccountID, query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Schedule retrieved successfully.",
+	})
+}
+
+func (h *ProgramHandler) GetScheduledWorkout(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	scheduledID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(scheduledID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Scheduled workout not found."})
+	}
+
+	out, err := h.programUsecase.GetScheduledWorkout(c.Context(), principal.AccountID, scheduledID)
+	if err != nil {
+		return programError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Scheduled workout retrieved successfully.",
+	})
+}
+
+func (h *ProgramHandler) RescheduleWorkout(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	scheduledID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(scheduledID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Scheduled workout not found."})
+	}
+
+	var req dto.RescheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.programUsecase.RescheduleWorkout(c.Context(), principal.AccountID, scheduledID, req)
+	if err != nil {
+		return programError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Workout rescheduled successfully.",
+	})
+}
+
+func (h *ProgramHandler) SkipScheduledWorkout(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	scheduledID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(scheduledID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Scheduled workout not found."})
+	}
+
+	out, err := h.programUsecase.SkipScheduledWorkout(c.Context(), principal.AccountID, scheduledID)
+	if err != nil {
+		return programError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Workout skipped.",
+	})
+}
+
+func (h *ProgramHandler) CompleteScheduledWorkout(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	scheduledID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(scheduledID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Scheduled workout not found."})
+	}
+
+	var req dto.CompleteScheduledRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.programUsecase.CompleteScheduledWorkout(c.Context(), principal.AccountID, scheduledID, req)
+	if err != nil {
+		return programError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Workout completed.",
+	})
+}
+
+func programError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, program.ErrProgramNotFound):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Program not found."})
+	case errors.Is(err, program.ErrEnrollmentNotFound):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Enrollment not found."})
+	case errors.Is(err, program.ErrScheduledNotFound):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Scheduled workout not found."})
+	case errors.Is(err, program.ErrProgramInUse):
+		return c.Status(http.StatusConflict).JSON(response.Base{Message: "Program has enrollments, unpublish it instead."})
+	case errors.Is(err, program.ErrAlreadyEnrolled):
+		return c.Status(http.StatusConflict).JSON(response.Base{Message: "You are already enrolled in this program."})
+	case errors.Is(err, program.ErrEnrollmentClosed):
+		return c.Status(http.StatusConflict).JSON(response.Base{Message: "Enrollment is no longer active."})
+	case errors.Is(err, program.ErrScheduledCompleted):
+		return c.Status(http.StatusConflict).JSON(response.Base{Message: "Workout is already completed."})
+	case errors.Is(err, program.ErrWorkoutLinked):
+		return c.Status(http.StatusConflict).JSON(response.Base{Message: "Workout already completes another scheduled workout."})
+	case errors.Is(err, program.ErrPlanNotOwned):
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: &validator.ValidationError{
+				Errors: map[string]string{"workouts": "Programs can only use plans of their author"},
+			}},
+		)
+	case errors.Is(err, program.ErrWorkoutNotFound):
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: &validator.ValidationError{
+				Errors: map[string]string{"workoutId": "Workout not found"},
+			}},
+		)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,33 @@
+package http
+
+import (
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/rbac"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func Register(app *fiber.App, programHandler *ProgramHandler, authMw *middleware.AuthMiddleware) {
+	browse := authMw.Require(middleware.GuestAllowed)
+	userOnly := authMw.Require(middleware.UserOnly)
+	manageContent := middleware.RequirePermission(rbac.PermManageContent)
+	manageOwnData := middleware.RequirePermission(rbac.PermManageOwnData)
+
+	apiV1 := app.Group("/api/v1")
+	apiV1.Get("/programs", browse, programHandler.ListPrograms)
+	apiV1.Get("/programs/:id", browse, programHandler.GetProgram)
+	apiV1.Post("/programs", userOnly, manageContent, programHandler.CreateProgram)
+	apiV1.Put("/programs/:id", userOnly, manageContent, programHandler.UpdateProgram)
+	apiV1.Delete("/programs/:id", userOnly, manageContent, programHandler.DeleteProgram)
+	apiV1.Post("/programs/:id/enroll", userOnly, manageOwnData, programHandler.Enroll)
+
+	apiV1.Get("/enrollments", userOnly, manageOwnData, programHandler.ListEnrollments)
+	apiV1.Get("/enrollments/:id", userOnly, manageOwnData, programHandler.GetEnrollment)
+	apiV1.Delete("/enrollments/:id", userOnly, manageOwnData, programHandler.CancelEnrollment)
+
+	apiV1.Get("/schedule", userOnly, manageOwnData, programHandler.ListSchedule)
+	apiV1.Get("/schedule/:id", userOnly, manageOwnData, programHandler.GetScheduledWorkout)
+	apiV1.Patch("/schedule/:id", userOnly, manageOwnData, programHandler.RescheduleWorkout)
+	apiV1.Post("/schedule/:id/skip", userOnly, manageOwnData, programHandler.SkipScheduledWorkout)
+	apiV1.Post("/schedule/:id/complete", userOnly, manageOwnData, programHandler.CompleteScheduledWorkout)
+}
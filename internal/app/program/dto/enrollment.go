@@ -0,0 +1,214 @@
+package dto
+
+import (
+	planEntity "haphap/swimo-api/internal/app/plan/entity"
+	"haphap/swimo-api/internal/app/program/entity"
+	"haphap/swimo-api/pkg/dates"
+	"haphap/swimo-api/pkg/validator"
+	"time"
+)
+
+const (
+	maxStartDaysAgo   = 7
+	maxStartDaysAhead = 365
+)
+
+type (
+	EnrollRequest struct {
+		StartDate string `json:"startDate"` // YYYY-MM-DD
+	}
+
+	RescheduleRequest struct {
+		Date string `json:"date"` // YYYY-MM-DD
+	}
+
+	CompleteScheduledRequest struct {
+		WorkoutID string `json:"workoutId"`
+	}
+
+	ScheduleQuery struct {
+		EnrollmentID string `query:"enrollmentId"`
+		From         string `query:"from"` // YYYY-MM-DD, inclusive
+		To           string `query:"to"`   // YYYY-MM-DD, inclusive
+	}
+
+	EnrollmentResponse struct {
+		ID          string                     `json:"id"`
+		ProgramID   string                     `json:"programId"`
+		ProgramName string                     `json:"programName"`
+		StartDate   string                     `json:"startDate"`
+		Status      string                     `json:"status"`
+		Progress    ProgressResponse           `json:"progress"`
+		Schedule    []ScheduledWorkoutResponse `json:"schedule,omitempty"`
+		CreatedAt   time.Time                  `json:"createdAt"`
+		UpdatedAt   time.Time                  `json:"updatedAt"`
+	}
+
+	ProgressResponse struct {
+		Total     int `json:"total"`
+		Completed int `json:"completed"`
+		Skipped   int `json:"skipped"`
+		Remaining int `json:"remaining"`
+		Percent   int `json:"percent"`
+	}
+
+	ScheduledWorkoutResponse struct {
+		ID            string             `json:"id"`
+		EnrollmentID  string             `json:"enrollmentId"`
+		ProgramName   string             `json:"programName"`
+		Week          int                `json:"week"`
+		Weekday       int                `json:"weekday"`
+		Date          string             `json:"date"`
+		Status        string             `json:"status"`
+		PlanID        *string            `json:"planId"`
+		PlanName      *string            `json:"planName"`
+		DistanceUnit  *string            `json:"distanceUnit"`
+		TotalDistance *float64           `json:"totalDistance"`
+		WorkoutID     *string            `json:"workoutId"`
+		CompletedAt   *time.Time         `json:"completedAt"`
+		Blocks        []planEntity.Block `json:"blocks,omitempty"`
+		Text          string             `json:"text,omitempty"`
+	}
+)
+
+func (r *EnrollRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	start, err := dates.Parse(r.StartDate)
+	if err != nil {
+		errors["startDate"] = "Start date must be formatted as YYYY-MM-DD"
+	} else {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		switch {
+		case start.Before(today.AddDate(0, 0, -maxStartDaysAgo)):
+			errors["startDate"] = "Start date cannot be more than a week ago"
+		case start.After(today.AddDate(0, 0, maxStartDaysAhead)):
+			errors["startDate"] = "Start date cannot be more than a year ahead"
+		}
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (r *RescheduleRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if _, err := dates.Parse(r.Date); err != nil {
+		errors["date"] = "Date must be formatted as YYYY-MM-DD"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (r *CompleteScheduledRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if !validator.UUIDPattern.MatchString(r.WorkoutID) {
+		errors["workoutId"] = "Workout ID is invalid"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (q *ScheduleQuery) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if q.EnrollmentID != "" && !validator.UUIDPattern.MatchString(q.EnrollmentID) {
+		errors["enrollmentId"] = "Enrollment ID is invalid"
+	}
+
+	from, fromErr := dates.Parse(q.From)
+	if q.From != "" && fromErr != nil {
+		errors["from"] = "From must be formatted as YYYY-MM-DD"
+	}
+
+	to, toErr := dates.Parse(q.To)
+	if q.To != "" && toErr != nil {
+		errors["to"] = "To must be formatted as YYYY-MM-DD"
+	}
+
+	if q.From != "" && q.To != "" && fromErr == nil && toErr == nil && from.After(to) {
+		errors["to"] = "To cannot be before from"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+// Filter converts a validated query.
+func (q *ScheduleQuery) Filter() entity.ScheduleFilter {
+	var filter entity.ScheduleFilter
+	if q.EnrollmentID != "" {
+		filter.EnrollmentID = &q.EnrollmentID
+	}
+	if from, err := dates.Parse(q.From); err == nil {
+		filter.From = &from
+	}
+	if to, err := dates.Parse(q.To); err == nil {
+		filter.To = &to
+	}
+	return filter
+}
+
+func ToEnrollmentResponse(enrollment *entity.Enrollment) EnrollmentResponse {
+	progress := enrollment.Progress
+
+	return EnrollmentResponse{
+		ID:          enrollment.ID,
+		ProgramID:   enrollment.ProgramID,
+		ProgramName: enrollment.ProgramName,
+		StartDate:   enrollment.StartDate.Format(dates.Layout),
+		Status:      enrollment.Status,
+		Progress: ProgressResponse{
+			Total:     progress.Total,
+			Completed: progress.Completed,
+			Skipped:   progress.Skipped,
+			Remaining: progress.Total - progress.Completed - progress.Skipped,
+			Percent:   progress.Percent(),
+		},
+		CreatedAt: enrollment.CreatedAt,
+		UpdatedAt: enrollment.UpdatedAt,
+	}
+}
+
+func ToScheduledWorkoutResponse(w *entity.ScheduledWorkout) ScheduledWorkoutResponse {
+	out := ScheduledWorkoutResponse{
+		ID:           w.ID,
+		EnrollmentID: w.EnrollmentID,
+		ProgramName:  w.ProgramName,
+		Week:         w.Week,
+		Weekday:      w.Weekday,
+		Date:         w.Date.Format(dates.Layout),
+		Status:       w.Status,
+		PlanID:       w.PlanID,
+		WorkoutID:    w.WorkoutID,
+		CompletedAt:  w.CompletedAt,
+		Blocks:       w.Blocks,
+	}
+
+	if w.Plan != nil {
+		out.PlanName = &w.Plan.Name
+		out.DistanceUnit = &w.Plan.DistanceUnit
+		out.TotalDistance = &w.Plan.TotalDistance
+	}
+	if len(w.Blocks) > 0 {
+		out.Text = planEntity.FormatShorthand(w.Blocks)
+	}
+
+	return out
+}
@@ -0,0 +1,168 @@
+package dto
+
+import (
+	"fmt"
+	"haphap/swimo-api/internal/app/program/entity"
+	"haphap/swimo-api/pkg/validator"
+	"strings"
+	"time"
+)
+
+const (
+	maxNameLength        = 120
+	maxDescriptionLength = 2000
+	maxWeeks             = 52
+)
+
+type (
+	ProgramRequest struct {
+		Name        string                  `json:"name"`
+		Description *string                 `json:"description"`
+		Weeks       int                     `json:"weeks"`
+		Published   bool                    `json:"published"`
+		Workouts    []ProgramWorkoutRequest `json:"workouts"`
+	}
+
+	// ProgramWorkoutRequest puts a plan of the author on a weekday, 1 = Monday.
+	ProgramWorkoutRequest struct {
+		Week    int    `json:"week"`
+		Weekday int    `json:"weekday"`
+		PlanID  string `json:"planId"`
+	}
+
+	ListProgramsQuery struct {
+		Limit  int `query:"limit"`
+		Offset int `query:"offset"`
+	}
+
+	ProgramResponse struct {
+		ID          string                   `json:"id"`
+		Name        string                   `json:"name"`
+		Description *string                  `json:"description"`
+		Weeks       int                      `json:"weeks"`
+		Published   bool                     `json:"published"`
+		Workouts    []ProgramWorkoutResponse `json:"workouts,omitempty"`
+		CreatedAt   time.Time                `json:"createdAt"`
+		UpdatedAt   time.Time                `json:"updatedAt"`
+	}
+
+	ProgramWorkoutResponse struct {
+		Week          int     `json:"week"`
+		Weekday       int     `json:"weekday"`
+		PlanID        string  `json:"planId"`
+		PlanName      string  `json:"planName"`
+		DistanceUnit  string  `json:"distanceUnit"`
+		TotalDistance float64 `json:"totalDistance"`
+	}
+)
+
+func (r *ProgramRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	name := strings.TrimSpace(r.Name)
+	if name == "" {
+		errors["name"] = "Name is required"
+	} else if len(name) > maxNameLength {
+		errors["name"] = fmt.Sprintf("Name cannot be longer than %d characters", maxNameLength)
+	}
+
+	if r.Description != nil && len(*r.Description) > maxDescriptionLength {
+		errors["description"] = fmt.Sprintf("Description cannot be longer than %d characters", maxDescriptionLength)
+	}
+
+	if r.Weeks < 1 || r.Weeks > maxWeeks {
+		errors["weeks"] = fmt.Sprintf("Weeks must be between 1 and %d", maxWeeks)
+	}
+
+	if len(r.Workouts) == 0 {
+		errors["workouts"] = "A program needs at least one workout"
+	}
+
+	seen := make(map[[2]int]bool, len(r.Workouts))
+	for i, w := range r.Workouts {
+		field := fmt.Sprintf("workouts[%d].", i)
+
+		if w.Week < 1 || w.Week > r.Weeks {
+			errors[field+"week"] = "Week must be between 1 and the number of weeks"
+		}
+		if w.Weekday < 1 || w.Weekday > 7 {
+			errors[field+"weekday"] = "Weekday must be between 1 (Monday) and 7 (Sunday)"
+		}
+		if !validator.UUIDPattern.MatchString(w.PlanID) {
+			errors[field+"planId"] = "Plan ID is invalid"
+		}
+
+		key := [2]int{w.Week, w.Weekday}
+		if seen[key] {
+			errors[field+"weekday"] = "Only one workout per day"
+		}
+		seen[key] = true
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+// PlanIDs returns the distinct plans the program uses.
+func (r *ProgramRequest) PlanIDs() []string {
+	ids := make([]string, 0, len(r.Workouts))
+	seen := make(map[string]bool, len(r.Workouts))
+	for _, w := range r.Workouts {
+		id := strings.ToLower(w.PlanID)
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Apply copies a validated request into program.
+func (r *ProgramRequest) Apply(program *entity.Program) {
+	program.Name = strings.TrimSpace(r.Name)
+	program.Description = nil
+	if r.Description != nil {
+		if description := strings.TrimSpace(*r.Description); description != "" {
+			program.Description = &description
+		}
+	}
+	program.Weeks = r.Weeks
+	program.IsPublished = r.Published
+
+	program.Workouts = make([]entity.ProgramWorkout, 0, len(r.Workouts))
+	for _, w := range r.Workouts {
+		program.Workouts = append(program.Workouts, entity.ProgramWorkout{
+			Week:    w.Week,
+			Weekday: w.Weekday,
+			PlanID:  strings.ToLower(w.PlanID),
+		})
+	}
+}
+
+func ToProgramResponse(program *entity.Program) ProgramResponse {
+	out := ProgramResponse{
+		ID:          program.ID,
+		Name:        program.Name,
+		Description: program.Description,
+		Weeks:       program.Weeks,
+		Published:   program.IsPublished,
+		CreatedAt:   program.CreatedAt,
+		UpdatedAt:   program.UpdatedAt,
+	}
+
+	for _, w := range program.Workouts {
+		out.Workouts = append(out.Workouts, ProgramWorkoutResponse{
+			Week:          w.Week,
+			Weekday:       w.Weekday,
+			PlanID:        w.PlanID,
+			PlanName:      w.Plan.Name,
+			DistanceUnit:  w.Plan.DistanceUnit,
+			TotalDistance: w.Plan.TotalDistance,
+		})
+	}
+
+	return out
+}
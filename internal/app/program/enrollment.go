@@ -0,0 +1,262 @@
+package program
+
+import (
+	"context"
+	planEntity "haphap/swimo-api/internal/app/plan/entity"
+	"haphap/swimo-api/internal/app/program/dto"
+	"haphap/swimo-api/internal/app/program/entity"
+	"haphap/swimo-api/pkg/dates"
+	"haphap/swimo-api/pkg/units"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Enroll materializes the calendar of a published program from the start date.
+func (uc *programUseCase) Enroll(ctx context.Context, accountID, programID string, req dto.EnrollRequest) (*dto.EnrollmentResponse, error) {
+	program, err := uc.programRepo.GetProgram(ctx, programID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	start, _ := dates.Parse(req.StartDate)
+	enrollment := &entity.Enrollment{
+		AccountID: accountID,
+		ProgramID: programID,
+		StartDate: start,
+	}
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := uc.programRepo.CreateEnrollment(ctx, tx, enrollment); err != nil {
+		return nil, err
+	}
+
+	if err := uc.programRepo.CreateScheduledWorkouts(ctx, tx, enrollment, program.Schedule(start)); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("enroll: commit transaction failed", slog.String("account_id", accountID), slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	slog.Info("program enrollment created",
+		slog.String("account_id", accountID),
+		slog.String("program_id", programID),
+		slog.String("enrollment_id", enrollment.ID),
+	)
+
+	return uc.GetEnrollment(ctx, accountID, enrollment.ID)
+}
+
+func (uc *programUseCase) ListEnrollments(ctx context.Context, accountID string) ([]dto.EnrollmentResponse, error) {
+	enrollments, err := uc.programRepo.ListEnrollments(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]dto.EnrollmentResponse, 0, len(enrollments))
+	for i := range enrollments {
+		out = append(out, dto.ToEnrollmentResponse(&enrollments[i]))
+	}
+
+	return out, nil
+}
+
+// GetEnrollment returns the enrollment with its whole calendar.
+func (uc *programUseCase) GetEnrollment(ctx context.Context, accountID, enrollmentID string) (*dto.EnrollmentResponse, error) {
+	enrollment, err := uc.programRepo.GetEnrollment(ctx, accountID, enrollmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := uc.programRepo.ListScheduledWorkouts(ctx, accountID, entity.ScheduleFilter{EnrollmentID: &enrollmentID})
+	if err != nil {
+		return nil, err
+	}
+
+	out := dto.ToEnrollmentResponse(enrollment)
+	out.Schedule = make([]dto.ScheduledWorkoutResponse, 0, len(schedule))
+	for i := range schedule {
+		out.Schedule = append(out.Schedule, dto.ToScheduledWorkoutResponse(&schedule[i]))
+	}
+
+	return &out, nil
+}
+
+// CancelEnrollment drops the remaining calendar, completed and skipped workouts stay as history.
+func (uc *programUseCase) CancelEnrollment(ctx context.Context, accountID, enrollmentID string) error {
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := uc.programRepo.CancelEnrollment(ctx, tx, accountID, enrollmentID); err != nil {
+		return err
+	}
+
+	if err := uc.programRepo.DeletePlannedWorkouts(ctx, tx, enrollmentID); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("cancel enrollment: commit transaction failed", slog.String("enrollment_id", enrollmentID), slog.String("err", err.Error()))
+		return err
+	}
+
+	slog.Info("program enrollment cancelled", slog.String("account_id", accountID), slog.String("enrollment_id", enrollmentID))
+	return nil
+}
+
+func (uc *programUseCase) ListSchedule(ctx context.Context, accountID string, query dto.ScheduleQuery) ([]dto.ScheduledWorkoutResponse, error) {
+	schedule, err := uc.programRepo.ListScheduledWorkouts(ctx, accountID, query.Filter())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]dto.ScheduledWorkoutResponse, 0, len(schedule))
+	for i := range schedule {
+		out = append(out, dto.ToScheduledWorkoutResponse(&schedule[i]))
+	}
+
+	return out, nil
+}
+
+// GetScheduledWorkout includes the plan to swim, even though the plan belongs to the program author.
+// Paces relative to CSS are resolved to send-offs for the enrolled user.
+func (uc *programUseCase) GetScheduledWorkout(ctx context.Context, accountID, scheduledID string) (*dto.ScheduledWorkoutResponse, error) {
+	scheduled, err := uc.programRepo.GetScheduledWorkout(ctx, accountID, scheduledID)
+	if err != nil {
+		return nil, err
+	}
+
+	if scheduled.Plan != nil && planEntity.HasPaces(scheduled.Blocks) {
+		pace, err := uc.programRepo.GetThresholdPace(ctx, accountID)
+		if err != nil {
+			return nil, err
+		}
+		if pace != nil {
+			pref := units.Preference{Distance: scheduled.Plan.DistanceUnit}
+			scheduled.Blocks = planEntity.ResolvePaces(scheduled.Blocks, pref.PaceFromSecPer100m(*pace))
+		}
+	}
+
+	out := dto.ToScheduledWorkoutResponse(scheduled)
+	return &out, nil
+}
+
+// RescheduleWorkout moves a planned or skipped workout to another day.
+func (uc *programUseCase) RescheduleWorkout(ctx context.Context, accountID, scheduledID string, req dto.RescheduleRequest) (*dto.ScheduledWorkoutResponse, error) {
+	if _, err := uc.editableScheduledWorkout(ctx, accountID, scheduledID); err != nil {
+		return nil, err
+	}
+
+	date, _ := dates.Parse(req.Date)
+	if err := uc.programRepo.RescheduleWorkout(ctx, accountID, scheduledID, date); err != nil {
+		return nil, err
+	}
+
+	slog.Info("scheduled workout moved", slog.String("account_id", accountID), slog.String("scheduled_id", scheduledID))
+	return uc.GetScheduledWorkout(ctx, accountID, scheduledID)
+}
+
+func (uc *programUseCase) SkipScheduledWorkout(ctx context.Context, accountID, scheduledID string) (*dto.ScheduledWorkoutResponse, error) {
+	return uc.finishScheduledWorkout(ctx, accountID, scheduledID, entity.ScheduledSkipped, nil)
+}
+
+// CompleteScheduledWorkout marks the scheduled workout done by a workout the user logged.
+func (uc *programUseCase) CompleteScheduledWorkout(ctx context.Context, accountID, scheduledID string, req dto.CompleteScheduledRequest) (*dto.ScheduledWorkoutResponse, error) {
+	exists, err := uc.programRepo.WorkoutExists(ctx, accountID, req.WorkoutID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrWorkoutNotFound
+	}
+
+	return uc.finishScheduledWorkout(ctx, accountID, scheduledID, entity.ScheduledCompleted, &req.WorkoutID)
+}
+
+// finishScheduledWorkout skips or completes a workout and closes the enrollment when it was the last one.
+func (uc *programUseCase) finishScheduledWorkout(ctx context.Context, accountID, scheduledID, status string, workoutID *string) (*dto.ScheduledWorkoutResponse, error) {
+	scheduled, err := uc.editableScheduledWorkout(ctx, accountID, scheduledID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := uc.programRepo.SetScheduledStatus(ctx, tx, scheduledID, status, workoutID); err != nil {
+		return nil, err
+	}
+
+	finished, err := uc.programRepo.CompleteEnrollmentIfDone(ctx, tx, scheduled.EnrollmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("finish scheduled workout: commit transaction failed", slog.String("scheduled_id", scheduledID), slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	slog.Info("scheduled workout "+status, slog.String("account_id", accountID), slog.String("scheduled_id", scheduledID))
+	if finished {
+		slog.Info("program enrollment completed", slog.String("account_id", accountID), slog.String("enrollment_id", scheduled.EnrollmentID))
+	}
+
+	return uc.GetScheduledWorkout(ctx, accountID, scheduledID)
+}
+
+// editableScheduledWorkout only lets the calendar of an active enrollment change, completed workouts are final.
+// The updates check it again, this only fails early.
+func (uc *programUseCase) editableScheduledWorkout(ctx context.Context, accountID, scheduledID string) (*entity.ScheduledWorkout, error) {
+	scheduled, err := uc.programRepo.GetScheduledWorkout(ctx, accountID, scheduledID)
+	if err != nil {
+		return nil, err
+	}
+
+	if scheduled.EnrollmentStatus != entity.EnrollmentActive {
+		return nil, ErrEnrollmentClosed
+	}
+	if scheduled.Status == entity.ScheduledCompleted {
+		return nil, ErrScheduledCompleted
+	}
+
+	return scheduled, nil
+}
+
+// ExportAccountData contributes enrollments and their calendars to the account export.
+func (uc *programUseCase) ExportAccountData(ctx context.Context, accountID string) (map[string]any, error) {
+	enrollments, err := uc.programRepo.ListEnrollments(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]dto.EnrollmentResponse, 0, len(enrollments))
+	for i := range enrollments {
+		enrollment, err := uc.GetEnrollment(ctx, accountID, enrollments[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *enrollment)
+	}
+
+	return map[string]any{"enrollments": out}, nil
+}
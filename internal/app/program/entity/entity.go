@@ -0,0 +1,128 @@
+package entity
+
+import (
+	planEntity "haphap/swimo-api/internal/app/plan/entity"
+	"slices"
+	"time"
+)
+
+const (
+	EnrollmentActive    = "active"
+	EnrollmentCompleted = "completed"
+	EnrollmentCancelled = "cancelled"
+)
+
+const (
+	ScheduledPlanned   = "planned"
+	ScheduledCompleted = "completed"
+	ScheduledSkipped   = "skipped"
+)
+
+type (
+	Program struct {
+		ID              string
+		AuthorAccountID *string
+		Name            string
+		Description     *string
+		Weeks           int
+		IsPublished     bool
+		Workouts        []ProgramWorkout
+		CreatedAt       time.Time
+		UpdatedAt       time.Time
+	}
+
+	// ProgramWorkout puts a plan on a weekday (1 = Monday) of a week (1-based).
+	ProgramWorkout struct {
+		Week    int
+		Weekday int
+		PlanID  string
+		Plan    PlanSummary
+	}
+
+	// PlanSummary is what schedules show of a plan, plans stay owned by their author.
+	PlanSummary struct {
+		Name          string
+		DistanceUnit  string
+		TotalDistance float64
+	}
+
+	Enrollment struct {
+		ID          string
+		AccountID   string
+		ProgramID   string
+		ProgramName string
+		StartDate   time.Time
+		Status      string
+		Progress    Progress
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
+	}
+
+	Progress struct {
+		Total     int
+		Completed int
+		Skipped   int
+	}
+
+	ScheduledWorkout struct {
+		ID               string
+		EnrollmentID     string
+		EnrollmentStatus string
+		AccountID        string
+		ProgramName      string
+		PlanID           *string
+		Plan             *PlanSummary       // nil once the plan is deleted
+		Blocks           []planEntity.Block // only loaded for a single scheduled workout
+		Week             int
+		Weekday          int
+		Date             time.Time
+		Status           string
+		WorkoutID        *string
+		CompletedAt      *time.Time
+	}
+
+	ScheduleFilter struct {
+		EnrollmentID *string
+		From         *time.Time
+		To           *time.Time
+	}
+)
+
+// Percent is the share of scheduled workouts completed, skipped ones count as not done.
+func (p Progress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Completed * 100 / p.Total
+}
+
+// ScheduleDate places a program workout on the calendar: week N starts N-1 weeks
+// after start and the workout lands on the first matching weekday from there.
+func ScheduleDate(start time.Time, week, weekday int) time.Time {
+	weekStart := start.AddDate(0, 0, (week-1)*7)
+
+	isoWeekday := int(weekStart.Weekday())
+	if isoWeekday == 0 {
+		isoWeekday = 7
+	}
+
+	return weekStart.AddDate(0, 0, (weekday-isoWeekday+7)%7)
+}
+
+// Schedule materializes every workout of the program from start, in calendar order.
+func (p *Program) Schedule(start time.Time) []ScheduledWorkout {
+	out := make([]ScheduledWorkout, 0, len(p.Workouts))
+	for _, w := range p.Workouts {
+		planID := w.PlanID
+		out = append(out, ScheduledWorkout{
+			PlanID:  &planID,
+			Week:    w.Week,
+			Weekday: w.Weekday,
+			Date:    ScheduleDate(start, w.Week, w.Weekday),
+			Status:  ScheduledPlanned,
+		})
+	}
+
+	slices.SortStableFunc(out, func(a, b ScheduledWorkout) int { return a.Date.Compare(b.Date) })
+	return out
+}
@@ -0,0 +1,657 @@
+package program
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/program/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrProgramNotFound    = errors.New("program not found")
+	ErrProgramInUse       = errors.New("program has enrollments")
+	ErrEnrollmentNotFound = errors.New("enrollment not found")
+	ErrAlreadyEnrolled    = errors.New("already enrolled in program")
+	ErrScheduledNotFound  = errors.New("scheduled workout not found")
+	ErrWorkoutNotFound    = errors.New("workout not found")
+	ErrWorkoutLinked      = errors.New("workout already completes another scheduled workout")
+)
+
+type ProgramRepository interface {
+	CountOwnedPlans(ctx context.Context, accountID string, programID *string, planIDs []string) (int, error)
+	CreateProgram(ctx context.Context, tx pgx.Tx, program *entity.Program) error
+	UpdateProgram(ctx context.Context, tx pgx.Tx, program *entity.Program) error
+	ReplaceProgramWorkouts(ctx context.Context, tx pgx.Tx, programID string, workouts []entity.ProgramWorkout) error
+	GetProgram(ctx context.Context, programID string, publishedOnly bool) (*entity.Program, error)
+	ListPrograms(ctx context.Context, publishedOnly bool, limit, offset int) ([]entity.Program, int, error)
+	DeleteProgram(ctx context.Context, tx pgx.Tx, programID string) error
+	DetachProgramPlans(ctx context.Context, tx pgx.Tx, accountIDs []string) (count int64, err error)
+
+	CreateEnrollment(ctx context.Context, tx pgx.Tx, enrollment *entity.Enrollment) error
+	CreateScheduledWorkouts(ctx context.Context, tx pgx.Tx, enrollment *entity.Enrollment, workouts []entity.ScheduledWorkout) error
+	GetEnrollment(ctx context.Context, accountID, enrollmentID string) (*entity.Enrollment, error)
+	ListEnrollments(ctx context.Context, accountID string) ([]entity.Enrollment, error)
+	CancelEnrollment(ctx context.Context, tx pgx.Tx, accountID, enrollmentID string) error
+	DeletePlannedWorkouts(ctx context.Context, tx pgx.Tx, enrollmentID string) error
+	CompleteEnrollmentIfDone(ctx context.Context, tx pgx.Tx, enrollmentID string) (bool, error)
+
+	GetScheduledWorkout(ctx context.Context, accountID, scheduledID string) (*entity.ScheduledWorkout, error)
+	ListScheduledWorkouts(ctx context.Context, accountID string, filter entity.ScheduleFilter) ([]entity.ScheduledWorkout, error)
+	RescheduleWorkout(ctx context.Context, accountID, scheduledID string, date time.Time) error
+	SetScheduledStatus(ctx context.Context, tx pgx.Tx, scheduledID, status string, workoutID *string) error
+	WorkoutExists(ctx context.Context, accountID, workoutID string) (bool, error)
+	GetThresholdPace(ctx context.Context, accountID string) (*float64, error)
+}
+
+type programRepository struct{ db *pgxpool.Pool }
+
+func NewProgramRepository(db *pgxpool.Pool) ProgramRepository { return &programRepository{db: db} }
+
+// CountOwnedPlans counts how many of planIDs belong to accountID, programs only use their author's plans.
+// Plans detached from a purged author belong to the program scheduling them, programID when it is set.
+func (r *programRepository) CountOwnedPlans(ctx context.Context, accountID string, programID *string, planIDs []string) (int, error) {
+	const sql = `
+		SELECT COUNT(*) FROM workout_plans
+		WHERE (account_id = $1 OR (account_id IS NULL AND program_id = $2)) AND id = ANY($3::uuid[])`
+
+	var count int
+	if err := r.db.QueryRow(ctx, sql, accountID, programID, planIDs).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *programRepository) CreateProgram(ctx context.Context, tx pgx.Tx, program *entity.Program) error {
+	const sql = `
+		INSERT INTO programs (author_account_id, name, description, weeks, is_published)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at`
+
+	return tx.QueryRow(ctx, sql,
+		program.AuthorAccountID,
+		program.Name,
+		program.Description,
+		program.Weeks,
+		program.IsPublished,
+	).Scan(&program.ID, &program.CreatedAt, &program.UpdatedAt)
+}
+
+func (r *programRepository) UpdateProgram(ctx context.Context, tx pgx.Tx, program *entity.Program) error {
+	const sql = `
+		UPDATE programs
+		SET name = $2, description = $3, weeks = $4, is_published = $5, updated_at = now()
+		WHERE id = $1
+		RETURNING author_account_id, created_at, updated_at`
+
+	if err := tx.QueryRow(ctx, sql,
+		program.ID,
+		program.Name,
+		program.Description,
+		program.Weeks,
+		program.IsPublished,
+	).Scan(&program.AuthorAccountID, &program.CreatedAt, &program.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrProgramNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *programRepository) ReplaceProgramWorkouts(ctx context.Context, tx pgx.Tx, programID string, workouts []entity.ProgramWorkout) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM program_workouts WHERE program_id = $1`, programID); err != nil {
+		return err
+	}
+
+	var (
+		weeks    = make([]int, len(workouts))
+		weekdays = make([]int, len(workouts))
+		planIDs  = make([]string, len(workouts))
+	)
+	for i, w := range workouts {
+		weeks[i] = w.Week
+		weekdays[i] = w.Weekday
+		planIDs[i] = w.PlanID
+	}
+
+	const sql = `
+		INSERT INTO program_workouts (program_id, week, weekday, plan_id)
+		SELECT $1, w.week, w.weekday, w.plan_id
+		FROM unnest($2::int[], $3::int[], $4::uuid[]) AS w(week, weekday, plan_id)`
+
+	_, err := tx.Exec(ctx, sql, programID, weeks, weekdays, planIDs)
+	return err
+}
+
+func (r *programRepository) GetProgram(ctx context.Context, programID string, publishedOnly bool) (*entity.Program, error) {
+	const sql = `
+		SELECT id, author_account_id, name, description, weeks, is_published, created_at, updated_at
+		FROM programs
+		WHERE id = $1 AND (is_published OR NOT $2)`
+
+	var program entity.Program
+	if err := r.db.QueryRow(ctx, sql, programID, publishedOnly).Scan(
+		&program.ID,
+		&program.AuthorAccountID,
+		&program.Name,
+		&program.Description,
+		&program.Weeks,
+		&program.IsPublished,
+		&program.CreatedAt,
+		&program.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrProgramNotFound
+		}
+		return nil, err
+	}
+
+	const workoutsSQL = `
+		SELECT w.week, w.weekday, w.plan_id, p.name, p.distance_unit, p.total_distance
+		FROM program_workouts AS w
+		JOIN workout_plans AS p ON p.id = w.plan_id
+		WHERE w.program_id = $1
+		ORDER BY w.week, w.weekday`
+
+	rows, err := r.db.Query(ctx, workoutsSQL, programID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var w entity.ProgramWorkout
+		if err := rows.Scan(
+			&w.Week,
+			&w.Weekday,
+			&w.PlanID,
+			&w.Plan.Name,
+			&w.Plan.DistanceUnit,
+			&w.Plan.TotalDistance,
+		); err != nil {
+			return nil, err
+		}
+		program.Workouts = append(program.Workouts, w)
+	}
+
+	return &program, rows.Err()
+}
+
+// ListPrograms returns a page of programs without their workouts, newest first.
+func (r *programRepository) ListPrograms(ctx context.Context, publishedOnly bool, limit, offset int) ([]entity.Program, int, error) {
+	const sql = `
+		SELECT
+			id, author_account_id, name, description, weeks, is_published, created_at, updated_at,
+			COUNT(*) OVER ()
+		FROM programs
+		WHERE is_published OR NOT $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(ctx, sql, publishedOnly, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	total := 0
+	programs := make([]entity.Program, 0)
+	for rows.Next() {
+		var program entity.Program
+		if err := rows.Scan(
+			&program.ID,
+			&program.AuthorAccountID,
+			&program.Name,
+			&program.Description,
+			&program.Weeks,
+			&program.IsPublished,
+			&program.CreatedAt,
+			&program.UpdatedAt,
+			&total,
+		); err != nil {
+			return nil, 0, err
+		}
+		programs = append(programs, program)
+	}
+
+	return programs, total, rows.Err()
+}
+
+// DeleteProgram also deletes the plans the program owns, see DetachProgramPlans.
+func (r *programRepository) DeleteProgram(ctx context.Context, tx pgx.Tx, programID string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM program_workouts WHERE program_id = $1`, programID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM workout_plans WHERE program_id = $1 AND account_id IS NULL`, programID); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM programs WHERE id = $1`, programID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" { // foreign_key_violation
+			return ErrProgramInUse
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProgramNotFound
+	}
+
+	return nil
+}
+
+// DetachProgramPlans hands the accounts' plans used by a program over to that program, so
+// that they survive the account purge. The first program using a plan owns it, the others
+// get a copy. Nobody can edit a detached plan anymore.
+func (r *programRepository) DetachProgramPlans(ctx context.Context, tx pgx.Tx, accountIDs []string) (count int64, err error) {
+	const usesSQL = `
+		SELECT DISTINCT w.plan_id, w.program_id
+		FROM program_workouts AS w
+		JOIN workout_plans AS p ON p.id = w.plan_id
+		WHERE p.account_id = ANY($1::uuid[])
+		ORDER BY w.plan_id, w.program_id`
+
+	rows, err := tx.Query(ctx, usesSQL, accountIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	type planUse struct{ planID, programID string }
+	var uses []planUse
+	for rows.Next() {
+		var use planUse
+		if err := rows.Scan(&use.planID, &use.programID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		uses = append(uses, use)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	const detachSQL = `UPDATE workout_plans SET account_id = NULL, program_id = $2, updated_at = now() WHERE id = $1`
+	const copySQL = `
+		INSERT INTO workout_plans (program_id, name, description, distance_unit, blocks, total_distance)
+		SELECT $2, name, description, distance_unit, blocks, total_distance
+		FROM workout_plans WHERE id = $1
+		RETURNING id`
+	const repointSQL = `UPDATE program_workouts SET plan_id = $3 WHERE program_id = $2 AND plan_id = $1`
+
+	for i, use := range uses {
+		if i == 0 || uses[i-1].planID != use.planID {
+			if _, err := tx.Exec(ctx, detachSQL, use.planID, use.programID); err != nil {
+				return 0, err
+			}
+			count++
+			continue
+		}
+
+		var copyID string
+		if err := tx.QueryRow(ctx, copySQL, use.planID, use.programID).Scan(&copyID); err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec(ctx, repointSQL, use.planID, use.programID, copyID); err != nil {
+			return 0, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+func (r *programRepository) CreateEnrollment(ctx context.Context, tx pgx.Tx, enrollment *entity.Enrollment) error {
+	const sql = `
+		INSERT INTO enrollments (account_id, program_id, start_date)
+		VALUES ($1, $2, $3)
+		RETURNING id, status, created_at, updated_at`
+
+	if err := tx.QueryRow(ctx, sql,
+		enrollment.AccountID,
+		enrollment.ProgramID,
+		enrollment.StartDate,
+	).Scan(&enrollment.ID, &enrollment.Status, &enrollment.CreatedAt, &enrollment.UpdatedAt); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return ErrAlreadyEnrolled
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *programRepository) CreateScheduledWorkouts(ctx context.Context, tx pgx.Tx, enrollment *entity.Enrollment, workouts []entity.ScheduledWorkout) error {
+	var (
+		planIDs  = make([]*string, len(workouts))
+		weeks    = make([]int, len(workouts))
+		weekdays = make([]int, len(workouts))
+		dates    = make([]time.Time, len(workouts))
+	)
+	for i, w := range workouts {
+		planIDs[i] = w.PlanID
+		weeks[i] = w.Week
+		weekdays[i] = w.Weekday
+		dates[i] = w.Date
+	}
+
+	const sql = `
+		INSERT INTO scheduled_workouts (enrollment_id, account_id, plan_id, week, weekday, scheduled_on)
+		SELECT $1, $2, w.plan_id, w.week, w.weekday, w.scheduled_on
+		FROM unnest($3::uuid[], $4::int[], $5::int[], $6::date[]) AS w(plan_id, week, weekday, scheduled_on)`
+
+	_, err := tx.Exec(ctx, sql, enrollment.ID, enrollment.AccountID, planIDs, weeks, weekdays, dates)
+	return err
+}
+
+const enrollmentSelectSQL = `
+	SELECT
+		e.id, e.account_id, e.program_id, p.name, e.start_date, e.status, e.created_at, e.updated_at,
+		COUNT(s.id),
+		COUNT(s.id) FILTER (WHERE s.status = 'completed'),
+		COUNT(s.id) FILTER (WHERE s.status = 'skipped')
+	FROM enrollments AS e
+	JOIN programs AS p ON p.id = e.program_id
+	LEFT JOIN scheduled_workouts AS s ON s.enrollment_id = e.id`
+
+func scanEnrollment(row pgx.Row) (*entity.Enrollment, error) {
+	var enrollment entity.Enrollment
+	if err := row.Scan(
+		&enrollment.ID,
+		&enrollment.AccountID,
+		&enrollment.ProgramID,
+		&enrollment.ProgramName,
+		&enrollment.StartDate,
+		&enrollment.Status,
+		&enrollment.CreatedAt,
+		&enrollment.UpdatedAt,
+		&enrollment.Progress.Total,
+		&enrollment.Progress.Completed,
+		&enrollment.Progress.Skipped,
+	); err != nil {
+		return nil, err
+	}
+
+	return &enrollment, nil
+}
+
+func (r *programRepository) GetEnrollment(ctx context.Context, accountID, enrollmentID string) (*entity.Enrollment, error) {
+	sql := enrollmentSelectSQL + `
+		WHERE e.id = $1 AND e.account_id = $2
+		GROUP BY e.id, p.name`
+
+	enrollment, err := scanEnrollment(r.db.QueryRow(ctx, sql, enrollmentID, accountID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEnrollmentNotFound
+		}
+		return nil, err
+	}
+
+	return enrollment, nil
+}
+
+// ListEnrollments returns active enrollments first, then the history.
+func (r *programRepository) ListEnrollments(ctx context.Context, accountID string) ([]entity.Enrollment, error) {
+	sql := enrollmentSelectSQL + `
+		WHERE e.account_id = $1
+		GROUP BY e.id, p.name
+		ORDER BY e.status = 'active' DESC, e.start_date DESC`
+
+	rows, err := r.db.Query(ctx, sql, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	enrollments := make([]entity.Enrollment, 0)
+	for rows.Next() {
+		enrollment, err := scanEnrollment(rows)
+		if err != nil {
+			return nil, err
+		}
+		enrollments = append(enrollments, *enrollment)
+	}
+
+	return enrollments, rows.Err()
+}
+
+func (r *programRepository) CancelEnrollment(ctx context.Context, tx pgx.Tx, accountID, enrollmentID string) error {
+	const sql = `
+		UPDATE enrollments SET status = 'cancelled', updated_at = now()
+		WHERE id = $1 AND account_id = $2 AND status = 'active'`
+
+	tag, err := tx.Exec(ctx, sql, enrollmentID, accountID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrEnrollmentNotFound
+	}
+
+	return nil
+}
+
+// DeletePlannedWorkouts drops what is left of a cancelled enrollment, completed and skipped ones stay as history.
+func (r *programRepository) DeletePlannedWorkouts(ctx context.Context, tx pgx.Tx, enrollmentID string) error {
+	const sql = `DELETE FROM scheduled_workouts WHERE enrollment_id = $1 AND status = 'planned'`
+
+	_, err := tx.Exec(ctx, sql, enrollmentID)
+	return err
+}
+
+// CompleteEnrollmentIfDone closes an active enrollment once nothing is planned anymore.
+func (r *programRepository) CompleteEnrollmentIfDone(ctx context.Context, tx pgx.Tx, enrollmentID string) (bool, error) {
+	const sql = `
+		UPDATE enrollments SET status = 'completed', updated_at = now()
+		WHERE id = $1 AND status = 'active'
+		  AND NOT EXISTS (
+			SELECT 1 FROM scheduled_workouts
+			WHERE enrollment_id = $1 AND status = 'planned'
+		  )`
+
+	tag, err := tx.Exec(ctx, sql, enrollmentID)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+const scheduledSelectSQL = `
+	SELECT
+		s.id, s.enrollment_id, e.status, s.account_id, p.name,
+		s.plan_id, wp.name, wp.distance_unit, wp.total_distance,
+		s.week, s.weekday, s.scheduled_on, s.status, s.workout_id, s.completed_at
+	FROM scheduled_workouts AS s
+	JOIN enrollments AS e ON e.id = s.enrollment_id
+	JOIN programs AS p ON p.id = e.program_id
+	LEFT JOIN workout_plans AS wp ON wp.id = s.plan_id`
+
+func scanScheduledWorkout(row pgx.Row) (*entity.ScheduledWorkout, error) {
+	var (
+		w             entity.ScheduledWorkout
+		planName      *string
+		distanceUnit  *string
+		totalDistance *float64
+	)
+	if err := row.Scan(
+		&w.ID,
+		&w.EnrollmentID,
+		&w.EnrollmentStatus,
+		&w.AccountID,
+		&w.ProgramName,
+		&w.PlanID,
+		&planName,
+		&distanceUnit,
+		&totalDistance,
+		&w.Week,
+		&w.Weekday,
+		&w.Date,
+		&w.Status,
+		&w.WorkoutID,
+		&w.CompletedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if planName != nil {
+		w.Plan = &entity.PlanSummary{Name: *planName, DistanceUnit: *distanceUnit, TotalDistance: *totalDistance}
+	}
+
+	return &w, nil
+}
+
+// GetScheduledWorkout also loads the blocks of the plan to swim.
+func (r *programRepository) GetScheduledWorkout(ctx context.Context, accountID, scheduledID string) (*entity.ScheduledWorkout, error) {
+	sql := scheduledSelectSQL + `
+		WHERE s.id = $1 AND s.account_id = $2`
+
+	w, err := scanScheduledWorkout(r.db.QueryRow(ctx, sql, scheduledID, accountID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrScheduledNotFound
+		}
+		return nil, err
+	}
+
+	if w.PlanID != nil {
+		const blocksSQL = `SELECT blocks FROM workout_plans WHERE id = $1`
+		if err := r.db.QueryRow(ctx, blocksSQL, *w.PlanID).Scan(&w.Blocks); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+// ListScheduledWorkouts returns the calendar in date order. Without an enrollment
+// filter, only enrollments that are not cancelled are shown.
+func (r *programRepository) ListScheduledWorkouts(ctx context.Context, accountID string, filter entity.ScheduleFilter) ([]entity.ScheduledWorkout, error) {
+	sql := scheduledSelectSQL + `
+		WHERE s.account_id = $1
+		  AND (($2::uuid IS NULL AND e.status <> 'cancelled') OR s.enrollment_id = $2)
+		  AND ($3::date IS NULL OR s.scheduled_on >= $3)
+		  AND ($4::date IS NULL OR s.scheduled_on <= $4)
+		ORDER BY s.scheduled_on, s.week, s.weekday`
+
+	rows, err := r.db.Query(ctx, sql, accountID, filter.EnrollmentID, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workouts := make([]entity.ScheduledWorkout, 0)
+	for rows.Next() {
+		w, err := scanScheduledWorkout(rows)
+		if err != nil {
+			return nil, err
+		}
+		workouts = append(workouts, *w)
+	}
+
+	return workouts, rows.Err()
+}
+
+// RescheduleWorkout moves a workout to another day, a skipped one becomes planned again.
+func (r *programRepository) RescheduleWorkout(ctx context.Context, accountID, scheduledID string, date time.Time) error {
+	const sql = `
+		UPDATE scheduled_workouts AS s
+		SET scheduled_on = $3, status = 'planned', updated_at = now()
+		WHERE s.id = $1 AND s.account_id = $2 AND s.status <> 'completed'
+		  AND EXISTS (SELECT 1 FROM enrollments AS e WHERE e.id = s.enrollment_id AND e.status = 'active')`
+
+	tag, err := r.db.Exec(ctx, sql, scheduledID, accountID, date)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		const stateSQL = `
+			SELECT s.status, e.status
+			FROM scheduled_workouts AS s
+			JOIN enrollments AS e ON e.id = s.enrollment_id
+			WHERE s.id = $1 AND s.account_id = $2`
+
+		return scheduledNotUpdated(r.db.QueryRow(ctx, stateSQL, scheduledID, accountID))
+	}
+
+	return nil
+}
+
+func (r *programRepository) SetScheduledStatus(ctx context.Context, tx pgx.Tx, scheduledID, status string, workoutID *string) error {
+	const sql = `
+		UPDATE scheduled_workouts AS s
+		SET status = $2, workout_id = $3,
+		    completed_at = CASE WHEN $2 = 'completed' THEN now() END,
+		    updated_at = now()
+		WHERE s.id = $1 AND s.status <> 'completed'
+		  AND EXISTS (SELECT 1 FROM enrollments AS e WHERE e.id = s.enrollment_id AND e.status = 'active')`
+
+	tag, err := tx.Exec(ctx, sql, scheduledID, status, workoutID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" { // unique_violation
+			return ErrWorkoutLinked
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		const stateSQL = `
+			SELECT s.status, e.status
+			FROM scheduled_workouts AS s
+			JOIN enrollments AS e ON e.id = s.enrollment_id
+			WHERE s.id = $1`
+
+		return scheduledNotUpdated(tx.QueryRow(ctx, stateSQL, scheduledID))
+	}
+
+	return nil
+}
+
+// scheduledNotUpdated tells why a guarded update of a scheduled workout matched no row,
+// from its status and its enrollment's: completed workouts and closed enrollments are final.
+func scheduledNotUpdated(row pgx.Row) error {
+	var status, enrollmentStatus string
+	if err := row.Scan(&status, &enrollmentStatus); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrScheduledNotFound
+		}
+		return err
+	}
+
+	if enrollmentStatus != entity.EnrollmentActive {
+		return ErrEnrollmentClosed
+	}
+	return ErrScheduledCompleted
+}
+
+func (r *programRepository) WorkoutExists(ctx context.Context, accountID, workoutID string) (bool, error) {
+	const sql = `SELECT EXISTS (SELECT 1 FROM workouts WHERE id = $1 AND account_id = $2)`
+
+	var exists bool
+	if err := r.db.QueryRow(ctx, sql, workoutID, accountID).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// GetThresholdPace returns the enrolled user's CSS in seconds per 100m, nil until it is known.
+func (r *programRepository) GetThresholdPace(ctx context.Context, accountID string) (*float64, error) {
+	const sql = `SELECT threshold_pace_sec FROM users WHERE account_id = $1`
+
+	var pace *float64
+	if err := r.db.QueryRow(ctx, sql, accountID).Scan(&pace); err != nil {
+		return nil, err
+	}
+
+	return pace, nil
+}
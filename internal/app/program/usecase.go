@@ -0,0 +1,199 @@
+package program
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/program/dto"
+	"haphap/swimo-api/internal/app/program/entity"
+	"haphap/swimo-api/pkg/response"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrPlanNotOwned       = errors.New("program plans must belong to its author")
+	ErrEnrollmentClosed   = errors.New("enrollment is not active")
+	ErrScheduledCompleted = errors.New("scheduled workout is already completed")
+)
+
+type ProgramUseCase interface {
+	CreateProgram(ctx context.Context, actorID string, req dto.ProgramRequest) (*dto.ProgramResponse, error)
+	UpdateProgram(ctx context.Context, actorID, programID string, req dto.ProgramRequest) (*dto.ProgramResponse, error)
+	GetProgram(ctx context.Context, programID string, includeUnpublished bool) (*dto.ProgramResponse, error)
+	ListPrograms(ctx context.Context, query dto.ListProgramsQuery, includeUnpublished bool) ([]dto.ProgramResponse, int, error)
+	DeleteProgram(ctx context.Context, programID string) error
+
+	Enroll(ctx context.Context, accountID, programID string, req dto.EnrollRequest) (*dto.EnrollmentResponse, error)
+	ListEnrollments(ctx context.Context, accountID string) ([]dto.EnrollmentResponse, error)
+	GetEnrollment(ctx context.Context, accountID, enrollmentID string) (*dto.EnrollmentResponse, error)
+	CancelEnrollment(ctx context.Context, accountID, enrollmentID string) error
+
+	ListSchedule(ctx context.Context, accountID string, query dto.ScheduleQuery) ([]dto.ScheduledWorkoutResponse, error)
+	GetScheduledWorkout(ctx context.Context, accountID, scheduledID string) (*dto.ScheduledWorkoutResponse, error)
+	RescheduleWorkout(ctx context.Context, accountID, scheduledID string, req dto.RescheduleRequest) (*dto.ScheduledWorkoutResponse, error)
+	SkipScheduledWorkout(ctx context.Context, accountID, scheduledID string) (*dto.ScheduledWorkoutResponse, error)
+	CompleteScheduledWorkout(ctx context.Context, accountID, scheduledID string, req dto.CompleteScheduledRequest) (*dto.ScheduledWorkoutResponse, error)
+
+	ExportAccountData(ctx context.Context, accountID string) (map[string]any, error)
+	BeforeAccountPurge(ctx context.Context, tx pgx.Tx, accountIDs []string) error
+}
+
+type programUseCase struct {
+	pool        *pgxpool.Pool
+	programRepo ProgramRepository
+}
+
+func NewProgramUseCase(pool *pgxpool.Pool, programRepo ProgramRepository) ProgramUseCase {
+	return &programUseCase{pool, programRepo}
+}
+
+func (uc *programUseCase) CreateProgram(ctx context.Context, actorID string, req dto.ProgramRequest) (*dto.ProgramResponse, error) {
+	if err := uc.checkPlansOwned(ctx, actorID, nil, req); err != nil {
+		return nil, err
+	}
+
+	program := &entity.Program{AuthorAccountID: &actorID}
+	req.Apply(program)
+
+	err := uc.saveProgram(ctx, program, func(tx pgx.Tx) error {
+		return uc.programRepo.CreateProgram(ctx, tx, program)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("program created", slog.String("account_id", actorID), slog.String("program_id", program.ID))
+	return uc.GetProgram(ctx, program.ID, true)
+}
+
+// UpdateProgram changes the program for future enrollments, existing calendars are kept.
+func (uc *programUseCase) UpdateProgram(ctx context.Context, actorID, programID string, req dto.ProgramRequest) (*dto.ProgramResponse, error) {
+	program, err := uc.programRepo.GetProgram(ctx, programID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// plans stay those of the author, an orphaned program is adopted by whoever edits it
+	// and keeps the plans detached to it
+	owner := actorID
+	if program.AuthorAccountID != nil {
+		owner = *program.AuthorAccountID
+	}
+	if err := uc.checkPlansOwned(ctx, owner, &programID, req); err != nil {
+		return nil, err
+	}
+
+	req.Apply(program)
+	err = uc.saveProgram(ctx, program, func(tx pgx.Tx) error {
+		return uc.programRepo.UpdateProgram(ctx, tx, program)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("program updated", slog.String("account_id", actorID), slog.String("program_id", programID))
+	return uc.GetProgram(ctx, programID, true)
+}
+
+func (uc *programUseCase) GetProgram(ctx context.Context, programID string, includeUnpublished bool) (*dto.ProgramResponse, error) {
+	program, err := uc.programRepo.GetProgram(ctx, programID, !includeUnpublished)
+	if err != nil {
+		return nil, err
+	}
+
+	out := dto.ToProgramResponse(program)
+	return &out, nil
+}
+
+func (uc *programUseCase) ListPrograms(ctx context.Context, query dto.ListProgramsQuery, includeUnpublished bool) ([]dto.ProgramResponse, int, error) {
+	limit, offset := response.NormalizePage(query.Limit, query.Offset)
+
+	programs, total, err := uc.programRepo.ListPrograms(ctx, !includeUnpublished, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]dto.ProgramResponse, 0, len(programs))
+	for i := range programs {
+		out = append(out, dto.ToProgramResponse(&programs[i]))
+	}
+
+	return out, total, nil
+}
+
+func (uc *programUseCase) DeleteProgram(ctx context.Context, programID string) error {
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := uc.programRepo.DeleteProgram(ctx, tx, programID); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("delete program: commit transaction failed", slog.String("program_id", programID), slog.String("err", err.Error()))
+		return err
+	}
+
+	slog.Info("program deleted", slog.String("program_id", programID))
+	return nil
+}
+
+// BeforeAccountPurge keeps the plans of purged authors that programs still schedule.
+func (uc *programUseCase) BeforeAccountPurge(ctx context.Context, tx pgx.Tx, accountIDs []string) error {
+	count, err := uc.programRepo.DetachProgramPlans(ctx, tx, accountIDs)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		slog.Info("program plans detached from purged accounts", slog.Int64("count", count))
+	}
+	return nil
+}
+
+func (uc *programUseCase) checkPlansOwned(ctx context.Context, accountID string, programID *string, req dto.ProgramRequest) error {
+	planIDs := req.PlanIDs()
+
+	count, err := uc.programRepo.CountOwnedPlans(ctx, accountID, programID, planIDs)
+	if err != nil {
+		return err
+	}
+	if count != len(planIDs) {
+		return ErrPlanNotOwned
+	}
+
+	return nil
+}
+
+// saveProgram runs save and replaces the program workouts in one transaction.
+func (uc *programUseCase) saveProgram(ctx context.Context, program *entity.Program, save func(tx pgx.Tx) error) error {
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := save(tx); err != nil {
+		return err
+	}
+
+	if err := uc.programRepo.ReplaceProgramWorkouts(ctx, tx, program.ID, program.Workouts); err != nil {
+		return err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("save program: commit transaction failed", slog.String("program_id", program.ID), slog.String("err", err.Error()))
+		return err
+	}
+
+	return nil
+}
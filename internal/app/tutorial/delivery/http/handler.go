@@ -0,0 +1,156 @@
+package http
+
+import (
+	"errors"
+	"haphap/swimo-api/config"
+	"haphap/swimo-api/internal/app/tutorial"
+	"haphap/swimo-api/internal/app/tutorial/dto"
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/rbac"
+	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/storage"
+	"haphap/swimo-api/pkg/validator"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TutorialHandler struct {
+	tutorialUsecase tutorial.TutorialUseCase
+	cfg             config.TutorialConfig
+}
+
+func NewTutorialHandler(tutorialUsecase tutorial.TutorialUseCase, cfg config.TutorialConfig) *TutorialHandler {
+	return &TutorialHandler{tutorialUsecase, cfg}
+}
+
+func (h *TutorialHandler) ListTutorials(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var query dto.ListTutorialsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid query parameters."})
+	}
+
+	if err := query.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	// content managers also see drafts
+	out, total, err := h.tutorialUsecase.ListTutorials(c.Context(), viewerOf(principal), query, principal.Can(rbac.PermManageContent))
+	if err != nil {
+		return err
+	}
+
+	limit, offset := response.NormalizePage(query.Limit, query.Offset)
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    response.Page{Items: out, Total: total, Limit: limit, Offset: offset},
+		Message: "Tutorials retrieved successfully.",
+	})
+}
+
+func (h *TutorialHandler) GetTutorial(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	tutorialID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(tutorialID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Tutorial not found."})
+	}
+
+	out, err := h.tutorialUsecase.GetTutorial(c.Context(), viewerOf(principal), tutorialID, principal.Can(rbac.PermManageContent))
+	if err != nil {
+		return tutorialError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Tutorial retrieved successfully.",
+	})
+}
+
+func (h *TutorialHandler) CreateTutorial(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var req dto.TutorialRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.tutorialUsecase.CreateTutorial(c.Context(), principal.AccountID, req)
+	if err != nil {
+		return tutorialError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.Base{
+		Data:    out,
+		Message: "Tutorial created successfully.",
+	})
+}
+
+func (h *TutorialHandler) UpdateTutorial(c *fiber.Ctx) error {
+	tutorialID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(tutorialID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Tutorial not found."})
+	}
+
+	var req dto.TutorialRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.tutorialUsecase.UpdateTutorial(c.Context(), tutorialID, req)
+	if err != nil {
+		return tutorialError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Tutorial updated successfully.",
+	})
+}
+
+func (h *TutorialHandler) DeleteTutorial(c *fiber.Ctx) error {
+	tutorialID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(tutorialID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Tutorial not found."})
+	}
+
+	if err := h.tutorialUsecase.DeleteTutorial(c.Context(), tutorialID); err != nil {
+		return tutorialError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Tutorial deleted successfully."})
+}
+
+func tutorialError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, tutorial.ErrTutorialNotFound):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Tutorial not found."})
+	case errors.Is(err, tutorial.ErrMediaNotFound), errors.Is(err, storage.ErrNotFound):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Media not found."})
+	case errors.Is(err, tutorial.ErrFileTooLarge):
+		return c.Status(http.StatusRequestEntityTooLarge).JSON(response.Base{Message: "File is too large."})
+	case errors.Is(err, tutorial.ErrFileEmpty):
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "File is empty."})
+	case errors.Is(err, tutorial.ErrUnsupportedMediaType):
+		return c.Status(http.StatusUnsupportedMediaType).JSON(response.Base{Message: "Unsupported file type."})
+	default:
+		return err
+	}
+}
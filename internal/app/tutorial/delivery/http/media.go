@@ -0,0 +1,187 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"haphap/swimo-api/internal/app/tutorial"
+	"haphap/swimo-api/internal/app/tutorial/entity"
+	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/validator"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UploadMedia takes the file as the raw request body, ex: PUT with Content-Type video/mp4.
+func (h *TutorialHandler) UploadMedia(c *fiber.Ctx) error {
+	tutorialID, kind, ok := mediaParams(c)
+	if !ok {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Media not found."})
+	}
+
+	// bodies above the global limit are streamed, see server.NewServer
+	body := c.Context().RequestBodyStream()
+	if body == nil {
+		body = bytes.NewReader(c.Body())
+	}
+
+	out, err := h.tutorialUsecase.UploadMedia(c.Context(), tutorialID, kind, tutorial.MediaUpload{
+		ContentType: c.Get(fiber.HeaderContentType),
+		Size:        int64(max(c.Request().Header.ContentLength(), -1)),
+		Body:        body,
+	})
+	if err != nil {
+		// the body may be left unread, it must not be parsed as the next request
+		c.Context().SetConnectionClose()
+		return tutorialError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Media uploaded successfully.",
+	})
+}
+
+func (h *TutorialHandler) DeleteMedia(c *fiber.Ctx) error {
+	tutorialID, kind, ok := mediaParams(c)
+	if !ok {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Media not found."})
+	}
+
+	if err := h.tutorialUsecase.DeleteMedia(c.Context(), tutorialID, kind); err != nil {
+		return tutorialError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Media deleted successfully."})
+}
+
+// StreamMedia serves a published file with single range requests so players can seek.
+// The ETag is the content checksum, URLs carry it too so Cache-Control can be generous.
+func (h *TutorialHandler) StreamMedia(c *fiber.Ctx) error {
+	tutorialID, kind, ok := mediaParams(c)
+	if !ok {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Media not found."})
+	}
+
+	media, file, err := h.tutorialUsecase.OpenMedia(c.Context(), tutorialID, kind)
+	if err != nil {
+		return tutorialError(c, err)
+	}
+
+	etag := media.ETag()
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderLastModified, media.UploadedAt.UTC().Format(http.TimeFormat))
+	c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(h.cfg.MediaMaxAge.Seconds())))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+
+	if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+		file.Close()
+		return c.SendStatus(http.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, media.ContentType)
+
+	size := media.SizeBytes
+	rangeHeader := c.Get(fiber.HeaderRange)
+	// a stale If-Range asks for the whole new file instead of a piece of it
+	if ifRange := c.Get(fiber.HeaderIfRange); ifRange != "" && ifRange != etag {
+		rangeHeader = ""
+	}
+
+	start, end, status := parseRange(rangeHeader, size)
+	switch status {
+	case http.StatusRequestedRangeNotSatisfiable:
+		file.Close()
+		c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", size))
+		return c.SendStatus(status)
+	case http.StatusPartialContent:
+		if _, err := file.Seek(start, io.SeekStart); err != nil {
+			file.Close()
+			return err
+		}
+		c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", start, end, size))
+	}
+
+	length := end - start + 1
+	c.Status(status)
+	// fasthttp closes the file once the response is written
+	c.Context().SetBodyStream(&fileSection{io.LimitReader(file, length), file}, int(length))
+	return nil
+}
+
+func mediaParams(c *fiber.Ctx) (tutorialID, kind string, ok bool) {
+	tutorialID, kind = c.Params("id"), c.Params("kind")
+	return tutorialID, kind, validator.UUIDPattern.MatchString(tutorialID) && entity.IsValidMediaKind(kind)
+}
+
+// fileSection reads part of a file and closes the whole of it.
+type fileSection struct {
+	io.Reader
+	io.Closer
+}
+
+// parseRange reads a single "bytes=" range. Multiple ranges are answered with the
+// whole file, which RFC 9110 allows, players only ever ask for one. No range of an
+// empty file can be satisfied.
+func parseRange(header string, size int64) (start, end int64, status int) {
+	full := func() (int64, int64, int) { return 0, size - 1, http.StatusOK }
+
+	spec, ok := strings.CutPrefix(strings.TrimSpace(header), "bytes=")
+	if !ok {
+		return full()
+	}
+	if size == 0 {
+		return 0, 0, http.StatusRequestedRangeNotSatisfiable
+	}
+	if strings.Contains(spec, ",") {
+		return full()
+	}
+
+	first, last, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return full()
+	}
+
+	if first == "" {
+		// suffix range, the last n bytes
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 {
+			return 0, 0, http.StatusRequestedRangeNotSatisfiable
+		}
+		return max(size-n, 0), size - 1, http.StatusPartialContent
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return full()
+	}
+	if start >= size {
+		return 0, 0, http.StatusRequestedRangeNotSatisfiable
+	}
+
+	end = size - 1
+	if last != "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n < start {
+			return full()
+		}
+		end = min(n, size-1)
+	}
+
+	return start, end, http.StatusPartialContent
+}
+
+// etagMatches implements the weak comparison If-None-Match asks for.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		size      int64
+		wantStart int64
+		wantEnd   int64
+		want      int
+	}{
+		{"no range", "", 1000, 0, 999, http.StatusOK},
+		{"open ended", "bytes=100-", 1000, 100, 999, http.StatusPartialContent},
+		{"bounded", "bytes=100-199", 1000, 100, 199, http.StatusPartialContent},
+		{"end past the file", "bytes=900-2000", 1000, 900, 999, http.StatusPartialContent},
+		{"suffix", "bytes=-100", 1000, 900, 999, http.StatusPartialContent},
+		{"suffix longer than the file", "bytes=-5000", 1000, 0, 999, http.StatusPartialContent},
+		{"start past the file", "bytes=1000-", 1000, 0, 0, http.StatusRequestedRangeNotSatisfiable},
+		{"multiple ranges", "bytes=0-1,5-6", 1000, 0, 999, http.StatusOK},
+		{"other unit", "items=0-1", 1000, 0, 999, http.StatusOK},
+		{"empty file", "bytes=0-", 0, 0, 0, http.StatusRequestedRangeNotSatisfiable},
+		{"suffix of an empty file", "bytes=-100", 0, 0, 0, http.StatusRequestedRangeNotSatisfiable},
+		{"empty file without range", "", 0, 0, -1, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, status := parseRange(tt.header, tt.size)
+			if status != tt.want || (status != http.StatusRequestedRangeNotSatisfiable && (start != tt.wantStart || end != tt.wantEnd)) {
+				t.Fatalf("parseRange(%q, %d) = %d-%d %d, want %d-%d %d", tt.header, tt.size, start, end, status, tt.wantStart, tt.wantEnd, tt.want)
+			}
+		})
+	}
+}
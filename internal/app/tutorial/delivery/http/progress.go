@@ -0,0 +1,120 @@
+package http
+
+import (
+	"haphap/swimo-api/internal/app/tutorial/dto"
+	"haphap/swimo-api/internal/app/tutorial/entity"
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/validator"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func (h *TutorialHandler) SaveProgress(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	tutorialID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(tutorialID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Tutorial not found."})
+	}
+
+	var req dto.ProgressRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.tutorialUsecase.SaveProgress(c.Context(), viewerOf(principal), tutorialID, req)
+	if err != nil {
+		return tutorialError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Progress saved successfully.",
+	})
+}
+
+func (h *TutorialHandler) ResetProgress(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	tutorialID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(tutorialID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Tutorial not found."})
+	}
+
+	if err := h.tutorialUsecase.ResetProgress(c.Context(), viewerOf(principal), tutorialID); err != nil {
+		return tutorialError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Progress reset successfully."})
+}
+
+func (h *TutorialHandler) AddFavorite(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	tutorialID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(tutorialID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Tutorial not found."})
+	}
+
+	if err := h.tutorialUsecase.AddFavorite(c.Context(), viewerOf(principal), tutorialID); err != nil {
+		return tutorialError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Tutorial added to favorites."})
+}
+
+func (h *TutorialHandler) RemoveFavorite(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	tutorialID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(tutorialID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Tutorial not found."})
+	}
+
+	if err := h.tutorialUsecase.RemoveFavorite(c.Context(), viewerOf(principal), tutorialID); err != nil {
+		return tutorialError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Tutorial removed from favorites."})
+}
+
+func (h *TutorialHandler) Recommend(c *fiber.Ctx) error {
+	principal := middleware.GetPrincipal(c)
+
+	var query dto.RecommendationsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid query parameters."})
+	}
+
+	if err := query.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.tutorialUsecase.Recommend(c.Context(), viewerOf(principal), query)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Recommendations retrieved successfully.",
+	})
+}
+
+// viewerOf keys progress and favorites by account, or by session for guests.
+func viewerOf(principal *middleware.Principal) entity.Viewer {
+	if principal.IsGuest() {
+		return entity.Viewer{SessionID: principal.SessionID}
+	}
+	return entity.Viewer{AccountID: principal.AccountID}
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/rbac"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func Register(app *fiber.App, tutorialHandler *TutorialHandler, authMw *middleware.AuthMiddleware) {
+	browse := authMw.Require(middleware.GuestAllowed)
+	userOnly := authMw.Require(middleware.UserOnly)
+	manageContent := middleware.RequirePermission(rbac.PermManageContent)
+
+	apiV1 := app.Group("/api/v1")
+	apiV1.Get("/tutorials", browse, tutorialHandler.ListTutorials)
+	apiV1.Get("/tutorials/recommended", browse, tutorialHandler.Recommend)
+	apiV1.Get("/tutorials/:id", browse, tutorialHandler.GetTutorial)
+	apiV1.Post("/tutorials", userOnly, manageContent, tutorialHandler.CreateTutorial)
+	apiV1.Put("/tutorials/:id", userOnly, manageContent, tutorialHandler.UpdateTutorial)
+	apiV1.Delete("/tutorials/:id", userOnly, manageContent, tutorialHandler.DeleteTutorial)
+
+	// guests keep progress and favorites on their session until they register
+	apiV1.Put("/tutorials/:id/progress", browse, tutorialHandler.SaveProgress)
+	apiV1.Delete("/tutorials/:id/progress", browse, tutorialHandler.ResetProgress)
+	apiV1.Put("/tutorials/:id/favorite", browse, tutorialHandler.AddFavorite)
+	apiV1.Delete("/tutorials/:id/favorite", browse, tutorialHandler.RemoveFavorite)
+
+	// players cannot send bearer tokens, published media is public
+	media := app.Group(middleware.MediaPrefix + "tutorials")
+	media.Get("/:id/:kind", tutorialHandler.StreamMedia)
+	media.Put("/:id/:kind", userOnly, manageContent, tutorialHandler.UploadMedia)
+	media.Delete("/:id/:kind", userOnly, manageContent, tutorialHandler.DeleteMedia)
+}
@@ -0,0 +1,136 @@
+package dto
+
+import (
+	"fmt"
+	"haphap/swimo-api/internal/app/tutorial/entity"
+	"haphap/swimo-api/pkg/swim"
+	"haphap/swimo-api/pkg/validator"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRecommendations = 10
+	maxRecommendations     = 50
+)
+
+type (
+	// ProgressRequest is sent by the player while watching, Completed marks the
+	// tutorial as watched even when the end was skipped.
+	ProgressRequest struct {
+		PositionSeconds int  `json:"positionSeconds"`
+		Completed       bool `json:"completed"`
+	}
+
+	RecommendationsQuery struct {
+		SkillLevel string `query:"skillLevel"` // overrides the profile, guests have none
+		Limit      int    `query:"limit"`
+	}
+
+	ProgressResponse struct {
+		PositionSeconds int        `json:"positionSeconds"`
+		Completed       bool       `json:"completed"`
+		CompletedAt     *time.Time `json:"completedAt"`
+		UpdatedAt       time.Time  `json:"updatedAt"`
+	}
+
+	RecommendationResponse struct {
+		TutorialResponse
+		Reason string `json:"reason"`
+	}
+
+	ProgressExport struct {
+		TutorialID      string     `json:"tutorialId"`
+		Title           string     `json:"title"`
+		PositionSeconds int        `json:"positionSeconds"`
+		CompletedAt     *time.Time `json:"completedAt"`
+		UpdatedAt       time.Time  `json:"updatedAt"`
+	}
+
+	FavoriteExport struct {
+		TutorialID string    `json:"tutorialId"`
+		Title      string    `json:"title"`
+		CreatedAt  time.Time `json:"createdAt"`
+	}
+)
+
+func (r *ProgressRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if r.PositionSeconds < 0 || r.PositionSeconds > maxDurationSeconds {
+		errors["positionSeconds"] = fmt.Sprintf("Position must be between 0 and %d seconds", maxDurationSeconds)
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (q *RecommendationsQuery) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if q.SkillLevel != "" && !swim.IsValidSkillLevel(q.SkillLevel) {
+		errors["skillLevel"] = "Skill level must be one of " + strings.Join(swim.SkillLevels(), ", ")
+	}
+	if q.Limit < 0 || q.Limit > maxRecommendations {
+		errors["limit"] = fmt.Sprintf("Limit must be between 1 and %d", maxRecommendations)
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (q *RecommendationsQuery) NormalizedLimit() int {
+	if q.Limit == 0 {
+		return defaultRecommendations
+	}
+	return q.Limit
+}
+
+// ApplyViewerState adds what the caller did with the tutorial.
+func (r *TutorialResponse) ApplyViewerState(state entity.ViewerState) {
+	r.Progress = ToProgressResponse(state.Progress)
+	r.Favorite = state.Favorite
+}
+
+func ToProgressResponse(progress *entity.Progress) *ProgressResponse {
+	if progress == nil {
+		return nil
+	}
+
+	return &ProgressResponse{
+		PositionSeconds: progress.PositionSec,
+		Completed:       progress.CompletedAt != nil,
+		CompletedAt:     progress.CompletedAt,
+		UpdatedAt:       progress.UpdatedAt,
+	}
+}
+
+func ToRecommendationResponse(rec *entity.Recommendation, state entity.ViewerState) RecommendationResponse {
+	out := RecommendationResponse{TutorialResponse: ToTutorialResponse(&rec.Tutorial), Reason: rec.Reason}
+	out.ApplyViewerState(state)
+	return out
+}
+
+func ToProgressExport(progress *entity.Progress) ProgressExport {
+	return ProgressExport{
+		TutorialID:      progress.TutorialID,
+		Title:           progress.TutorialTitle,
+		PositionSeconds: progress.PositionSec,
+		CompletedAt:     progress.CompletedAt,
+		UpdatedAt:       progress.UpdatedAt,
+	}
+}
+
+func ToFavoriteExport(favorite *entity.Favorite) FavoriteExport {
+	return FavoriteExport{
+		TutorialID: favorite.TutorialID,
+		Title:      favorite.TutorialTitle,
+		CreatedAt:  favorite.CreatedAt,
+	}
+}
@@ -0,0 +1,191 @@
+package dto
+
+import (
+	"fmt"
+	"haphap/swimo-api/internal/app/tutorial/entity"
+	"haphap/swimo-api/pkg/swim"
+	"haphap/swimo-api/pkg/validator"
+	"strings"
+	"time"
+)
+
+const (
+	maxTitleLength       = 120
+	maxDescriptionLength = 4000
+	maxDrillLength       = 80
+	maxDurationSeconds   = 4 * 60 * 60
+
+	// mediaURL matches the media routes, the version changes with every upload
+	// so players and CDNs may cache a URL for as long as they like
+	mediaURL = "/api/v1/media/tutorials/%s/%s?v=%s"
+)
+
+type (
+	TutorialRequest struct {
+		Title           string  `json:"title"`
+		Description     *string `json:"description"`
+		Stroke          *string `json:"stroke"`
+		Drill           *string `json:"drill"`
+		SkillLevel      string  `json:"skillLevel"`
+		DurationSeconds *int    `json:"durationSeconds"`
+		Published       bool    `json:"published"`
+	}
+
+	ListTutorialsQuery struct {
+		Stroke     string `query:"stroke"`
+		Drill      string `query:"drill"`
+		SkillLevel string `query:"skillLevel"`
+		Search     string `query:"q"`
+		Shelf      string `query:"shelf"` // in_progress, completed or favorites of the caller
+		Limit      int    `query:"limit"`
+		Offset     int    `query:"offset"`
+	}
+
+	TutorialResponse struct {
+		ID              string            `json:"id"`
+		Title           string            `json:"title"`
+		Description     *string           `json:"description"`
+		Stroke          *string           `json:"stroke"`
+		Drill           *string           `json:"drill"`
+		SkillLevel      string            `json:"skillLevel"`
+		DurationSeconds *int              `json:"durationSeconds"`
+		Published       bool              `json:"published"`
+		Video           *MediaResponse    `json:"video"`
+		Thumbnail       *MediaResponse    `json:"thumbnail"`
+		Progress        *ProgressResponse `json:"progress"`
+		Favorite        bool              `json:"favorite"`
+		CreatedAt       time.Time         `json:"createdAt"`
+		UpdatedAt       time.Time         `json:"updatedAt"`
+	}
+
+	MediaResponse struct {
+		URL         string    `json:"url"`
+		ContentType string    `json:"contentType"`
+		SizeBytes   int64     `json:"sizeBytes"`
+		Checksum    string    `json:"checksum"`
+		UploadedAt  time.Time `json:"uploadedAt"`
+	}
+)
+
+func (r *TutorialRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	title := strings.TrimSpace(r.Title)
+	if title == "" {
+		errors["title"] = "Title is required"
+	} else if len(title) > maxTitleLength {
+		errors["title"] = fmt.Sprintf("Title cannot be longer than %d characters", maxTitleLength)
+	}
+
+	if r.Description != nil && len(*r.Description) > maxDescriptionLength {
+		errors["description"] = fmt.Sprintf("Description cannot be longer than %d characters", maxDescriptionLength)
+	}
+
+	if r.Stroke != nil && *r.Stroke != "" && !swim.IsValidStroke(*r.Stroke) {
+		errors["stroke"] = "Stroke must be one of " + strings.Join(swim.Strokes(), ", ")
+	}
+
+	if r.Drill != nil && len(strings.TrimSpace(*r.Drill)) > maxDrillLength {
+		errors["drill"] = fmt.Sprintf("Drill cannot be longer than %d characters", maxDrillLength)
+	}
+
+	if !swim.IsValidSkillLevel(r.SkillLevel) {
+		errors["skillLevel"] = "Skill level must be one of " + strings.Join(swim.SkillLevels(), ", ")
+	}
+
+	if r.DurationSeconds != nil && (*r.DurationSeconds < 1 || *r.DurationSeconds > maxDurationSeconds) {
+		errors["durationSeconds"] = fmt.Sprintf("Duration must be between 1 and %d seconds", maxDurationSeconds)
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+// Apply copies a validated request into tutorial, empty strings clear optional fields.
+func (r *TutorialRequest) Apply(tutorial *entity.Tutorial) {
+	tutorial.Title = strings.TrimSpace(r.Title)
+	tutorial.Description = trimmed(r.Description)
+	tutorial.Stroke = trimmed(r.Stroke)
+	tutorial.Drill = trimmed(r.Drill)
+	tutorial.SkillLevel = r.SkillLevel
+	tutorial.DurationSec = r.DurationSeconds
+	tutorial.IsPublished = r.Published
+}
+
+func (q *ListTutorialsQuery) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if q.Stroke != "" && !swim.IsValidStroke(q.Stroke) {
+		errors["stroke"] = "Stroke must be one of " + strings.Join(swim.Strokes(), ", ")
+	}
+	if q.SkillLevel != "" && !swim.IsValidSkillLevel(q.SkillLevel) {
+		errors["skillLevel"] = "Skill level must be one of " + strings.Join(swim.SkillLevels(), ", ")
+	}
+	if q.Shelf != "" && !entity.IsValidShelf(q.Shelf) {
+		errors["shelf"] = "Shelf must be in_progress, completed or favorites"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+func (q *ListTutorialsQuery) Filter(viewer entity.Viewer, publishedOnly bool, limit, offset int) entity.TutorialFilter {
+	return entity.TutorialFilter{
+		Stroke:        q.Stroke,
+		Drill:         strings.TrimSpace(q.Drill),
+		SkillLevel:    q.SkillLevel,
+		Search:        strings.TrimSpace(q.Search),
+		PublishedOnly: publishedOnly,
+		Shelf:         q.Shelf,
+		ViewerID:      viewer.OwnerID(),
+		Limit:         limit,
+		Offset:        offset,
+	}
+}
+
+func ToTutorialResponse(tutorial *entity.Tutorial) TutorialResponse {
+	return TutorialResponse{
+		ID:              tutorial.ID,
+		Title:           tutorial.Title,
+		Description:     tutorial.Description,
+		Stroke:          tutorial.Stroke,
+		Drill:           tutorial.Drill,
+		SkillLevel:      tutorial.SkillLevel,
+		DurationSeconds: tutorial.DurationSec,
+		Published:       tutorial.IsPublished,
+		Video:           ToMediaResponse(tutorial.ID, tutorial.Video),
+		Thumbnail:       ToMediaResponse(tutorial.ID, tutorial.Thumbnail),
+		CreatedAt:       tutorial.CreatedAt,
+		UpdatedAt:       tutorial.UpdatedAt,
+	}
+}
+
+func ToMediaResponse(tutorialID string, media *entity.Media) *MediaResponse {
+	if media == nil {
+		return nil
+	}
+
+	return &MediaResponse{
+		URL:         fmt.Sprintf(mediaURL, tutorialID, media.Kind, media.Checksum[:min(12, len(media.Checksum))]),
+		ContentType: media.ContentType,
+		SizeBytes:   media.SizeBytes,
+		Checksum:    media.Checksum,
+		UploadedAt:  media.UploadedAt,
+	}
+}
+
+func trimmed(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	if v := strings.TrimSpace(*s); v != "" {
+		return &v
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package entity
+
+import "time"
+
+const (
+	MediaVideo     = "video"
+	MediaThumbnail = "thumbnail"
+)
+
+// Shelves narrow a listing to what the viewer did with the tutorials.
+const (
+	ShelfInProgress = "in_progress"
+	ShelfCompleted  = "completed"
+	ShelfFavorites  = "favorites"
+)
+
+type (
+	Tutorial struct {
+		ID              string
+		AuthorAccountID *string
+		Title           string
+		Description     *string
+		Stroke          *string // swim.Stroke*, nil for general technique
+		Drill           *string
+		SkillLevel      string
+		DurationSec     *int
+		IsPublished     bool
+		Video           *Media
+		Thumbnail       *Media
+		CreatedAt       time.Time
+		UpdatedAt       time.Time
+	}
+
+	// Media is an uploaded file of a tutorial, Checksum doubles as its ETag.
+	Media struct {
+		Kind        string
+		StorageKey  string
+		ContentType string
+		SizeBytes   int64
+		Checksum    string
+		UploadedAt  time.Time
+	}
+
+	TutorialFilter struct {
+		Stroke        string
+		Drill         string
+		SkillLevel    string
+		Search        string // matched against title and drill
+		PublishedOnly bool
+		Shelf         string // Shelf*, needs ViewerID
+		ViewerID      string // Viewer.OwnerID
+		Limit         int
+		Offset        int
+	}
+)
+
+func IsValidShelf(shelf string) bool {
+	return shelf == ShelfInProgress || shelf == ShelfCompleted || shelf == ShelfFavorites
+}
+
+func IsValidMediaKind(kind string) bool {
+	return kind == MediaVideo || kind == MediaThumbnail
+}
+
+// ETag is the strong validator of the file, quoted as HTTP wants it.
+func (m *Media) ETag() string {
+	return `"` + m.Checksum + `"`
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"cmp"
+	"haphap/swimo-api/pkg/swim"
+	"slices"
+	"time"
+)
+
+// completeRatio marks a tutorial as watched once this much of it was seen, credits
+// and outros are rarely watched to the last second.
+const completeRatio = 0.95
+
+type (
+	// Viewer owns progress and favorites: the account of a user, the session of a guest.
+	Viewer struct {
+		AccountID string
+		SessionID string
+	}
+
+	Progress struct {
+		TutorialID    string
+		TutorialTitle string
+		PositionSec   int
+		CompletedAt   *time.Time
+		UpdatedAt     time.Time
+	}
+
+	Favorite struct {
+		TutorialID    string
+		TutorialTitle string
+		CreatedAt     time.Time
+	}
+
+	// ViewerState is what a viewer did with one tutorial.
+	ViewerState struct {
+		Progress *Progress
+		Favorite bool
+	}
+
+	// Recommendation is a tutorial with why it was picked.
+	Recommendation struct {
+		Tutorial Tutorial
+		Score    float64
+		Reason   string
+	}
+)
+
+const (
+	ReasonContinue   = "continue_watching"
+	ReasonFavorite   = "favorite"
+	ReasonStroke     = "stroke_you_swim"
+	ReasonSkillLevel = "skill_level"
+	ReasonNextLevel  = "next_level"
+	ReasonRefresher  = "refresher"
+)
+
+// OwnerID is the key progress and favorites are stored under.
+func (v Viewer) OwnerID() string {
+	if v.AccountID != "" {
+		return v.AccountID
+	}
+	return v.SessionID
+}
+
+func (v Viewer) IsGuest() bool { return v.AccountID == "" }
+
+// ReachesEnd reports whether position counts as having watched the tutorial.
+func (t *Tutorial) ReachesEnd(positionSec int) bool {
+	return t.DurationSec != nil && float64(positionSec) >= float64(*t.DurationSec)*completeRatio
+}
+
+// Recommend ranks unfinished tutorials for a swimmer at skillLevel. strokeShare maps
+// strokes to their share of the recent distance swum, 0..1. Tutorials matching the level
+// lead, the next level follows and the previous one is offered as a refresher; started
+// tutorials and favorites get a boost so the list picks up where the viewer left off.
+func Recommend(candidates []Tutorial, states map[string]ViewerState, skillLevel string, strokeShare map[string]float64, limit int) []Recommendation {
+	levels := swim.SkillLevels()
+	level := slices.Index(levels, skillLevel)
+
+	out := make([]Recommendation, 0, len(candidates))
+	for _, tutorial := range candidates {
+		state := states[tutorial.ID]
+		if state.Progress != nil && state.Progress.CompletedAt != nil {
+			continue
+		}
+
+		rec := Recommendation{Tutorial: tutorial}
+		switch slices.Index(levels, tutorial.SkillLevel) - level {
+		case 0:
+			rec.Score, rec.Reason = 3, ReasonSkillLevel
+		case 1:
+			rec.Score, rec.Reason = 1.5, ReasonNextLevel
+		case -1:
+			rec.Score, rec.Reason = 1, ReasonRefresher
+		default:
+			continue
+		}
+
+		// general technique tutorials help whatever is swum
+		share := 0.25
+		if tutorial.Stroke != nil {
+			share = strokeShare[*tutorial.Stroke]
+		}
+		rec.Score += 3 * share
+		if share >= 0.25 && tutorial.Stroke != nil {
+			rec.Reason = ReasonStroke
+		}
+
+		if state.Favorite {
+			rec.Score += 1
+			rec.Reason = ReasonFavorite
+		}
+		if state.Progress != nil && state.Progress.PositionSec > 0 {
+			rec.Score += 2
+			rec.Reason = ReasonContinue
+		}
+
+		out = append(out, rec)
+	}
+
+	slices.SortStableFunc(out, func(a, b Recommendation) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Tutorial.Title, b.Tutorial.Title)
+	})
+
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
@@ -0,0 +1,168 @@
+package tutorial
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"haphap/swimo-api/internal/app/tutorial/dto"
+	"haphap/swimo-api/internal/app/tutorial/entity"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+)
+
+var (
+	ErrFileTooLarge         = errors.New("file is too large")
+	ErrFileEmpty            = errors.New("file is empty")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
+
+// sniffLen is what http.DetectContentType looks at.
+const sniffLen = 512
+
+// MediaUpload is a raw file body, Size is -1 when the client did not send a length.
+type MediaUpload struct {
+	ContentType string
+	Size        int64
+	Body        io.Reader
+}
+
+// UploadMedia stores the file under a new key, then points the tutorial to it. The
+// previous file is only removed afterwards so players never lose the one they stream.
+func (uc *tutorialUseCase) UploadMedia(ctx context.Context, tutorialID, kind string, upload MediaUpload) (*dto.MediaResponse, error) {
+	maxBytes, types := uc.mediaLimits(kind)
+	if upload.Size > maxBytes {
+		return nil, ErrFileTooLarge
+	}
+
+	// do not store files for a tutorial that does not exist
+	if _, err := uc.tutorialRepo.GetTutorial(ctx, tutorialID, false); err != nil {
+		return nil, err
+	}
+
+	body := bufio.NewReaderSize(upload.Body, sniffLen)
+	head, err := body.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if len(head) == 0 {
+		return nil, ErrFileEmpty
+	}
+
+	// trust the bytes, the declared type only has to agree with them
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if !slices.Contains(types, contentType) {
+		return nil, ErrUnsupportedMediaType
+	}
+	if declared, _, err := mime.ParseMediaType(upload.ContentType); err == nil &&
+		declared != "application/octet-stream" && declared != contentType {
+		return nil, ErrUnsupportedMediaType
+	}
+
+	key, err := newMediaKey(tutorialID, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := uc.store.Put(ctx, key, &limitedReader{r: body, n: maxBytes})
+	if err != nil {
+		return nil, err
+	}
+
+	media := &entity.Media{
+		Kind:        kind,
+		StorageKey:  key,
+		ContentType: contentType,
+		SizeBytes:   obj.Size,
+		Checksum:    obj.Checksum,
+	}
+	previousKey, err := uc.tutorialRepo.SaveMedia(ctx, tutorialID, media)
+	if err != nil {
+		uc.removeFile(ctx, key)
+		return nil, err
+	}
+	if previousKey != nil {
+		uc.removeFile(ctx, *previousKey)
+	}
+
+	slog.Info("tutorial media uploaded",
+		slog.String("tutorial_id", tutorialID),
+		slog.String("kind", kind),
+		slog.String("content_type", contentType),
+		slog.Int64("size", obj.Size),
+	)
+	return dto.ToMediaResponse(tutorialID, media), nil
+}
+
+func (uc *tutorialUseCase) DeleteMedia(ctx context.Context, tutorialID, kind string) error {
+	key, err := uc.tutorialRepo.DeleteMedia(ctx, tutorialID, kind)
+	if err != nil {
+		return err
+	}
+
+	uc.removeFile(ctx, key)
+
+	slog.Info("tutorial media deleted", slog.String("tutorial_id", tutorialID), slog.String("kind", kind))
+	return nil
+}
+
+// OpenMedia returns a published tutorial's file, the caller closes it.
+func (uc *tutorialUseCase) OpenMedia(ctx context.Context, tutorialID, kind string) (*entity.Media, io.ReadSeekCloser, error) {
+	media, err := uc.tutorialRepo.GetMedia(ctx, tutorialID, kind, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, _, err := uc.store.Open(ctx, media.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open %s: %w", media.StorageKey, err)
+	}
+
+	return media, file, nil
+}
+
+// mediaLimits returns the configured size limit and accepted MIME types of kind.
+func (uc *tutorialUseCase) mediaLimits(kind string) (int64, []string) {
+	if kind == entity.MediaThumbnail {
+		return uc.cfg.ThumbnailMaxBytes, uc.cfg.ThumbnailTypes
+	}
+	return uc.cfg.VideoMaxBytes, uc.cfg.VideoTypes
+}
+
+// newMediaKey names every upload differently so a replaced file can be removed safely.
+func newMediaKey(tutorialID, kind string) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("tutorials/%s/%s-%s", tutorialID, kind, hex.EncodeToString(suffix)), nil
+}
+
+// limitedReader fails with ErrFileTooLarge once more than n bytes were read,
+// chunked uploads have no length to check up front.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, ErrFileTooLarge
+	}
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, ErrFileTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,199 @@
+package tutorial
+
+import (
+	"context"
+	"haphap/swimo-api/internal/app/tutorial/dto"
+	"haphap/swimo-api/internal/app/tutorial/entity"
+	"haphap/swimo-api/pkg/swim"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// recommendCandidates bounds how many tutorials are ranked per request
+	recommendCandidates = 200
+	// strokeWindow is how far back logged workouts count towards stroke interest
+	strokeWindow = 90 * 24 * time.Hour
+)
+
+func (uc *tutorialUseCase) SaveProgress(ctx context.Context, viewer entity.Viewer, tutorialID string, req dto.ProgressRequest) (*dto.ProgressResponse, error) {
+	tutorial, err := uc.tutorialRepo.GetTutorial(ctx, tutorialID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &entity.Progress{TutorialID: tutorialID, PositionSec: req.PositionSeconds}
+	if tutorial.DurationSec != nil {
+		progress.PositionSec = min(progress.PositionSec, *tutorial.DurationSec)
+	}
+	if req.Completed || tutorial.ReachesEnd(progress.PositionSec) {
+		now := time.Now()
+		progress.CompletedAt = &now
+	}
+
+	if err := uc.tutorialRepo.SaveProgress(ctx, viewer, progress); err != nil {
+		return nil, err
+	}
+
+	return dto.ToProgressResponse(progress), nil
+}
+
+// ResetProgress forgets the position and completion, ex: to watch a tutorial as new.
+func (uc *tutorialUseCase) ResetProgress(ctx context.Context, viewer entity.Viewer, tutorialID string) error {
+	return uc.tutorialRepo.DeleteProgress(ctx, viewer.OwnerID(), tutorialID)
+}
+
+func (uc *tutorialUseCase) AddFavorite(ctx context.Context, viewer entity.Viewer, tutorialID string) error {
+	if _, err := uc.tutorialRepo.GetTutorial(ctx, tutorialID, true); err != nil {
+		return err
+	}
+
+	return uc.tutorialRepo.AddFavorite(ctx, viewer, tutorialID)
+}
+
+func (uc *tutorialUseCase) RemoveFavorite(ctx context.Context, viewer entity.Viewer, tutorialID string) error {
+	return uc.tutorialRepo.RemoveFavorite(ctx, viewer.OwnerID(), tutorialID)
+}
+
+// Recommend builds the "next up" list from the skill level and the strokes of recent workouts.
+func (uc *tutorialUseCase) Recommend(ctx context.Context, viewer entity.Viewer, query dto.RecommendationsQuery) ([]dto.RecommendationResponse, error) {
+	skillLevel, err := uc.skillLevel(ctx, viewer, query.SkillLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	// the level itself plus its neighbours, Recommend ranks them
+	levels := swim.SkillLevels()
+	i := slices.Index(levels, skillLevel)
+	nearby := levels[max(i-1, 0):min(i+2, len(levels))]
+
+	candidates, err := uc.tutorialRepo.ListRecommendable(ctx, viewer.OwnerID(), nearby, recommendCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	strokeShare, err := uc.strokeShare(ctx, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(candidates))
+	for i := range candidates {
+		ids[i] = candidates[i].ID
+	}
+	states, err := uc.tutorialRepo.GetViewerStates(ctx, viewer.OwnerID(), ids)
+	if err != nil {
+		return nil, err
+	}
+
+	recs := entity.Recommend(candidates, states, skillLevel, strokeShare, query.NormalizedLimit())
+
+	out := make([]dto.RecommendationResponse, 0, len(recs))
+	for i := range recs {
+		out = append(out, dto.ToRecommendationResponse(&recs[i], states[recs[i].Tutorial.ID]))
+	}
+
+	return out, nil
+}
+
+// MigrateGuestData keeps what a guest watched and liked when the session registers.
+func (uc *tutorialUseCase) MigrateGuestData(ctx context.Context, tx pgx.Tx, sessionID, accountID string) error {
+	return uc.tutorialRepo.MoveGuestData(ctx, tx, sessionID, accountID)
+}
+
+// ExportAccountData contributes watch progress and favorites to the account export.
+func (uc *tutorialUseCase) ExportAccountData(ctx context.Context, accountID string) (map[string]any, error) {
+	progress, err := uc.tutorialRepo.ListProgress(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	favorites, err := uc.tutorialRepo.ListFavorites(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	progressOut := make([]dto.ProgressExport, 0, len(progress))
+	for i := range progress {
+		progressOut = append(progressOut, dto.ToProgressExport(&progress[i]))
+	}
+	favoritesOut := make([]dto.FavoriteExport, 0, len(favorites))
+	for i := range favorites {
+		favoritesOut = append(favoritesOut, dto.ToFavoriteExport(&favorites[i]))
+	}
+
+	return map[string]any{
+		"tutorialProgress":  progressOut,
+		"tutorialFavorites": favoritesOut,
+	}, nil
+}
+
+// applyViewerStates adds the viewer's progress and favorites to tutorials.
+func (uc *tutorialUseCase) applyViewerStates(ctx context.Context, viewer entity.Viewer, tutorials []dto.TutorialResponse) error {
+	if viewer.OwnerID() == "" || len(tutorials) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(tutorials))
+	for i := range tutorials {
+		ids[i] = tutorials[i].ID
+	}
+
+	states, err := uc.tutorialRepo.GetViewerStates(ctx, viewer.OwnerID(), ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range tutorials {
+		tutorials[i].ApplyViewerState(states[tutorials[i].ID])
+	}
+	return nil
+}
+
+// skillLevel picks the requested level, then the profile one, beginners otherwise.
+func (uc *tutorialUseCase) skillLevel(ctx context.Context, viewer entity.Viewer, requested string) (string, error) {
+	if requested != "" {
+		return requested, nil
+	}
+	if viewer.IsGuest() {
+		return swim.SkillBeginner, nil
+	}
+
+	level, err := uc.tutorialRepo.GetSkillLevel(ctx, viewer.AccountID)
+	if err != nil {
+		return "", err
+	}
+	if level == nil {
+		return swim.SkillBeginner, nil
+	}
+	return *level, nil
+}
+
+// strokeShare returns each stroke's share of the distance logged lately, guests log none.
+func (uc *tutorialUseCase) strokeShare(ctx context.Context, viewer entity.Viewer) (map[string]float64, error) {
+	if viewer.IsGuest() {
+		return nil, nil
+	}
+
+	distances, err := uc.tutorialRepo.StrokeDistances(ctx, viewer.AccountID, time.Now().Add(-strokeWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0.0
+	for _, meters := range distances {
+		total += meters
+	}
+	if total == 0 {
+		slog.Debug("tutorial recommendations without workouts", slog.String("account_id", viewer.AccountID))
+		return nil, nil
+	}
+
+	share := make(map[string]float64, len(distances))
+	for stroke, meters := range distances {
+		share[stroke] = meters / total
+	}
+	return share, nil
+}
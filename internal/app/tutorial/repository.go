@@ -0,0 +1,602 @@
+package tutorial
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/tutorial/entity"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrTutorialNotFound = errors.New("tutorial not found")
+	ErrMediaNotFound    = errors.New("tutorial media not found")
+)
+
+type TutorialRepository interface {
+	CreateTutorial(ctx context.Context, tutorial *entity.Tutorial) error
+	UpdateTutorial(ctx context.Context, tutorial *entity.Tutorial) error
+	GetTutorial(ctx context.Context, tutorialID string, publishedOnly bool) (*entity.Tutorial, error)
+	ListTutorials(ctx context.Context, filter entity.TutorialFilter) ([]entity.Tutorial, int, error)
+	DeleteTutorial(ctx context.Context, tutorialID string) ([]string, error)
+
+	ListRecommendable(ctx context.Context, ownerID string, skillLevels []string, limit int) ([]entity.Tutorial, error)
+	GetSkillLevel(ctx context.Context, accountID string) (*string, error)
+	StrokeDistances(ctx context.Context, accountID string, since time.Time) (map[string]float64, error)
+
+	GetMedia(ctx context.Context, tutorialID, kind string, publishedOnly bool) (*entity.Media, error)
+	SaveMedia(ctx context.Context, tutorialID string, media *entity.Media) (*string, error)
+	DeleteMedia(ctx context.Context, tutorialID, kind string) (string, error)
+
+	GetViewerStates(ctx context.Context, ownerID string, tutorialIDs []string) (map[string]entity.ViewerState, error)
+	SaveProgress(ctx context.Context, viewer entity.Viewer, progress *entity.Progress) error
+	DeleteProgress(ctx context.Context, ownerID, tutorialID string) error
+	AddFavorite(ctx context.Context, viewer entity.Viewer, tutorialID string) error
+	RemoveFavorite(ctx context.Context, ownerID, tutorialID string) error
+	ListProgress(ctx context.Context, ownerID string) ([]entity.Progress, error)
+	ListFavorites(ctx context.Context, ownerID string) ([]entity.Favorite, error)
+	MoveGuestData(ctx context.Context, tx pgx.Tx, sessionID, accountID string) error
+}
+
+type tutorialRepository struct{ db *pgxpool.Pool }
+
+func NewTutorialRepository(db *pgxpool.Pool) TutorialRepository { return &tutorialRepository{db: db} }
+
+func (r *tutorialRepository) CreateTutorial(ctx context.Context, tutorial *entity.Tutorial) error {
+	const sql = `
+		INSERT INTO tutorials (author_account_id, title, description, stroke, drill, skill_level, duration_sec, is_published)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at`
+
+	return r.db.QueryRow(ctx, sql,
+		tutorial.AuthorAccountID,
+		tutorial.Title,
+		tutorial.Description,
+		tutorial.Stroke,
+		tutorial.Drill,
+		tutorial.SkillLevel,
+		tutorial.DurationSec,
+		tutorial.IsPublished,
+	).Scan(&tutorial.ID, &tutorial.CreatedAt, &tutorial.UpdatedAt)
+}
+
+func (r *tutorialRepository) UpdateTutorial(ctx context.Context, tutorial *entity.Tutorial) error {
+	const sql = `
+		UPDATE tutorials
+		SET title = $2, description = $3, stroke = $4, drill = $5, skill_level = $6,
+			duration_sec = $7, is_published = $8, updated_at = now()
+		WHERE id = $1
+		RETURNING author_account_id, created_at, updated_at`
+
+	if err := r.db.QueryRow(ctx, sql,
+		tutorial.ID,
+		tutorial.Title,
+		tutorial.Description,
+		tutorial.Stroke,
+		tutorial.Drill,
+		tutorial.SkillLevel,
+		tutorial.DurationSec,
+		tutorial.IsPublished,
+	).Scan(&tutorial.AuthorAccountID, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrTutorialNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *tutorialRepository) GetTutorial(ctx context.Context, tutorialID string, publishedOnly bool) (*entity.Tutorial, error) {
+	const sql = `
+		SELECT id, author_account_id, title, description, stroke, drill, skill_level, duration_sec,
+			is_published, created_at, updated_at
+		FROM tutorials
+		WHERE id = $1 AND (is_published OR NOT $2)`
+
+	var tutorial entity.Tutorial
+	if err := r.db.QueryRow(ctx, sql, tutorialID, publishedOnly).Scan(
+		&tutorial.ID,
+		&tutorial.AuthorAccountID,
+		&tutorial.Title,
+		&tutorial.Description,
+		&tutorial.Stroke,
+		&tutorial.Drill,
+		&tutorial.SkillLevel,
+		&tutorial.DurationSec,
+		&tutorial.IsPublished,
+		&tutorial.CreatedAt,
+		&tutorial.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTutorialNotFound
+		}
+		return nil, err
+	}
+
+	tutorials := []entity.Tutorial{tutorial}
+	if err := r.attachMedia(ctx, tutorials); err != nil {
+		return nil, err
+	}
+
+	return &tutorials[0], nil
+}
+
+// ListTutorials returns a page of tutorials matching filter, beginner material first.
+// Shelves list what the viewer touched last first.
+func (r *tutorialRepository) ListTutorials(ctx context.Context, filter entity.TutorialFilter) ([]entity.Tutorial, int, error) {
+	const sql = `
+		SELECT
+			t.id, t.author_account_id, t.title, t.description, t.stroke, t.drill, t.skill_level, t.duration_sec,
+			t.is_published, t.created_at, t.updated_at,
+			COUNT(*) OVER ()
+		FROM tutorials AS t
+		LEFT JOIN tutorial_progress AS p ON p.tutorial_id = t.id AND p.owner_id = $9
+		LEFT JOIN tutorial_favorites AS f ON f.tutorial_id = t.id AND f.owner_id = $9
+		WHERE (t.is_published OR NOT $1)
+			AND ($2 = '' OR t.stroke = $2)
+			AND ($3 = '' OR t.drill ILIKE $3)
+			AND ($4 = '' OR t.skill_level = $4)
+			AND ($5 = '' OR t.title ILIKE '%' || $5 || '%' OR t.drill ILIKE '%' || $5 || '%')
+			AND CASE $8
+				WHEN 'in_progress' THEN p.completed_at IS NULL AND p.position_sec > 0
+				WHEN 'completed' THEN p.completed_at IS NOT NULL
+				WHEN 'favorites' THEN f.tutorial_id IS NOT NULL
+				ELSE true
+			END
+		ORDER BY
+			CASE WHEN $8 <> '' THEN COALESCE(p.updated_at, f.created_at) END DESC NULLS LAST,
+			array_position(ARRAY['beginner', 'intermediate', 'advanced'], t.skill_level), t.title
+		LIMIT $6 OFFSET $7`
+
+	rows, err := r.db.Query(ctx, sql,
+		filter.PublishedOnly,
+		filter.Stroke,
+		filter.Drill,
+		filter.SkillLevel,
+		filter.Search,
+		filter.Limit,
+		filter.Offset,
+		filter.Shelf,
+		nullIfEmpty(filter.ViewerID),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	total := 0
+	tutorials := make([]entity.Tutorial, 0)
+	for rows.Next() {
+		var tutorial entity.Tutorial
+		if err := rows.Scan(
+			&tutorial.ID,
+			&tutorial.AuthorAccountID,
+			&tutorial.Title,
+			&tutorial.Description,
+			&tutorial.Stroke,
+			&tutorial.Drill,
+			&tutorial.SkillLevel,
+			&tutorial.DurationSec,
+			&tutorial.IsPublished,
+			&tutorial.CreatedAt,
+			&tutorial.UpdatedAt,
+			&total,
+		); err != nil {
+			return nil, 0, err
+		}
+		tutorials = append(tutorials, tutorial)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.attachMedia(ctx, tutorials); err != nil {
+		return nil, 0, err
+	}
+
+	return tutorials, total, nil
+}
+
+// ListRecommendable returns published tutorials of skillLevels the viewer has not finished.
+func (r *tutorialRepository) ListRecommendable(ctx context.Context, ownerID string, skillLevels []string, limit int) ([]entity.Tutorial, error) {
+	const sql = `
+		SELECT t.id, t.author_account_id, t.title, t.description, t.stroke, t.drill, t.skill_level, t.duration_sec,
+			t.is_published, t.created_at, t.updated_at
+		FROM tutorials AS t
+		WHERE t.is_published AND t.skill_level = ANY($2)
+			AND NOT EXISTS (
+				SELECT 1 FROM tutorial_progress AS p
+				WHERE p.owner_id = $1 AND p.tutorial_id = t.id AND p.completed_at IS NOT NULL
+			)
+		ORDER BY t.created_at DESC
+		LIMIT $3`
+
+	rows, err := r.db.Query(ctx, sql, ownerID, skillLevels, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tutorials := make([]entity.Tutorial, 0)
+	for rows.Next() {
+		var tutorial entity.Tutorial
+		if err := rows.Scan(
+			&tutorial.ID,
+			&tutorial.AuthorAccountID,
+			&tutorial.Title,
+			&tutorial.Description,
+			&tutorial.Stroke,
+			&tutorial.Drill,
+			&tutorial.SkillLevel,
+			&tutorial.DurationSec,
+			&tutorial.IsPublished,
+			&tutorial.CreatedAt,
+			&tutorial.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		tutorials = append(tutorials, tutorial)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachMedia(ctx, tutorials); err != nil {
+		return nil, err
+	}
+
+	return tutorials, nil
+}
+
+func (r *tutorialRepository) GetSkillLevel(ctx context.Context, accountID string) (*string, error) {
+	const sql = `SELECT skill_level FROM users WHERE account_id = $1`
+
+	var level *string
+	if err := r.db.QueryRow(ctx, sql, accountID).Scan(&level); err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	return level, nil
+}
+
+// StrokeDistances sums the meters logged per stroke since a date.
+func (r *tutorialRepository) StrokeDistances(ctx context.Context, accountID string, since time.Time) (map[string]float64, error) {
+	const sql = `
+		SELECT s.stroke, SUM(s.repetitions * s.distance_m)::float8
+		FROM workout_sets AS s
+		JOIN workouts AS w ON w.id = s.workout_id
+		WHERE w.account_id = $1 AND w.swum_on >= $2
+		GROUP BY s.stroke`
+
+	rows, err := r.db.Query(ctx, sql, accountID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	distances := make(map[string]float64)
+	for rows.Next() {
+		var (
+			stroke string
+			meters float64
+		)
+		if err := rows.Scan(&stroke, &meters); err != nil {
+			return nil, err
+		}
+		distances[stroke] = meters
+	}
+
+	return distances, rows.Err()
+}
+
+// DeleteTutorial removes the tutorial and returns the storage keys of its media.
+func (r *tutorialRepository) DeleteTutorial(ctx context.Context, tutorialID string) ([]string, error) {
+	const sql = `
+		WITH media AS (
+			SELECT storage_key FROM tutorial_media WHERE tutorial_id = $1
+		), deleted AS (
+			DELETE FROM tutorials WHERE id = $1 RETURNING id
+		)
+		SELECT (SELECT COUNT(*) FROM deleted), COALESCE((SELECT array_agg(storage_key) FROM media), '{}')`
+
+	var (
+		count int
+		keys  []string
+	)
+	if err := r.db.QueryRow(ctx, sql, tutorialID).Scan(&count, &keys); err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, ErrTutorialNotFound
+	}
+
+	return keys, nil
+}
+
+func (r *tutorialRepository) GetMedia(ctx context.Context, tutorialID, kind string, publishedOnly bool) (*entity.Media, error) {
+	const sql = `
+		SELECT m.kind, m.storage_key, m.content_type, m.size_bytes, m.checksum, m.uploaded_at
+		FROM tutorial_media AS m
+		JOIN tutorials AS t ON t.id = m.tutorial_id
+		WHERE m.tutorial_id = $1 AND m.kind = $2 AND (t.is_published OR NOT $3)`
+
+	var media entity.Media
+	if err := r.db.QueryRow(ctx, sql, tutorialID, kind, publishedOnly).Scan(
+		&media.Kind,
+		&media.StorageKey,
+		&media.ContentType,
+		&media.SizeBytes,
+		&media.Checksum,
+		&media.UploadedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrMediaNotFound
+		}
+		return nil, err
+	}
+
+	return &media, nil
+}
+
+// SaveMedia records an upload and returns the storage key it replaced, if any.
+func (r *tutorialRepository) SaveMedia(ctx context.Context, tutorialID string, media *entity.Media) (*string, error) {
+	const sql = `
+		WITH previous AS (
+			SELECT storage_key FROM tutorial_media WHERE tutorial_id = $1 AND kind = $2
+		), saved AS (
+			INSERT INTO tutorial_media (tutorial_id, kind, storage_key, content_type, size_bytes, checksum)
+			SELECT id, $2, $3, $4, $5, $6 FROM tutorials WHERE id = $1
+			ON CONFLICT (tutorial_id, kind) DO UPDATE
+			SET storage_key = EXCLUDED.storage_key, content_type = EXCLUDED.content_type,
+				size_bytes = EXCLUDED.size_bytes, checksum = EXCLUDED.checksum, uploaded_at = now()
+			RETURNING uploaded_at
+		)
+		SELECT (SELECT uploaded_at FROM saved), (SELECT storage_key FROM previous)`
+
+	var (
+		uploadedAt  *time.Time
+		previousKey *string
+	)
+	if err := r.db.QueryRow(ctx, sql,
+		tutorialID,
+		media.Kind,
+		media.StorageKey,
+		media.ContentType,
+		media.SizeBytes,
+		media.Checksum,
+	).Scan(&uploadedAt, &previousKey); err != nil {
+		return nil, err
+	}
+	if uploadedAt == nil {
+		return nil, ErrTutorialNotFound
+	}
+
+	media.UploadedAt = *uploadedAt
+	return previousKey, nil
+}
+
+// DeleteMedia removes the record and returns its storage key.
+func (r *tutorialRepository) DeleteMedia(ctx context.Context, tutorialID, kind string) (string, error) {
+	const sql = `DELETE FROM tutorial_media WHERE tutorial_id = $1 AND kind = $2 RETURNING storage_key`
+
+	var key string
+	if err := r.db.QueryRow(ctx, sql, tutorialID, kind).Scan(&key); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrMediaNotFound
+		}
+		return "", err
+	}
+
+	return key, nil
+}
+
+// attachMedia loads the media of tutorials in one query.
+func (r *tutorialRepository) attachMedia(ctx context.Context, tutorials []entity.Tutorial) error {
+	if len(tutorials) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(tutorials))
+	index := make(map[string]*entity.Tutorial, len(tutorials))
+	for i := range tutorials {
+		ids[i] = tutorials[i].ID
+		index[tutorials[i].ID] = &tutorials[i]
+	}
+
+	const sql = `
+		SELECT tutorial_id, kind, storage_key, content_type, size_bytes, checksum, uploaded_at
+		FROM tutorial_media
+		WHERE tutorial_id = ANY($1::uuid[])`
+
+	rows, err := r.db.Query(ctx, sql, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			tutorialID string
+			media      entity.Media
+		)
+		if err := rows.Scan(
+			&tutorialID,
+			&media.Kind,
+			&media.StorageKey,
+			&media.ContentType,
+			&media.SizeBytes,
+			&media.Checksum,
+			&media.UploadedAt,
+		); err != nil {
+			return err
+		}
+
+		tutorial := index[tutorialID]
+		switch media.Kind {
+		case entity.MediaVideo:
+			tutorial.Video = &media
+		case entity.MediaThumbnail:
+			tutorial.Thumbnail = &media
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *tutorialRepository) GetViewerStates(ctx context.Context, ownerID string, tutorialIDs []string) (map[string]entity.ViewerState, error) {
+	const sql = `
+		SELECT t.id, p.position_sec, p.completed_at, p.updated_at, f.tutorial_id IS NOT NULL
+		FROM unnest($2::uuid[]) AS t(id)
+		LEFT JOIN tutorial_progress AS p ON p.tutorial_id = t.id AND p.owner_id = $1
+		LEFT JOIN tutorial_favorites AS f ON f.tutorial_id = t.id AND f.owner_id = $1
+		WHERE p.tutorial_id IS NOT NULL OR f.tutorial_id IS NOT NULL`
+
+	rows, err := r.db.Query(ctx, sql, ownerID, tutorialIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	states := make(map[string]entity.ViewerState)
+	for rows.Next() {
+		var (
+			tutorialID  string
+			positionSec *int
+			completedAt *time.Time
+			updatedAt   *time.Time
+			state       entity.ViewerState
+		)
+		if err := rows.Scan(&tutorialID, &positionSec, &completedAt, &updatedAt, &state.Favorite); err != nil {
+			return nil, err
+		}
+		if positionSec != nil {
+			state.Progress = &entity.Progress{
+				TutorialID:  tutorialID,
+				PositionSec: *positionSec,
+				CompletedAt: completedAt,
+				UpdatedAt:   *updatedAt,
+			}
+		}
+		states[tutorialID] = state
+	}
+
+	return states, rows.Err()
+}
+
+// SaveProgress records the position, completion is sticky so rewatching keeps it.
+func (r *tutorialRepository) SaveProgress(ctx context.Context, viewer entity.Viewer, progress *entity.Progress) error {
+	const sql = `
+		INSERT INTO tutorial_progress (account_id, session_id, tutorial_id, position_sec, completed_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (owner_id, tutorial_id) DO UPDATE
+		SET position_sec = EXCLUDED.position_sec,
+			completed_at = COALESCE(tutorial_progress.completed_at, EXCLUDED.completed_at),
+			updated_at = now()
+		RETURNING completed_at, updated_at`
+
+	return r.db.QueryRow(ctx, sql,
+		nullIfEmpty(viewer.AccountID),
+		nullIfEmpty(viewer.SessionID),
+		progress.TutorialID,
+		progress.PositionSec,
+		progress.CompletedAt,
+	).Scan(&progress.CompletedAt, &progress.UpdatedAt)
+}
+
+func (r *tutorialRepository) DeleteProgress(ctx context.Context, ownerID, tutorialID string) error {
+	const sql = `DELETE FROM tutorial_progress WHERE owner_id = $1 AND tutorial_id = $2`
+
+	_, err := r.db.Exec(ctx, sql, ownerID, tutorialID)
+	return err
+}
+
+func (r *tutorialRepository) AddFavorite(ctx context.Context, viewer entity.Viewer, tutorialID string) error {
+	const sql = `
+		INSERT INTO tutorial_favorites (account_id, session_id, tutorial_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (owner_id, tutorial_id) DO NOTHING`
+
+	_, err := r.db.Exec(ctx, sql, nullIfEmpty(viewer.AccountID), nullIfEmpty(viewer.SessionID), tutorialID)
+	return err
+}
+
+func (r *tutorialRepository) RemoveFavorite(ctx context.Context, ownerID, tutorialID string) error {
+	const sql = `DELETE FROM tutorial_favorites WHERE owner_id = $1 AND tutorial_id = $2`
+
+	_, err := r.db.Exec(ctx, sql, ownerID, tutorialID)
+	return err
+}
+
+func (r *tutorialRepository) ListProgress(ctx context.Context, ownerID string) ([]entity.Progress, error) {
+	const sql = `
+		SELECT p.tutorial_id, t.title, p.position_sec, p.completed_at, p.updated_at
+		FROM tutorial_progress AS p
+		JOIN tutorials AS t ON t.id = p.tutorial_id
+		WHERE p.owner_id = $1
+		ORDER BY p.updated_at DESC`
+
+	rows, err := r.db.Query(ctx, sql, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	progress := make([]entity.Progress, 0)
+	for rows.Next() {
+		var p entity.Progress
+		if err := rows.Scan(&p.TutorialID, &p.TutorialTitle, &p.PositionSec, &p.CompletedAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		progress = append(progress, p)
+	}
+
+	return progress, rows.Err()
+}
+
+func (r *tutorialRepository) ListFavorites(ctx context.Context, ownerID string) ([]entity.Favorite, error) {
+	const sql = `
+		SELECT f.tutorial_id, t.title, f.created_at
+		FROM tutorial_favorites AS f
+		JOIN tutorials AS t ON t.id = f.tutorial_id
+		WHERE f.owner_id = $1
+		ORDER BY f.created_at DESC`
+
+	rows, err := r.db.Query(ctx, sql, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	favorites := make([]entity.Favorite, 0)
+	for rows.Next() {
+		var f entity.Favorite
+		if err := rows.Scan(&f.TutorialID, &f.TutorialTitle, &f.CreatedAt); err != nil {
+			return nil, err
+		}
+		favorites = append(favorites, f)
+	}
+
+	return favorites, rows.Err()
+}
+
+// MoveGuestData hands progress and favorites of a guest session to the account it became.
+func (r *tutorialRepository) MoveGuestData(ctx context.Context, tx pgx.Tx, sessionID, accountID string) error {
+	const progressSQL = `UPDATE tutorial_progress SET account_id = $2, session_id = NULL WHERE session_id = $1`
+	if _, err := tx.Exec(ctx, progressSQL, sessionID, accountID); err != nil {
+		return err
+	}
+
+	const favoritesSQL = `UPDATE tutorial_favorites SET account_id = $2, session_id = NULL WHERE session_id = $1`
+	_, err := tx.Exec(ctx, favoritesSQL, sessionID, accountID)
+	return err
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,128 @@
+package tutorial
+
+import (
+	"context"
+	"haphap/swimo-api/config"
+	"haphap/swimo-api/internal/app/tutorial/dto"
+	"haphap/swimo-api/internal/app/tutorial/entity"
+	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/storage"
+	"io"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type TutorialUseCase interface {
+	CreateTutorial(ctx context.Context, actorID string, req dto.TutorialRequest) (*dto.TutorialResponse, error)
+	UpdateTutorial(ctx context.Context, tutorialID string, req dto.TutorialRequest) (*dto.TutorialResponse, error)
+	GetTutorial(ctx context.Context, viewer entity.Viewer, tutorialID string, includeUnpublished bool) (*dto.TutorialResponse, error)
+	ListTutorials(ctx context.Context, viewer entity.Viewer, query dto.ListTutorialsQuery, includeUnpublished bool) ([]dto.TutorialResponse, int, error)
+	DeleteTutorial(ctx context.Context, tutorialID string) error
+
+	SaveProgress(ctx context.Context, viewer entity.Viewer, tutorialID string, req dto.ProgressRequest) (*dto.ProgressResponse, error)
+	ResetProgress(ctx context.Context, viewer entity.Viewer, tutorialID string) error
+	AddFavorite(ctx context.Context, viewer entity.Viewer, tutorialID string) error
+	RemoveFavorite(ctx context.Context, viewer entity.Viewer, tutorialID string) error
+	Recommend(ctx context.Context, viewer entity.Viewer, query dto.RecommendationsQuery) ([]dto.RecommendationResponse, error)
+
+	MigrateGuestData(ctx context.Context, tx pgx.Tx, sessionID, accountID string) error
+	ExportAccountData(ctx context.Context, accountID string) (map[string]any, error)
+
+	UploadMedia(ctx context.Context, tutorialID, kind string, upload MediaUpload) (*dto.MediaResponse, error)
+	DeleteMedia(ctx context.Context, tutorialID, kind string) error
+	OpenMedia(ctx context.Context, tutorialID, kind string) (*entity.Media, io.ReadSeekCloser, error)
+}
+
+type tutorialUseCase struct {
+	cfg          config.TutorialConfig
+	tutorialRepo TutorialRepository
+	store        storage.Storage
+}
+
+func NewTutorialUseCase(cfg config.TutorialConfig, tutorialRepo TutorialRepository, store storage.Storage) TutorialUseCase {
+	return &tutorialUseCase{cfg, tutorialRepo, store}
+}
+
+func (uc *tutorialUseCase) CreateTutorial(ctx context.Context, actorID string, req dto.TutorialRequest) (*dto.TutorialResponse, error) {
+	tutorial := &entity.Tutorial{AuthorAccountID: &actorID}
+	req.Apply(tutorial)
+
+	if err := uc.tutorialRepo.CreateTutorial(ctx, tutorial); err != nil {
+		return nil, err
+	}
+
+	slog.Info("tutorial created", slog.String("account_id", actorID), slog.String("tutorial_id", tutorial.ID))
+	out := dto.ToTutorialResponse(tutorial)
+	return &out, nil
+}
+
+func (uc *tutorialUseCase) UpdateTutorial(ctx context.Context, tutorialID string, req dto.TutorialRequest) (*dto.TutorialResponse, error) {
+	tutorial, err := uc.tutorialRepo.GetTutorial(ctx, tutorialID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Apply(tutorial)
+	if err := uc.tutorialRepo.UpdateTutorial(ctx, tutorial); err != nil {
+		return nil, err
+	}
+
+	slog.Info("tutorial updated", slog.String("tutorial_id", tutorialID))
+	out := dto.ToTutorialResponse(tutorial)
+	return &out, nil
+}
+
+func (uc *tutorialUseCase) GetTutorial(ctx context.Context, viewer entity.Viewer, tutorialID string, includeUnpublished bool) (*dto.TutorialResponse, error) {
+	tutorial, err := uc.tutorialRepo.GetTutorial(ctx, tutorialID, !includeUnpublished)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []dto.TutorialResponse{dto.ToTutorialResponse(tutorial)}
+	if err := uc.applyViewerStates(ctx, viewer, out); err != nil {
+		return nil, err
+	}
+
+	return &out[0], nil
+}
+
+func (uc *tutorialUseCase) ListTutorials(ctx context.Context, viewer entity.Viewer, query dto.ListTutorialsQuery, includeUnpublished bool) ([]dto.TutorialResponse, int, error) {
+	limit, offset := response.NormalizePage(query.Limit, query.Offset)
+
+	tutorials, total, err := uc.tutorialRepo.ListTutorials(ctx, query.Filter(viewer, !includeUnpublished, limit, offset))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]dto.TutorialResponse, 0, len(tutorials))
+	for i := range tutorials {
+		out = append(out, dto.ToTutorialResponse(&tutorials[i]))
+	}
+	if err := uc.applyViewerStates(ctx, viewer, out); err != nil {
+		return nil, 0, err
+	}
+
+	return out, total, nil
+}
+
+func (uc *tutorialUseCase) DeleteTutorial(ctx context.Context, tutorialID string) error {
+	keys, err := uc.tutorialRepo.DeleteTutorial(ctx, tutorialID)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		uc.removeFile(ctx, key)
+	}
+
+	slog.Info("tutorial deleted", slog.String("tutorial_id", tutorialID))
+	return nil
+}
+
+// removeFile deletes a file no row points to anymore, a failure only leaves an orphan behind.
+func (uc *tutorialUseCase) removeFile(ctx context.Context, key string) {
+	if err := uc.store.Delete(ctx, key); err != nil {
+		slog.Warn("tutorial media delete failed", slog.String("key", key), slog.String("err", err.Error()))
+	}
+}
@@ -0,0 +1,213 @@
+package http
+
+import (
+	"errors"
+	"haphap/swimo-api/internal/app/workout"
+	"haphap/swimo-api/internal/app/workout/dto"
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/validator"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WorkoutHandler struct {
+	workoutUsecase workout.WorkoutUseCase
+}
+
+func NewWorkoutHandler(workoutUsecase workout.WorkoutUseCase) *WorkoutHandler {
+	return &WorkoutHandler{workoutUsecase}
+}
+
+func (h *WorkoutHandler) CreateWorkout(c *fiber.Ctx) error {
+	accountID := ownerID(c)
+
+	var req dto.WorkoutRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.workoutUsecase.CreateWorkout(c.Context(), accountID, req)
+	if err != nil {
+		return workoutError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(response.Base{
+		Data:    out,
+		Message: "Workout created successfully.",
+	})
+}
+
+func (h *WorkoutHandler) ListWorkouts(c *fiber.Ctx) error {
+	accountID := ownerID(c)
+
+	var query dto.ListWorkoutsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid query parameters."})
+	}
+
+	if err := query.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, total, err := h.workoutUsecase.ListWorkouts(c.Context(), accountID, query)
+	if err != nil {
+		return workoutError(c, err)
+	}
+
+	filter := query.Filter()
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    response.Page{Items: out, Total: total, Limit: filter.Limit, Offset: filter.Offset},
+		Message: "Workouts retrieved successfully.",
+	})
+}
+
+func (h *WorkoutHandler) GetWorkout(c *fiber.Ctx) error {
+	accountID := ownerID(c)
+
+	workoutID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(workoutID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Workout not found."})
+	}
+
+	out, err := h.workoutUsecase.GetWorkout(c.Context(), accountID, workoutID)
+	if err != nil {
+		return workoutError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Workout retrieved successfully.",
+	})
+}
+
+func (h *WorkoutHandler) UpdateWorkout(c *fiber.Ctx) error {
+	accountID := ownerID(c)
+
+	workoutID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(workoutID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Workout not found."})
+	}
+
+	var req dto.WorkoutRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid JSON body."})
+	}
+
+	// validate required fields
+	if err := req.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.workoutUsecase.UpdateWorkout(c.Context(), accountID, workoutID, req)
+	if err != nil {
+		return workoutError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Workout updated successfully.",
+	})
+}
+
+func (h *WorkoutHandler) DeleteWorkout(c *fiber.Ctx) error {
+	accountID := ownerID(c)
+
+	workoutID := c.Params("id")
+	if !validator.UUIDPattern.MatchString(workoutID) {
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Workout not found."})
+	}
+
+	if err := h.workoutUsecase.DeleteWorkout(c.Context(), accountID, workoutID); err != nil {
+		return workoutError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{Message: "Workout deleted successfully."})
+}
+
+func (h *WorkoutHandler) WeeklyTotals(c *fiber.Ctx) error {
+	accountID := ownerID(c)
+
+	var query dto.WeeklyTotalsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid query parameters."})
+	}
+
+	if err := query.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.workoutUsecase.WeeklyTotals(c.Context(), accountID, query)
+	if err != nil {
+		return workoutError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Weekly totals retrieved successfully.",
+	})
+}
+
+func (h *WorkoutHandler) Metrics(c *fiber.Ctx) error {
+	accountID := ownerID(c)
+
+	var query dto.MetricsQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(response.Base{Message: "Invalid query parameters."})
+	}
+
+	if err := query.Validate(); err != nil {
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: err},
+		)
+	}
+
+	out, err := h.workoutUsecase.Metrics(c.Context(), accountID, query)
+	if err != nil {
+		return workoutError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(response.Base{
+		Data:    out,
+		Message: "Swim metrics retrieved successfully.",
+	})
+}
+
+// ownerID is the account the route acts on: the swimmer of /swimmers/:accountId routes,
+// which RequireActOn guards, otherwise the caller.
+func ownerID(c *fiber.Ctx) string {
+	if accountID := c.Params("accountId"); accountID != "" {
+		return accountID
+	}
+	return middleware.GetPrincipal(c).AccountID
+}
+
+func workoutError(c *fiber.Ctx, err error) error {
+	var validationErr *validator.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return c.Status(http.StatusUnprocessableEntity).JSON(
+			response.ValidationError{Message: "Validation Error", Errors: validationErr},
+		)
+	case errors.Is(err, workout.ErrWorkoutNotFound):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Workout not found."})
+	case errors.Is(err, workout.ErrSwimmerNotFound):
+		return c.Status(http.StatusNotFound).JSON(response.Base{Message: "Swimmer not found."})
+	default:
+		return err
+	}
+}
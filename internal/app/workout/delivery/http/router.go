@@ -0,0 +1,35 @@
+package http
+
+import (
+	"haphap/swimo-api/internal/middleware"
+	"haphap/swimo-api/pkg/rbac"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func Register(app *fiber.App, workoutHandler *WorkoutHandler, authMw *middleware.AuthMiddleware, coachLinks middleware.CoachLinks) {
+	workouts := app.Group("/api/v1/workouts",
+		authMw.Require(middleware.UserOnly),
+		middleware.RequirePermission(rbac.PermManageOwnData),
+	)
+	workouts.Get("", workoutHandler.ListWorkouts)
+	workouts.Post("", workoutHandler.CreateWorkout)
+	workouts.Get("/weekly", workoutHandler.WeeklyTotals)
+	workouts.Get("/metrics", workoutHandler.Metrics)
+	workouts.Get("/:id", workoutHandler.GetWorkout)
+	workouts.Put("/:id", workoutHandler.UpdateWorkout)
+	workouts.Delete("/:id", workoutHandler.DeleteWorkout)
+
+	// coaches read and log the workouts of their linked swimmers, admins of everyone
+	viewSwimmer := middleware.RequireActOn(coachLinks, "accountId", rbac.PermViewSwimmers)
+	manageSwimmer := middleware.RequireActOn(coachLinks, "accountId", rbac.PermManageSwimmers)
+
+	swimmerWorkouts := app.Group("/api/v1/swimmers/:accountId/workouts", authMw.Require(middleware.UserOnly))
+	swimmerWorkouts.Get("", viewSwimmer, workoutHandler.ListWorkouts)
+	swimmerWorkouts.Post("", manageSwimmer, workoutHandler.CreateWorkout)
+	swimmerWorkouts.Get("/weekly", viewSwimmer, workoutHandler.WeeklyTotals)
+	swimmerWorkouts.Get("/metrics", viewSwimmer, workoutHandler.Metrics)
+	swimmerWorkouts.Get("/:id", viewSwimmer, workoutHandler.GetWorkout)
+	swimmerWorkouts.Put("/:id", manageSwimmer, workoutHandler.UpdateWorkout)
+	swimmerWorkouts.Delete("/:id", manageSwimmer, workoutHandler.DeleteWorkout)
+}
@@ -0,0 +1,205 @@
+package dto
+
+import (
+	"fmt"
+	"haphap/swimo-api/internal/app/workout/entity"
+	"haphap/swimo-api/pkg/dates"
+	"haphap/swimo-api/pkg/units"
+	"haphap/swimo-api/pkg/validator"
+	"time"
+)
+
+const (
+	defaultMetricsDays = 12 * 7
+	maxMetricsDays     = 366
+)
+
+type (
+	// MetricsQuery selects the workouts to aggregate, it defaults to the last 12 weeks.
+	MetricsQuery struct {
+		From string `query:"from"` // YYYY-MM-DD, inclusive
+		To   string `query:"to"`   // YYYY-MM-DD, inclusive
+	}
+
+	// MetricsResponse shows distances in the user's unit and paces in seconds per 100 of it.
+	// Stroke metrics are null without stroke counts, paces without times.
+	MetricsResponse struct {
+		Lengths           int            `json:"lengths"`
+		SWOLF             *float64       `json:"swolf"` // length time + strokes
+		StrokesPerLength  *float64       `json:"strokesPerLength"`
+		DistancePerStroke *float64       `json:"distancePerStroke"`
+		StrokeRate        *float64       `json:"strokeRate"` // strokes per minute
+		AvgPace           *float64       `json:"avgPace"`
+		BestPace          *float64       `json:"bestPace"` // fastest repetition
+		Split             *SplitResponse `json:"split,omitempty"`
+		Consistency       *float64       `json:"consistency,omitempty"` // percent, 100 when every length takes the same time
+	}
+
+	SplitResponse struct {
+		Kind              string  `json:"kind"` // negative, even or positive
+		FirstHalfSeconds  float64 `json:"firstHalfSeconds"`
+		SecondHalfSeconds float64 `json:"secondHalfSeconds"`
+		DifferenceSeconds float64 `json:"differenceSeconds"` // second half - first half
+	}
+
+	// MetricsSummaryResponse aggregates the timed sets of a date range, Strokes are
+	// grouped by pool length too since SWOLF and strokes per length depend on it.
+	MetricsSummaryResponse struct {
+		From         string                  `json:"from"`
+		To           string                  `json:"to"`
+		DistanceUnit string                  `json:"distanceUnit"`
+		Workouts     int                     `json:"workouts"`
+		Total        MetricsResponse         `json:"total"`
+		Strokes      []StrokeMetricsResponse `json:"strokes"`
+	}
+
+	// StrokeMetricsResponse averages consistency over the sets, Splits counts them by kind.
+	StrokeMetricsResponse struct {
+		Stroke     string `json:"stroke"`
+		PoolLength string `json:"poolLength"`
+		Workouts   int    `json:"workouts"`
+		Sets       int    `json:"sets"`
+		MetricsResponse
+		Splits SplitCountsResponse `json:"splits"`
+	}
+
+	SplitCountsResponse struct {
+		Negative int `json:"negative"`
+		Even     int `json:"even"`
+		Positive int `json:"positive"`
+	}
+)
+
+func (q *MetricsQuery) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	from, fromErr := dates.Parse(q.From)
+	if q.From != "" && fromErr != nil {
+		errors["from"] = "From must be formatted as YYYY-MM-DD"
+	}
+
+	to, toErr := dates.Parse(q.To)
+	if q.To != "" && toErr != nil {
+		errors["to"] = "To must be formatted as YYYY-MM-DD"
+	}
+
+	if q.From != "" && q.To != "" && fromErr == nil && toErr == nil {
+		if from.After(to) {
+			errors["to"] = "To cannot be before from"
+		} else if to.Sub(from) >= maxMetricsDays*24*time.Hour {
+			errors["to"] = fmt.Sprintf("At most %d days can be requested", maxMetricsDays)
+		}
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+// Range returns the first and the last requested day, keeping a single bound within maxMetricsDays.
+func (q *MetricsQuery) Range(now time.Time) (from, to time.Time) {
+	to, _ = dates.Parse(now.UTC().Format(dates.Layout))
+	if t, err := dates.Parse(q.To); err == nil {
+		to = t
+	}
+
+	from = to.AddDate(0, 0, -(defaultMetricsDays - 1))
+	if f, err := dates.Parse(q.From); err == nil {
+		from = f
+		if last := from.AddDate(0, 0, maxMetricsDays-1); q.To == "" && last.Before(to) {
+			to = last
+		}
+		if to.Before(from) {
+			to = from
+		}
+	}
+
+	return from, to
+}
+
+// ToMetricsResponse returns nil when nothing was timed nor counted.
+func ToMetricsResponse(metrics *entity.Metrics, pref units.Preference) *MetricsResponse {
+	if metrics.Lengths == 0 && metrics.AvgPaceSec == nil {
+		return nil
+	}
+
+	out := &MetricsResponse{
+		Lengths:          metrics.Lengths,
+		SWOLF:            roundPtr(metrics.SWOLF, 1),
+		StrokesPerLength: roundPtr(metrics.StrokesPerLength, 1),
+		StrokeRate:       roundPtr(metrics.StrokeRate, 1),
+		Consistency:      roundPtr(metrics.Consistency, 1),
+	}
+
+	if metrics.DistancePerStrokeM != nil {
+		// converted by the hundred so that it keeps two decimals
+		dps := units.Round(pref.DistanceFromMeters(*metrics.DistancePerStrokeM*100)/100, 2)
+		out.DistancePerStroke = &dps
+	}
+	if metrics.AvgPaceSec != nil {
+		avg := pref.PaceFromSecPer100m(*metrics.AvgPaceSec)
+		out.AvgPace = &avg
+	}
+	if metrics.BestPaceSec != nil {
+		best := pref.PaceFromSecPer100m(*metrics.BestPaceSec)
+		out.BestPace = &best
+	}
+
+	if split := metrics.Split; split != nil {
+		out.Split = &SplitResponse{
+			Kind:              split.Kind(),
+			FirstHalfSeconds:  units.Round(split.FirstHalfSec, 1),
+			SecondHalfSeconds: units.Round(split.SecondHalfSec, 1),
+			DifferenceSeconds: units.Round(split.SecondHalfSec-split.FirstHalfSec, 1),
+		}
+	}
+
+	return out
+}
+
+func ToMetricsSummaryResponse(from, to time.Time, workouts []entity.Workout, pref units.Preference) MetricsSummaryResponse {
+	out := MetricsSummaryResponse{
+		From:         from.Format(dates.Layout),
+		To:           to.Format(dates.Layout),
+		DistanceUnit: pref.Distance,
+		Workouts:     len(workouts),
+		Strokes:      make([]StrokeMetricsResponse, 0),
+	}
+
+	total := entity.SummarizeMetrics(workouts)
+	if metrics := ToMetricsResponse(&total, pref); metrics != nil {
+		out.Total = *metrics
+	}
+
+	for _, group := range entity.GroupMetrics(workouts) {
+		group.Metrics.Consistency = group.Consistency
+
+		strokeOut := StrokeMetricsResponse{
+			Stroke:     group.Stroke,
+			PoolLength: group.PoolLength,
+			Workouts:   group.Workouts,
+			Sets:       group.Sets,
+			Splits: SplitCountsResponse{
+				Negative: group.Splits[entity.SplitNegative],
+				Even:     group.Splits[entity.SplitEven],
+				Positive: group.Splits[entity.SplitPositive],
+			},
+		}
+		if metrics := ToMetricsResponse(&group.Metrics, pref); metrics != nil {
+			strokeOut.MetricsResponse = *metrics
+		}
+		out.Strokes = append(out.Strokes, strokeOut)
+	}
+
+	return out
+}
+
+func roundPtr(v *float64, places int) *float64 {
+	if v == nil {
+		return nil
+	}
+	out := units.Round(*v, places)
+	return &out
+}
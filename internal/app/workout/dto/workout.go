@@ -0,0 +1,460 @@
+package dto
+
+import (
+	"fmt"
+	"haphap/swimo-api/internal/app/workout/entity"
+	"haphap/swimo-api/pkg/dates"
+	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/swim"
+	"haphap/swimo-api/pkg/units"
+	"haphap/swimo-api/pkg/validator"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	maxDurationSec = 24 * 60 * 60
+	maxDistanceM   = 100_000 // per set repetition and per workout, well within numeric(8,2)
+	maxSetTimeSec  = maxDurationSec
+	maxRestSec     = 60 * 60
+	maxSets        = 100
+	maxRepetitions = 100
+	maxLaps        = 400
+	maxLapSec      = 60 * 60
+	maxLapStrokes  = 200
+	maxNotesLength = 2000
+
+	defaultWeeks = 12
+	maxWeeks     = 53
+
+	// lapDistanceSlack absorbs the rounding of distances converted between units
+	lapDistanceSlack = 0.01
+)
+
+type (
+	// WorkoutRequest takes distances in the user's distance unit. TotalDistance
+	// defaults to the sum of the sets, it is required when no sets are logged.
+	WorkoutRequest struct {
+		Date            string       `json:"date"` // YYYY-MM-DD
+		PoolLength      string       `json:"poolLength"`
+		TotalDistance   *float64     `json:"totalDistance"`
+		DurationSeconds int          `json:"durationSeconds"`
+		Notes           *string      `json:"notes"`
+		Sets            []SetRequest `json:"sets"`
+	}
+
+	SetRequest struct {
+		Stroke      string       `json:"stroke"`
+		Repetitions int          `json:"repetitions"`
+		Distance    float64      `json:"distance"` // per repetition
+		RestSeconds int          `json:"restSeconds"`
+		TimeSeconds *float64     `json:"timeSeconds"` // per repetition, defaults to the laps
+		Laps        []LapRequest `json:"laps"`        // every pool length of every repetition
+	}
+
+	LapRequest struct {
+		TimeSeconds float64 `json:"timeSeconds"`
+		Strokes     *int    `json:"strokes"`
+	}
+
+	ListWorkoutsQuery struct {
+		From   string `query:"from"` // YYYY-MM-DD, inclusive
+		To     string `query:"to"`   // YYYY-MM-DD, inclusive
+		Limit  int    `query:"limit"`
+		Offset int    `query:"offset"`
+	}
+
+	// WeeklyTotalsQuery selects ISO weeks, from and to may be any day of them.
+	// It defaults to the last 12 weeks.
+	WeeklyTotalsQuery struct {
+		From string `query:"from"` // YYYY-MM-DD
+		To   string `query:"to"`   // YYYY-MM-DD
+	}
+
+	// WorkoutResponse carries calorie estimates, they are null until the profile has a weight.
+	// Metrics are null until a set is timed.
+	WorkoutResponse struct {
+		ID              string           `json:"id"`
+		Date            string           `json:"date"`
+		PoolLength      string           `json:"poolLength"`
+		TotalDistance   float64          `json:"totalDistance"`
+		DistanceUnit    string           `json:"distanceUnit"`
+		DurationSeconds int              `json:"durationSeconds"`
+		Calories        *float64         `json:"calories"` // kcal
+		Metrics         *MetricsResponse `json:"metrics"`
+		Notes           *string          `json:"notes"`
+		Sets            []SetResponse    `json:"sets"`
+		CreatedAt       time.Time        `json:"createdAt"`
+		UpdatedAt       time.Time        `json:"updatedAt"`
+	}
+
+	SetResponse struct {
+		Stroke      string           `json:"stroke"`
+		Repetitions int              `json:"repetitions"`
+		Distance    float64          `json:"distance"`
+		RestSeconds int              `json:"restSeconds"`
+		TimeSeconds *float64         `json:"timeSeconds"`
+		Calories    *float64         `json:"calories"` // kcal, rest included
+		Laps        []LapResponse    `json:"laps"`
+		Metrics     *MetricsResponse `json:"metrics"`
+	}
+
+	LapResponse struct {
+		TimeSeconds float64 `json:"timeSeconds"`
+		Strokes     *int    `json:"strokes"`
+		SWOLF       *int    `json:"swolf"` // rounded time + strokes
+	}
+
+	WeekResponse struct {
+		WeekStart       string   `json:"weekStart"` // Monday, YYYY-MM-DD
+		Workouts        int      `json:"workouts"`
+		TotalDistance   float64  `json:"totalDistance"`
+		DistanceUnit    string   `json:"distanceUnit"`
+		DurationSeconds int      `json:"durationSeconds"`
+		Calories        *float64 `json:"calories"` // kcal
+	}
+)
+
+func (r *WorkoutRequest) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	if date, err := dates.Parse(r.Date); err != nil {
+		errors["date"] = "Date must be formatted as YYYY-MM-DD"
+	} else if date.After(time.Now().Add(24 * time.Hour)) {
+		// one day of slack for clients ahead of UTC
+		errors["date"] = "Date cannot be in the future"
+	}
+
+	if !swim.IsValidPoolLength(r.PoolLength) {
+		errors["poolLength"] = "Pool length must be 25m, 50m, 25yd or open_water"
+	}
+
+	if r.TotalDistance == nil && len(r.Sets) == 0 {
+		errors["totalDistance"] = "Total distance is required when no sets are logged"
+	}
+	if r.TotalDistance != nil && *r.TotalDistance <= 0 {
+		errors["totalDistance"] = "Total distance must be positive"
+	}
+
+	if r.DurationSeconds <= 0 || r.DurationSeconds > maxDurationSec {
+		errors["durationSeconds"] = "Duration must be between 1 second and 24 hours"
+	}
+
+	if r.Notes != nil && len(*r.Notes) > maxNotesLength {
+		errors["notes"] = fmt.Sprintf("Notes cannot be longer than %d characters", maxNotesLength)
+	}
+
+	if len(r.Sets) > maxSets {
+		errors["sets"] = fmt.Sprintf("A workout cannot have more than %d sets", maxSets)
+	}
+	for i, set := range r.Sets {
+		field := fmt.Sprintf("sets[%d].", i)
+
+		if !swim.IsValidStroke(set.Stroke) {
+			errors[field+"stroke"] = "Stroke must be one of " + strings.Join(swim.Strokes(), ", ")
+		}
+		if set.Repetitions <= 0 || set.Repetitions > maxRepetitions {
+			errors[field+"repetitions"] = fmt.Sprintf("Repetitions must be between 1 and %d", maxRepetitions)
+		}
+		if set.Distance <= 0 {
+			errors[field+"distance"] = "Distance must be positive"
+		}
+		if set.RestSeconds < 0 || set.RestSeconds > maxRestSec {
+			errors[field+"restSeconds"] = "Rest must be between 0 and 1 hour"
+		}
+		if set.TimeSeconds != nil && (*set.TimeSeconds <= 0 || *set.TimeSeconds > maxSetTimeSec) {
+			errors[field+"timeSeconds"] = "Time must be between 0 and 24 hours"
+		}
+
+		if len(set.Laps) == 0 {
+			continue
+		}
+		if r.PoolLength == swim.PoolOpenWater {
+			errors[field+"laps"] = "Laps can only be logged in a pool"
+		}
+		if len(set.Laps) > maxLaps {
+			errors[field+"laps"] = fmt.Sprintf("A set cannot have more than %d laps", maxLaps)
+		}
+		for j, lap := range set.Laps {
+			lapField := fmt.Sprintf("%slaps[%d].", field, j)
+
+			if lap.TimeSeconds <= 0 || lap.TimeSeconds > maxLapSec {
+				errors[lapField+"timeSeconds"] = "Lap time must be between 0 and 1 hour"
+			}
+			if lap.Strokes != nil && (*lap.Strokes < 0 || *lap.Strokes > maxLapStrokes) {
+				errors[lapField+"strokes"] = fmt.Sprintf("Strokes must be between 0 and %d", maxLapStrokes)
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+// ToEntity converts a validated request to meters. Laps must cover every pool length
+// of the set, which depends on the unit the distance is given in.
+func (r *WorkoutRequest) ToEntity(accountID string, pref units.Preference) (*entity.Workout, *validator.ValidationError) {
+	date, _ := dates.Parse(r.Date)
+
+	workout := &entity.Workout{
+		AccountID:   accountID,
+		Date:        date,
+		PoolLength:  r.PoolLength,
+		DurationSec: r.DurationSeconds,
+		Sets:        make([]entity.Set, 0, len(r.Sets)),
+	}
+
+	if r.Notes != nil {
+		if notes := strings.TrimSpace(*r.Notes); notes != "" {
+			workout.Notes = &notes
+		}
+	}
+
+	errors := make(map[string]string)
+	lengthM, _ := swim.PoolLengthM(r.PoolLength)
+
+	for i, set := range r.Sets {
+		distanceM := pref.DistanceToMeters(set.Distance)
+		timeSec := set.TimeSeconds
+
+		if distanceM > maxDistanceM {
+			errors[fmt.Sprintf("sets[%d].distance", i)] = "Distance cannot be more than 100 km"
+			continue
+		}
+
+		laps := make([]entity.Lap, 0, len(set.Laps))
+		if len(set.Laps) > 0 {
+			lengths := math.Round(distanceM / lengthM)
+			if lengths < 1 || math.Abs(lengths*lengthM-distanceM) > distanceM*lapDistanceSlack {
+				errors[fmt.Sprintf("sets[%d].distance", i)] = "Distance must be a whole number of pool lengths to log laps"
+			} else if want := int(lengths) * set.Repetitions; len(set.Laps) != want {
+				errors[fmt.Sprintf("sets[%d].laps", i)] = fmt.Sprintf("Expected %d laps, one per pool length", want)
+			}
+
+			total := 0.0
+			for _, lap := range set.Laps {
+				laps = append(laps, entity.Lap{TimeSec: lap.TimeSeconds, Strokes: lap.Strokes})
+				total += lap.TimeSeconds
+			}
+			if timeSec == nil {
+				avg := units.Round(total/float64(set.Repetitions), 2)
+				timeSec = &avg
+			}
+			if *timeSec > maxSetTimeSec {
+				errors[fmt.Sprintf("sets[%d].laps", i)] = "Laps cannot add up to more than 24 hours per repetition"
+			}
+		}
+
+		workout.Sets = append(workout.Sets, entity.Set{
+			Position:    i + 1,
+			Stroke:      set.Stroke,
+			Repetitions: set.Repetitions,
+			DistanceM:   distanceM,
+			RestSec:     set.RestSeconds,
+			TimeSec:     timeSec,
+			Laps:        laps,
+		})
+	}
+
+	if len(errors) > 0 {
+		return nil, &validator.ValidationError{Errors: errors}
+	}
+
+	if r.TotalDistance != nil {
+		workout.TotalDistanceM = pref.DistanceToMeters(*r.TotalDistance)
+	} else {
+		workout.TotalDistanceM = units.Round(entity.SetsDistanceM(workout.Sets), 2)
+	}
+	if workout.TotalDistanceM > maxDistanceM {
+		return nil, &validator.ValidationError{Errors: map[string]string{
+			"totalDistance": "Total distance cannot be more than 100 km",
+		}}
+	}
+
+	return workout, nil
+}
+
+func (q *ListWorkoutsQuery) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	from, fromErr := dates.Parse(q.From)
+	if q.From != "" && fromErr != nil {
+		errors["from"] = "From must be formatted as YYYY-MM-DD"
+	}
+
+	to, toErr := dates.Parse(q.To)
+	if q.To != "" && toErr != nil {
+		errors["to"] = "To must be formatted as YYYY-MM-DD"
+	}
+
+	if q.From != "" && q.To != "" && fromErr == nil && toErr == nil && from.After(to) {
+		errors["to"] = "To cannot be before from"
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+// Filter converts a validated query, clamping the page.
+func (q *ListWorkoutsQuery) Filter() entity.WorkoutFilter {
+	var filter entity.WorkoutFilter
+	filter.Limit, filter.Offset = response.NormalizePage(q.Limit, q.Offset)
+
+	if from, err := dates.Parse(q.From); err == nil {
+		filter.From = &from
+	}
+	if to, err := dates.Parse(q.To); err == nil {
+		filter.To = &to
+	}
+
+	return filter
+}
+
+func (q *WeeklyTotalsQuery) Validate() *validator.ValidationError {
+	errors := make(map[string]string)
+
+	from, fromErr := dates.Parse(q.From)
+	if q.From != "" && fromErr != nil {
+		errors["from"] = "From must be formatted as YYYY-MM-DD"
+	}
+
+	to, toErr := dates.Parse(q.To)
+	if q.To != "" && toErr != nil {
+		errors["to"] = "To must be formatted as YYYY-MM-DD"
+	}
+
+	if q.From != "" && q.To != "" && fromErr == nil && toErr == nil {
+		if from.After(to) {
+			errors["to"] = "To cannot be before from"
+		} else if to.Sub(from) >= maxWeeks*7*24*time.Hour {
+			errors["to"] = fmt.Sprintf("At most %d weeks can be requested", maxWeeks)
+		}
+	}
+
+	if len(errors) > 0 {
+		return &validator.ValidationError{Errors: errors}
+	}
+
+	return nil
+}
+
+// Weeks returns the Mondays of the first and the last requested week.
+func (q *WeeklyTotalsQuery) Weeks(now time.Time) (first, last time.Time) {
+	last = dates.WeekStart(now.UTC())
+	if to, err := dates.Parse(q.To); err == nil {
+		last = dates.WeekStart(to)
+	}
+
+	first = last.AddDate(0, 0, -7*(defaultWeeks-1))
+	if from, err := dates.Parse(q.From); err == nil {
+		first = dates.WeekStart(from)
+	} else if q.To == "" {
+		return first, last
+	}
+
+	// only one bound given, keep the span within maxWeeks
+	if last.Before(first) {
+		last = first
+	}
+	if last.Sub(first) >= maxWeeks*7*24*time.Hour {
+		last = first.AddDate(0, 0, 7*(maxWeeks-1))
+	}
+	return first, last
+}
+
+// ToWeekResponses totals workouts by ISO week, weeks without workouts included.
+// calories follows workouts, it is nil when the swimmer's weight is unknown.
+func ToWeekResponses(first, last time.Time, workouts []entity.Workout, calories []*entity.Calories, pref units.Preference) []WeekResponse {
+	var (
+		out       = make([]WeekResponse, 0)
+		distances = make([]float64, 0)
+		kcal      = make([]float64, 0)
+		index     = make(map[string]int)
+	)
+	for week := first; !week.After(last); week = week.AddDate(0, 0, 7) {
+		index[week.Format(dates.Layout)] = len(out)
+		out = append(out, WeekResponse{WeekStart: week.Format(dates.Layout), DistanceUnit: pref.Distance})
+		distances = append(distances, 0)
+		kcal = append(kcal, 0)
+	}
+
+	for i := range workouts {
+		j, ok := index[dates.WeekStart(workouts[i].Date).Format(dates.Layout)]
+		if !ok {
+			continue
+		}
+
+		out[j].Workouts++
+		out[j].DurationSeconds += workouts[i].DurationSec
+		distances[j] += workouts[i].TotalDistanceM
+		if calories != nil && calories[i] != nil {
+			kcal[j] += calories[i].Total
+		}
+	}
+
+	for j := range out {
+		out[j].TotalDistance = pref.DistanceFromMeters(distances[j])
+		if calories != nil {
+			out[j].Calories = &kcal[j]
+		}
+	}
+
+	return out
+}
+
+// ToWorkoutResponse converts workout for display with its metrics, calories may be nil.
+func ToWorkoutResponse(workout *entity.Workout, pref units.Preference, calories *entity.Calories) WorkoutResponse {
+	out := WorkoutResponse{
+		ID:              workout.ID,
+		Date:            workout.Date.Format(dates.Layout),
+		PoolLength:      workout.PoolLength,
+		TotalDistance:   pref.DistanceFromMeters(workout.TotalDistanceM),
+		DistanceUnit:    pref.Distance,
+		DurationSeconds: workout.DurationSec,
+		Notes:           workout.Notes,
+		Sets:            make([]SetResponse, 0, len(workout.Sets)),
+		CreatedAt:       workout.CreatedAt,
+		UpdatedAt:       workout.UpdatedAt,
+	}
+	if calories != nil {
+		out.Calories = &calories.Total
+	}
+
+	metrics := workout.Metrics()
+	out.Metrics = ToMetricsResponse(&metrics.Total, pref)
+
+	for i, set := range workout.Sets {
+		setOut := SetResponse{
+			Stroke:      set.Stroke,
+			Repetitions: set.Repetitions,
+			Distance:    pref.DistanceFromMeters(set.DistanceM),
+			RestSeconds: set.RestSec,
+			TimeSeconds: set.TimeSec,
+		}
+		if calories != nil && i < len(calories.Sets) {
+			setOut.Calories = &calories.Sets[i]
+		}
+		setOut.Metrics = ToMetricsResponse(&metrics.Sets[i], pref)
+
+		setOut.Laps = make([]LapResponse, 0, len(set.Laps))
+		for _, lap := range set.Laps {
+			lapOut := LapResponse{TimeSeconds: lap.TimeSec, Strokes: lap.Strokes}
+			if lap.Strokes != nil {
+				swolf := int(math.Round(lap.TimeSec)) + *lap.Strokes
+				lapOut.SWOLF = &swolf
+			}
+			setOut.Laps = append(setOut.Laps, lapOut)
+		}
+
+		out.Sets = append(out.Sets, setOut)
+	}
+
+	return out
+}
@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"haphap/swimo-api/pkg/energy"
+	"haphap/swimo-api/pkg/swim"
+	"haphap/swimo-api/pkg/units"
+	"math"
+)
+
+type (
+	// Swimmer is the users row as workouts need it.
+	Swimmer struct {
+		DistanceUnit     string
+		WeightKG         *float64
+		ThresholdPaceSec *float64 // per 100m
+	}
+
+	// Calories is an estimate in kcal, Sets follows the workout's sets.
+	Calories struct {
+		Total float64
+		Sets  []float64
+	}
+)
+
+func (s *Swimmer) Units() units.Preference {
+	return units.Preference{Distance: s.DistanceUnit}
+}
+
+// EstimateCalories spreads the workout duration over its sets: timed sets use their
+// own time, the others share what is left after rest in proportion to their distance.
+// It returns nil when the swimmer's weight is unknown.
+func (w *Workout) EstimateCalories(table *energy.Table, swimmer *Swimmer) (*Calories, error) {
+	if swimmer.WeightKG == nil || *swimmer.WeightKG <= 0 {
+		return nil, nil
+	}
+	weight := *swimmer.WeightKG
+	threshold := 0.0
+	if swimmer.ThresholdPaceSec != nil {
+		threshold = *swimmer.ThresholdPaceSec
+	}
+
+	duration := float64(w.DurationSec)
+
+	if len(w.Sets) == 0 {
+		pace := 0.0
+		if w.TotalDistanceM > 0 {
+			pace = duration / w.TotalDistanceM * 100
+		}
+		met, err := table.SwimMET(swim.StrokeChoice, pace, threshold)
+		if err != nil {
+			return nil, err
+		}
+		total := energy.Kcal(met, weight, duration)
+		return &Calories{Total: math.Round(total), Sets: []float64{}}, nil
+	}
+
+	var timedSec, restSec, untimedM float64
+	for _, set := range w.Sets {
+		reps := float64(set.Repetitions)
+		restSec += reps * float64(set.RestSec)
+		if set.TimeSec != nil {
+			timedSec += reps * *set.TimeSec
+		} else {
+			untimedM += reps * set.DistanceM
+		}
+	}
+
+	// logged rest cannot take more than the time that is left, distances are trusted first
+	left := max(duration-timedSec, 0)
+	restScale := 0.0
+	if restSec > 0 {
+		restScale = min(restSec, left) / restSec
+	}
+	untimedSec := left - restSec*restScale
+	if untimedM > 0 && untimedSec <= 0 {
+		untimedSec, restScale = left, 0
+	}
+
+	out := &Calories{Sets: make([]float64, 0, len(w.Sets))}
+	for _, set := range w.Sets {
+		reps := float64(set.Repetitions)
+		distance := reps * set.DistanceM
+
+		active := 0.0
+		if set.TimeSec != nil {
+			active = reps * *set.TimeSec
+		} else if untimedM > 0 {
+			active = untimedSec * distance / untimedM
+		}
+
+		pace := 0.0
+		if active > 0 && distance > 0 {
+			pace = active / distance * 100
+		}
+
+		met, err := table.SwimMET(set.Stroke, pace, threshold)
+		if err != nil {
+			return nil, err
+		}
+		kcal := energy.Kcal(met, weight, active) +
+			energy.Kcal(table.RestMET, weight, reps*float64(set.RestSec)*restScale)
+
+		out.Sets = append(out.Sets, math.Round(kcal))
+		out.Total += kcal
+	}
+	out.Total = math.Round(out.Total)
+
+	return out, nil
+}
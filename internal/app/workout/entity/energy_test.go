@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"errors"
+	"haphap/swimo-api/pkg/energy"
+	"slices"
+	"testing"
+)
+
+func TestEstimateCalories(t *testing.T) {
+	table := energy.Default()
+	weight := 60.0
+	swimmer := &Swimmer{WeightKG: &weight} // no threshold pace: every set is moderate
+	repSec := 100.0
+
+	tests := []struct {
+		name      string
+		workout   Workout
+		wantTotal float64
+		wantSets  []float64
+	}{
+		{
+			name:      "without sets",
+			workout:   Workout{DurationSec: 1800, TotalDistanceM: 1500},
+			wantTotal: 210, // choice, 7 MET for half an hour
+			wantSets:  []float64{},
+		},
+		{
+			name: "rest fits in the duration",
+			workout: Workout{DurationSec: 1000, Sets: []Set{
+				{Stroke: "freestyle", Repetitions: 4, DistanceM: 100, RestSec: 20, TimeSec: &repSec},
+				{Stroke: "freestyle", Repetitions: 1, DistanceM: 200},
+			}},
+			// 400s at 7 MET + 80s of rest at 2 MET, then the 520s left at 7 MET
+			wantTotal: 110,
+			wantSets:  []float64{49, 61},
+		},
+		{
+			name: "rest is scaled down to the time left",
+			workout: Workout{DurationSec: 450, Sets: []Set{
+				{Stroke: "freestyle", Repetitions: 4, DistanceM: 100, RestSec: 50, TimeSec: &repSec},
+			}},
+			// only 50 of the 200s of rest fit
+			wantTotal: 48,
+			wantSets:  []float64{48},
+		},
+		{
+			name: "untimed distance goes before rest",
+			workout: Workout{DurationSec: 500, Sets: []Set{
+				{Stroke: "freestyle", Repetitions: 4, DistanceM: 100, RestSec: 50, TimeSec: &repSec},
+				{Stroke: "freestyle", Repetitions: 1, DistanceM: 100},
+			}},
+			// rest would eat the 100s left, the untimed set swims them instead
+			wantTotal: 58,
+			wantSets:  []float64{47, 12},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.workout.EstimateCalories(table, swimmer)
+			if err != nil {
+				t.Fatalf("EstimateCalories: %v", err)
+			}
+			if got.Total != tt.wantTotal || !slices.Equal(got.Sets, tt.wantSets) {
+				t.Fatalf("EstimateCalories() = %v %v, want %v %v", got.Total, got.Sets, tt.wantTotal, tt.wantSets)
+			}
+		})
+	}
+}
+
+func TestEstimateCaloriesWithoutWeight(t *testing.T) {
+	workout := Workout{DurationSec: 1800, TotalDistanceM: 1500}
+
+	got, err := workout.EstimateCalories(energy.Default(), &Swimmer{})
+	if err != nil || got != nil {
+		t.Fatalf("EstimateCalories() = %v, %v, want nil", got, err)
+	}
+}
+
+func TestEstimateCaloriesUnknownStroke(t *testing.T) {
+	weight := 60.0
+	workout := Workout{DurationSec: 600, Sets: []Set{{Stroke: "sidestroke", Repetitions: 1, DistanceM: 400}}}
+
+	_, err := workout.EstimateCalories(energy.Default(), &Swimmer{WeightKG: &weight})
+	if !errors.Is(err, energy.ErrUnknownStroke) {
+		t.Fatalf("EstimateCalories error = %v, want %v", err, energy.ErrUnknownStroke)
+	}
+}
@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+type (
+	// Workout is a logged swim session, distances are in meters.
+	Workout struct {
+		ID             string
+		AccountID      string
+		Date           time.Time
+		PoolLength     string
+		TotalDistanceM float64
+		DurationSec    int
+		Notes          *string
+		Sets           []Set
+		CreatedAt      time.Time
+		UpdatedAt      time.Time
+	}
+
+	// Set is one interval of a workout, ex: 4 x 100m freestyle on 20s rest.
+	Set struct {
+		Position    int
+		Stroke      string
+		Repetitions int
+		DistanceM   float64  // per repetition
+		RestSec     int      // after each repetition
+		TimeSec     *float64 // per repetition
+		Laps        []Lap    // one per pool length, empty when not tracked
+	}
+
+	// Lap is one pool length of a set.
+	Lap struct {
+		TimeSec float64
+		Strokes *int
+	}
+
+	WorkoutFilter struct {
+		From   *time.Time
+		To     *time.Time
+		Limit  int
+		Offset int
+	}
+)
+
+// SetsDistanceM sums the distance swum in every set.
+func SetsDistanceM(sets []Set) float64 {
+	total := 0.0
+	for _, set := range sets {
+		total += float64(set.Repetitions) * set.DistanceM
+	}
+	return total
+}
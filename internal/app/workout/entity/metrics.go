@@ -0,0 +1,313 @@
+package entity
+
+import (
+	"haphap/swimo-api/pkg/swim"
+	"math"
+	"slices"
+)
+
+const (
+	SplitNegative = "negative"
+	SplitEven     = "even"
+	SplitPositive = "positive"
+
+	// evenSplitRatio is how much the halves may differ, relative to the first one, and still be even.
+	evenSplitRatio = 0.01
+)
+
+type (
+	// Metrics describe how a set or a workout was swum, distances are in meters and paces per 100m.
+	// Stroke metrics need laps with stroke counts, paces need times, the others stay nil.
+	Metrics struct {
+		Lengths            int
+		SWOLF              *float64 // length time in seconds + strokes
+		StrokesPerLength   *float64
+		DistancePerStrokeM *float64
+		StrokeRate         *float64 // strokes per minute
+		AvgPaceSec         *float64
+		BestPaceSec        *float64 // fastest repetition
+		Split              *Split
+		Consistency        *float64 // percent, 100 when every length takes the same time
+	}
+
+	// Split compares the time swum in the first and the second half of the repetitions.
+	Split struct {
+		FirstHalfSec  float64
+		SecondHalfSec float64
+	}
+
+	// WorkoutMetrics totals the workout, Sets follows the workout's sets. Splits and
+	// consistency only mean something within a set, Total has neither.
+	WorkoutMetrics struct {
+		Total Metrics
+		Sets  []Metrics
+	}
+
+	// MetricsGroup gathers the sets of one stroke in one pool length across workouts.
+	MetricsGroup struct {
+		Stroke      string
+		PoolLength  string
+		Workouts    int
+		Sets        int
+		Metrics     Metrics
+		Splits      map[string]int // sets by split kind
+		Consistency *float64       // average of the sets
+	}
+
+	// metricsTally accumulates sets before their metrics are derived.
+	metricsTally struct {
+		lengths     int
+		timedM      float64
+		timedSec    float64
+		bestPaceSec float64
+		strokedLen  int
+		strokedM    float64
+		strokedSec  float64
+		strokes     int
+	}
+)
+
+// Kind tells a negative split (second half faster) from a positive one.
+func (s *Split) Kind() string {
+	diff := s.SecondHalfSec - s.FirstHalfSec
+	switch {
+	case math.Abs(diff) <= s.FirstHalfSec*evenSplitRatio:
+		return SplitEven
+	case diff < 0:
+		return SplitNegative
+	default:
+		return SplitPositive
+	}
+}
+
+// Metrics derives the set's metrics from its laps, lengthM is the pool length.
+// Without laps only the paces are known, from the repetition time.
+func (s *Set) Metrics(lengthM float64) Metrics {
+	var tally metricsTally
+	tally.addSet(s, lengthM)
+
+	out := tally.metrics()
+	out.Split = lapSplit(s.Laps, s.Repetitions)
+	out.Consistency = lapConsistency(s.Laps)
+
+	return out
+}
+
+// Metrics computes every set in the pool length of the workout.
+func (w *Workout) Metrics() WorkoutMetrics {
+	lengthM, _ := swim.PoolLengthM(w.PoolLength)
+
+	var total metricsTally
+	out := WorkoutMetrics{Sets: make([]Metrics, 0, len(w.Sets))}
+	for i := range w.Sets {
+		out.Sets = append(out.Sets, w.Sets[i].Metrics(lengthM))
+		total.addSet(&w.Sets[i], lengthM)
+	}
+	out.Total = total.metrics()
+
+	return out
+}
+
+// GroupMetrics aggregates the timed sets of workouts by stroke, then pool length.
+// SWOLF and strokes per length depend on the pool, so pools are never mixed.
+func GroupMetrics(workouts []Workout) []MetricsGroup {
+	type group struct {
+		MetricsGroup
+		tally       metricsTally
+		workouts    map[string]bool
+		consistency []float64
+	}
+
+	groups := make(map[[2]string]*group)
+	for _, workout := range workouts {
+		lengthM, _ := swim.PoolLengthM(workout.PoolLength)
+
+		for i := range workout.Sets {
+			set := &workout.Sets[i]
+			if len(set.Laps) == 0 && set.TimeSec == nil {
+				continue
+			}
+
+			key := [2]string{set.Stroke, workout.PoolLength}
+			g, ok := groups[key]
+			if !ok {
+				g = &group{
+					MetricsGroup: MetricsGroup{Stroke: set.Stroke, PoolLength: workout.PoolLength, Splits: make(map[string]int)},
+					workouts:     make(map[string]bool),
+				}
+				groups[key] = g
+			}
+
+			g.Sets++
+			g.workouts[workout.ID] = true
+			g.tally.addSet(set, lengthM)
+
+			if split := lapSplit(set.Laps, set.Repetitions); split != nil {
+				g.Splits[split.Kind()]++
+			}
+			if consistency := lapConsistency(set.Laps); consistency != nil {
+				g.consistency = append(g.consistency, *consistency)
+			}
+		}
+	}
+
+	out := make([]MetricsGroup, 0, len(groups))
+	for _, g := range groups {
+		g.Workouts = len(g.workouts)
+		g.Metrics = g.tally.metrics()
+		if len(g.consistency) > 0 {
+			avg := mean(g.consistency)
+			g.Consistency = &avg
+		}
+		out = append(out, g.MetricsGroup)
+	}
+
+	strokes := swim.Strokes()
+	slices.SortFunc(out, func(a, b MetricsGroup) int {
+		if a.Stroke != b.Stroke {
+			return slices.Index(strokes, a.Stroke) - slices.Index(strokes, b.Stroke)
+		}
+		return poolOrder(a.PoolLength) - poolOrder(b.PoolLength)
+	})
+
+	return out
+}
+
+// SummarizeMetrics totals the sets of several workouts, whatever their stroke and pool.
+func SummarizeMetrics(workouts []Workout) Metrics {
+	var total metricsTally
+	for _, workout := range workouts {
+		lengthM, _ := swim.PoolLengthM(workout.PoolLength)
+		for i := range workout.Sets {
+			total.addSet(&workout.Sets[i], lengthM)
+		}
+	}
+	return total.metrics()
+}
+
+// addSet counts the laps of a set, or its repetition time when no laps were tracked.
+// Laps always cover every repetition, see dto.WorkoutRequest.ToEntity.
+func (t *metricsTally) addSet(s *Set, lengthM float64) {
+	if len(s.Laps) == 0 || lengthM <= 0 {
+		if s.TimeSec != nil && s.DistanceM > 0 {
+			reps := float64(s.Repetitions)
+			t.addTime(reps*s.DistanceM, reps**s.TimeSec, *s.TimeSec/s.DistanceM*100)
+		}
+		return
+	}
+
+	perRep := len(s.Laps) / s.Repetitions
+	repSec := 0.0
+	for i, lap := range s.Laps {
+		t.lengths++
+		repSec += lap.TimeSec
+
+		if lap.Strokes != nil {
+			t.strokedLen++
+			t.strokedM += lengthM
+			t.strokedSec += lap.TimeSec
+			t.strokes += *lap.Strokes
+		}
+
+		if (i+1)%perRep == 0 {
+			repM := float64(perRep) * lengthM
+			t.addTime(repM, repSec, repSec/repM*100)
+			repSec = 0
+		}
+	}
+}
+
+func (t *metricsTally) addTime(distanceM, sec, paceSec float64) {
+	t.timedM += distanceM
+	t.timedSec += sec
+	if t.bestPaceSec == 0 || paceSec < t.bestPaceSec {
+		t.bestPaceSec = paceSec
+	}
+}
+
+func (t *metricsTally) metrics() Metrics {
+	out := Metrics{Lengths: t.lengths}
+
+	if t.timedM > 0 {
+		avg, best := t.timedSec/t.timedM*100, t.bestPaceSec
+		out.AvgPaceSec, out.BestPaceSec = &avg, &best
+	}
+
+	if t.strokedLen > 0 {
+		lengths, strokes := float64(t.strokedLen), float64(t.strokes)
+		swolf := (t.strokedSec + strokes) / lengths
+		spl := strokes / lengths
+		out.SWOLF, out.StrokesPerLength = &swolf, &spl
+
+		if t.strokes > 0 {
+			dps := t.strokedM / strokes
+			rate := strokes / t.strokedSec * 60
+			out.DistancePerStrokeM, out.StrokeRate = &dps, &rate
+		}
+	}
+
+	return out
+}
+
+// lapSplit adds up the halves of every repetition, or of the whole set when each
+// repetition is a single length (ex: 8x25). Odd middle lengths count half each side.
+func lapSplit(laps []Lap, reps int) *Split {
+	perRep := len(laps) / reps
+	if perRep < 2 {
+		perRep = len(laps)
+	}
+	if perRep < 2 {
+		return nil
+	}
+
+	var split Split
+	for start := 0; start+perRep <= len(laps); start += perRep {
+		for i, lap := range laps[start : start+perRep] {
+			switch pos := 2*i + 1; {
+			case pos < perRep:
+				split.FirstHalfSec += lap.TimeSec
+			case pos > perRep:
+				split.SecondHalfSec += lap.TimeSec
+			default:
+				split.FirstHalfSec += lap.TimeSec / 2
+				split.SecondHalfSec += lap.TimeSec / 2
+			}
+		}
+	}
+
+	return &split
+}
+
+// lapConsistency is 100 minus the coefficient of variation of the length times, in percent.
+func lapConsistency(laps []Lap) *float64 {
+	if len(laps) < 2 {
+		return nil
+	}
+
+	times := make([]float64, len(laps))
+	for i, lap := range laps {
+		times[i] = lap.TimeSec
+	}
+
+	avg := mean(times)
+	variance := 0.0
+	for _, t := range times {
+		variance += (t - avg) * (t - avg)
+	}
+	cv := math.Sqrt(variance/float64(len(times))) / avg
+
+	consistency := max(0, 100*(1-cv))
+	return &consistency
+}
+
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func poolOrder(pool string) int {
+	return slices.Index([]string{swim.Pool25M, swim.Pool50M, swim.Pool25Yd, swim.PoolOpenWater}, pool)
+}
@@ -0,0 +1,200 @@
+package entity
+
+import (
+	"math"
+	"testing"
+)
+
+func laps(times ...float64) []Lap {
+	out := make([]Lap, len(times))
+	for i, t := range times {
+		out[i] = Lap{TimeSec: t}
+	}
+	return out
+}
+
+func strokedLaps(times []float64, strokes []int) []Lap {
+	out := laps(times...)
+	for i := range out {
+		out[i].Strokes = &strokes[i]
+	}
+	return out
+}
+
+func assertFloat(t *testing.T, name string, got *float64, want float64) {
+	t.Helper()
+
+	if got == nil {
+		t.Fatalf("%s = nil, want %v", name, want)
+	}
+	if math.Abs(*got-want) > 1e-9 {
+		t.Fatalf("%s = %v, want %v", name, *got, want)
+	}
+}
+
+func TestSplitKind(t *testing.T) {
+	tests := []struct {
+		name  string
+		split Split
+		want  string
+	}{
+		{"same halves", Split{FirstHalfSec: 60, SecondHalfSec: 60}, SplitEven},
+		{"within one percent", Split{FirstHalfSec: 60, SecondHalfSec: 60.5}, SplitEven},
+		{"faster second half", Split{FirstHalfSec: 60, SecondHalfSec: 58}, SplitNegative},
+		{"slower second half", Split{FirstHalfSec: 60, SecondHalfSec: 62}, SplitPositive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.split.Kind(); got != tt.want {
+				t.Fatalf("Kind() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLapSplit(t *testing.T) {
+	tests := []struct {
+		name       string
+		laps       []Lap
+		reps       int
+		wantNil    bool
+		wantFirst  float64
+		wantSecond float64
+	}{
+		{
+			name:       "even lengths",
+			laps:       laps(20, 21, 22, 23),
+			reps:       1,
+			wantFirst:  41,
+			wantSecond: 45,
+		},
+		{
+			name:       "odd lengths share the middle one",
+			laps:       laps(20, 22, 24),
+			reps:       1,
+			wantFirst:  31,
+			wantSecond: 35,
+		},
+		{
+			name:       "halves of every repetition",
+			laps:       laps(20, 21, 22, 23),
+			reps:       2,
+			wantFirst:  42,
+			wantSecond: 44,
+		},
+		{
+			name:       "single length repetitions are merged",
+			laps:       laps(15, 15, 16, 16, 17, 17, 18, 18),
+			reps:       8,
+			wantFirst:  62,
+			wantSecond: 70,
+		},
+		{
+			name:    "single length",
+			laps:    laps(20),
+			reps:    1,
+			wantNil: true,
+		},
+		{
+			name:    "no laps",
+			reps:    4,
+			wantNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			split := lapSplit(tt.laps, tt.reps)
+			if tt.wantNil {
+				if split != nil {
+					t.Fatalf("lapSplit() = %+v, want nil", split)
+				}
+				return
+			}
+
+			assertFloat(t, "FirstHalfSec", &split.FirstHalfSec, tt.wantFirst)
+			assertFloat(t, "SecondHalfSec", &split.SecondHalfSec, tt.wantSecond)
+		})
+	}
+}
+
+func TestLapConsistency(t *testing.T) {
+	tests := []struct {
+		name    string
+		laps    []Lap
+		wantNil bool
+		want    float64
+	}{
+		{name: "same times", laps: laps(20, 20, 20), want: 100},
+		{name: "varying times", laps: laps(20, 30), want: 80},
+		{name: "never below zero", laps: laps(1, 1, 1, 100), want: 0},
+		{name: "single length", laps: laps(20), wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lapConsistency(tt.laps)
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("lapConsistency() = %v, want nil", *got)
+				}
+				return
+			}
+
+			assertFloat(t, "consistency", got, tt.want)
+		})
+	}
+}
+
+func TestSetMetrics(t *testing.T) {
+	t.Run("laps with strokes", func(t *testing.T) {
+		set := Set{
+			Repetitions: 2,
+			DistanceM:   50,
+			Laps:        strokedLaps([]float64{20, 22, 21, 23}, []int{10, 12, 11, 13}),
+		}
+
+		got := set.Metrics(25)
+		if got.Lengths != 4 {
+			t.Fatalf("Lengths = %d, want 4", got.Lengths)
+		}
+		assertFloat(t, "SWOLF", got.SWOLF, (86.0+46)/4)
+		assertFloat(t, "StrokesPerLength", got.StrokesPerLength, 11.5)
+		assertFloat(t, "DistancePerStrokeM", got.DistancePerStrokeM, 100.0/46)
+		assertFloat(t, "StrokeRate", got.StrokeRate, 46.0/86*60)
+		assertFloat(t, "AvgPaceSec", got.AvgPaceSec, 86)
+		assertFloat(t, "BestPaceSec", got.BestPaceSec, 84)
+	})
+
+	t.Run("laps without strokes", func(t *testing.T) {
+		set := Set{Repetitions: 1, DistanceM: 50, Laps: laps(30, 32)}
+
+		got := set.Metrics(25)
+		if got.SWOLF != nil || got.StrokesPerLength != nil || got.DistancePerStrokeM != nil || got.StrokeRate != nil {
+			t.Fatalf("stroke metrics without stroke counts: %+v", got)
+		}
+		assertFloat(t, "AvgPaceSec", got.AvgPaceSec, 124)
+		assertFloat(t, "BestPaceSec", got.BestPaceSec, 124)
+	})
+
+	t.Run("single length repetitions", func(t *testing.T) {
+		set := Set{Repetitions: 4, DistanceM: 25, Laps: laps(16, 15, 17, 18)}
+
+		got := set.Metrics(25)
+		assertFloat(t, "AvgPaceSec", got.AvgPaceSec, 66)
+		assertFloat(t, "BestPaceSec", got.BestPaceSec, 60)
+	})
+
+	t.Run("repetition time only", func(t *testing.T) {
+		timeSec := 90.0
+		set := Set{Repetitions: 4, DistanceM: 100, TimeSec: &timeSec}
+
+		got := set.Metrics(25)
+		if got.Lengths != 0 || got.SWOLF != nil || got.Split != nil || got.Consistency != nil {
+			t.Fatalf("lap metrics without laps: %+v", got)
+		}
+		assertFloat(t, "AvgPaceSec", got.AvgPaceSec, 90)
+		assertFloat(t, "BestPaceSec", got.BestPaceSec, 90)
+	})
+}
@@ -0,0 +1,344 @@
+package workout
+
+import (
+	"context"
+	"errors"
+	"haphap/swimo-api/internal/app/workout/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrWorkoutNotFound = errors.New("workout not found")
+	ErrSwimmerNotFound = errors.New("swimmer not found")
+)
+
+type WorkoutRepository interface {
+	GetSwimmer(ctx context.Context, accountID string) (*entity.Swimmer, error)
+	CreateWorkout(ctx context.Context, tx pgx.Tx, workout *entity.Workout) error
+	UpdateWorkout(ctx context.Context, tx pgx.Tx, workout *entity.Workout) error
+	ReplaceSets(ctx context.Context, tx pgx.Tx, workoutID string, sets []entity.Set) error
+	GetWorkout(ctx context.Context, accountID, workoutID string) (*entity.Workout, error)
+	ListWorkouts(ctx context.Context, accountID string, filter entity.WorkoutFilter) ([]entity.Workout, int, error)
+	DeleteWorkout(ctx context.Context, accountID, workoutID string) error
+}
+
+type workoutRepository struct{ db *pgxpool.Pool }
+
+func NewWorkoutRepository(db *pgxpool.Pool) WorkoutRepository { return &workoutRepository{db: db} }
+
+// GetSwimmer returns the distance unit and what calorie estimates need to know about the user.
+func (r *workoutRepository) GetSwimmer(ctx context.Context, accountID string) (*entity.Swimmer, error) {
+	const sql = `SELECT distance_unit, weight_kg, threshold_pace_sec FROM users WHERE account_id = $1`
+
+	var swimmer entity.Swimmer
+	if err := r.db.QueryRow(ctx, sql, accountID).Scan(
+		&swimmer.DistanceUnit,
+		&swimmer.WeightKG,
+		&swimmer.ThresholdPaceSec,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSwimmerNotFound
+		}
+		return nil, err
+	}
+
+	return &swimmer, nil
+}
+
+func (r *workoutRepository) CreateWorkout(ctx context.Context, tx pgx.Tx, workout *entity.Workout) error {
+	const sql = `
+		INSERT INTO workouts (account_id, swum_on, pool_length, total_distance_m, duration_sec, notes)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at`
+
+	return tx.QueryRow(ctx, sql,
+		workout.AccountID,
+		workout.Date,
+		workout.PoolLength,
+		workout.TotalDistanceM,
+		workout.DurationSec,
+		workout.Notes,
+	).Scan(&workout.ID, &workout.CreatedAt, &workout.UpdatedAt)
+}
+
+func (r *workoutRepository) UpdateWorkout(ctx context.Context, tx pgx.Tx, workout *entity.Workout) error {
+	const sql = `
+		UPDATE workouts
+		SET swum_on = $3, pool_length = $4, total_distance_m = $5, duration_sec = $6, notes = $7, updated_at = now()
+		WHERE id = $1 AND account_id = $2
+		RETURNING created_at, updated_at`
+
+	if err := tx.QueryRow(ctx, sql,
+		workout.ID,
+		workout.AccountID,
+		workout.Date,
+		workout.PoolLength,
+		workout.TotalDistanceM,
+		workout.DurationSec,
+		workout.Notes,
+	).Scan(&workout.CreatedAt, &workout.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrWorkoutNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ReplaceSets overwrites the sets of a workout and their laps, positions follow the slice order.
+func (r *workoutRepository) ReplaceSets(ctx context.Context, tx pgx.Tx, workoutID string, sets []entity.Set) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM workout_sets WHERE workout_id = $1`, workoutID); err != nil {
+		return err
+	}
+	if len(sets) == 0 {
+		return nil
+	}
+
+	var (
+		strokes     = make([]string, len(sets))
+		repetitions = make([]int, len(sets))
+		distances   = make([]float64, len(sets))
+		rests       = make([]int, len(sets))
+		times       = make([]*float64, len(sets))
+	)
+	for i, set := range sets {
+		strokes[i] = set.Stroke
+		repetitions[i] = set.Repetitions
+		distances[i] = set.DistanceM
+		rests[i] = set.RestSec
+		times[i] = set.TimeSec
+	}
+
+	const sql = `
+		INSERT INTO workout_sets (workout_id, position, stroke, repetitions, distance_m, rest_sec, time_sec)
+		SELECT $1, s.ord, s.stroke, s.repetitions, s.distance_m, s.rest_sec, s.time_sec
+		FROM unnest($2::text[], $3::int[], $4::numeric[], $5::int[], $6::numeric[])
+			WITH ORDINALITY AS s(stroke, repetitions, distance_m, rest_sec, time_sec, ord)`
+
+	if _, err := tx.Exec(ctx, sql, workoutID, strokes, repetitions, distances, rests, times); err != nil {
+		return err
+	}
+
+	return r.insertLaps(ctx, tx, workoutID, sets)
+}
+
+// insertLaps stores the laps of freshly inserted sets, numbered from 1 within each set.
+func (r *workoutRepository) insertLaps(ctx context.Context, tx pgx.Tx, workoutID string, sets []entity.Set) error {
+	var (
+		positions = make([]int, 0)
+		laps      = make([]int, 0)
+		times     = make([]float64, 0)
+		strokes   = make([]*int, 0)
+	)
+	for i, set := range sets {
+		for j, lap := range set.Laps {
+			positions = append(positions, i+1)
+			laps = append(laps, j+1)
+			times = append(times, lap.TimeSec)
+			strokes = append(strokes, lap.Strokes)
+		}
+	}
+	if len(laps) == 0 {
+		return nil
+	}
+
+	const sql = `
+		INSERT INTO workout_set_laps (workout_id, set_position, lap, time_sec, strokes)
+		SELECT $1, l.set_position, l.lap, l.time_sec, l.strokes
+		FROM unnest($2::int[], $3::int[], $4::numeric[], $5::int[]) AS l(set_position, lap, time_sec, strokes)`
+
+	_, err := tx.Exec(ctx, sql, workoutID, positions, laps, times, strokes)
+	return err
+}
+
+func (r *workoutRepository) GetWorkout(ctx context.Context, accountID, workoutID string) (*entity.Workout, error) {
+	const sql = `
+		SELECT id, account_id, swum_on, pool_length, total_distance_m, duration_sec, notes, created_at, updated_at
+		FROM workouts
+		WHERE id = $1 AND account_id = $2`
+
+	var workout entity.Workout
+	if err := r.db.QueryRow(ctx, sql, workoutID, accountID).Scan(
+		&workout.ID,
+		&workout.AccountID,
+		&workout.Date,
+		&workout.PoolLength,
+		&workout.TotalDistanceM,
+		&workout.DurationSec,
+		&workout.Notes,
+		&workout.CreatedAt,
+		&workout.UpdatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWorkoutNotFound
+		}
+		return nil, err
+	}
+
+	sets, err := r.listSets(ctx, workout.ID)
+	if err != nil {
+		return nil, err
+	}
+	workout.Sets = sets[workout.ID]
+
+	return &workout, nil
+}
+
+// ListWorkouts returns a page of workouts, newest first, with their sets and the total count.
+func (r *workoutRepository) ListWorkouts(ctx context.Context, accountID string, filter entity.WorkoutFilter) ([]entity.Workout, int, error) {
+	const sql = `
+		SELECT
+			id, account_id, swum_on, pool_length, total_distance_m, duration_sec, notes, created_at, updated_at,
+			COUNT(*) OVER ()
+		FROM workouts
+		WHERE account_id = $1
+		  AND ($2::date IS NULL OR swum_on >= $2)
+		  AND ($3::date IS NULL OR swum_on <= $3)
+		ORDER BY swum_on DESC, created_at DESC
+		LIMIT $4 OFFSET $5`
+
+	rows, err := r.db.Query(ctx, sql, accountID, filter.From, filter.To, filter.Limit, filter.Offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	total := 0
+	workouts := make([]entity.Workout, 0)
+	for rows.Next() {
+		var workout entity.Workout
+		if err := rows.Scan(
+			&workout.ID,
+			&workout.AccountID,
+			&workout.Date,
+			&workout.PoolLength,
+			&workout.TotalDistanceM,
+			&workout.DurationSec,
+			&workout.Notes,
+			&workout.CreatedAt,
+			&workout.UpdatedAt,
+			&total,
+		); err != nil {
+			return nil, 0, err
+		}
+		workouts = append(workouts, workout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]string, 0, len(workouts))
+	for _, workout := range workouts {
+		ids = append(ids, workout.ID)
+	}
+
+	sets, err := r.listSets(ctx, ids...)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range workouts {
+		workouts[i].Sets = sets[workouts[i].ID]
+	}
+
+	return workouts, total, nil
+}
+
+func (r *workoutRepository) DeleteWorkout(ctx context.Context, accountID, workoutID string) error {
+	const sql = `DELETE FROM workouts WHERE id = $1 AND account_id = $2`
+
+	tag, err := r.db.Exec(ctx, sql, workoutID, accountID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrWorkoutNotFound
+	}
+
+	return nil
+}
+
+// listSets loads the sets of several workouts in one query, keyed by workout id.
+func (r *workoutRepository) listSets(ctx context.Context, workoutIDs ...string) (map[string][]entity.Set, error) {
+	sets := make(map[string][]entity.Set, len(workoutIDs))
+	if len(workoutIDs) == 0 {
+		return sets, nil
+	}
+
+	const sql = `
+		SELECT workout_id, position, stroke, repetitions, distance_m, rest_sec, time_sec
+		FROM workout_sets
+		WHERE workout_id = ANY($1::uuid[])
+		ORDER BY workout_id, position`
+
+	rows, err := r.db.Query(ctx, sql, workoutIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			workoutID string
+			set       entity.Set
+		)
+		if err := rows.Scan(
+			&workoutID,
+			&set.Position,
+			&set.Stroke,
+			&set.Repetitions,
+			&set.DistanceM,
+			&set.RestSec,
+			&set.TimeSec,
+		); err != nil {
+			return nil, err
+		}
+		sets[workoutID] = append(sets[workoutID], set)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadLaps(ctx, sets, workoutIDs); err != nil {
+		return nil, err
+	}
+
+	return sets, nil
+}
+
+// loadLaps attaches the laps to the sets listSets loaded.
+func (r *workoutRepository) loadLaps(ctx context.Context, sets map[string][]entity.Set, workoutIDs []string) error {
+	const sql = `
+		SELECT workout_id, set_position, time_sec, strokes
+		FROM workout_set_laps
+		WHERE workout_id = ANY($1::uuid[])
+		ORDER BY workout_id, set_position, lap`
+
+	rows, err := r.db.Query(ctx, sql, workoutIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			workoutID string
+			position  int
+			lap       entity.Lap
+		)
+		if err := rows.Scan(&workoutID, &position, &lap.TimeSec, &lap.Strokes); err != nil {
+			return err
+		}
+
+		for i := range sets[workoutID] {
+			if set := &sets[workoutID][i]; set.Position == position {
+				set.Laps = append(set.Laps, lap)
+				break
+			}
+		}
+	}
+
+	return rows.Err()
+}
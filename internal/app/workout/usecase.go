@@ -0,0 +1,251 @@
+package workout
+
+import (
+	"context"
+	"haphap/swimo-api/internal/app/workout/dto"
+	"haphap/swimo-api/internal/app/workout/entity"
+	"haphap/swimo-api/pkg/energy"
+	"haphap/swimo-api/pkg/response"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WorkoutUseCase interface {
+	CreateWorkout(ctx context.Context, accountID string, req dto.WorkoutRequest) (*dto.WorkoutResponse, error)
+	GetWorkout(ctx context.Context, accountID, workoutID string) (*dto.WorkoutResponse, error)
+	ListWorkouts(ctx context.Context, accountID string, query dto.ListWorkoutsQuery) ([]dto.WorkoutResponse, int, error)
+	UpdateWorkout(ctx context.Context, accountID, workoutID string, req dto.WorkoutRequest) (*dto.WorkoutResponse, error)
+	DeleteWorkout(ctx context.Context, accountID, workoutID string) error
+	WeeklyTotals(ctx context.Context, accountID string, query dto.WeeklyTotalsQuery) ([]dto.WeekResponse, error)
+	Metrics(ctx context.Context, accountID string, query dto.MetricsQuery) (*dto.MetricsSummaryResponse, error)
+	ExportAccountData(ctx context.Context, accountID string) (map[string]any, error)
+}
+
+type workoutUseCase struct {
+	pool        *pgxpool.Pool
+	workoutRepo WorkoutRepository
+	metTable    *energy.Table
+}
+
+func NewWorkoutUseCase(pool *pgxpool.Pool, workoutRepo WorkoutRepository, metTable *energy.Table) WorkoutUseCase {
+	return &workoutUseCase{pool, workoutRepo, metTable}
+}
+
+func (uc *workoutUseCase) CreateWorkout(ctx context.Context, accountID string, req dto.WorkoutRequest) (*dto.WorkoutResponse, error) {
+	swimmer, err := uc.workoutRepo.GetSwimmer(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	workout, verr := req.ToEntity(accountID, swimmer.Units())
+	if verr != nil {
+		return nil, verr
+	}
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := uc.workoutRepo.CreateWorkout(ctx, tx, workout); err != nil {
+		return nil, err
+	}
+
+	if err := uc.workoutRepo.ReplaceSets(ctx, tx, workout.ID, workout.Sets); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("create workout: commit transaction failed", slog.String("account_id", accountID), slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	slog.Info("workout created", slog.String("account_id", accountID), slog.String("workout_id", workout.ID))
+
+	out := uc.toResponse(workout, swimmer)
+	return &out, nil
+}
+
+func (uc *workoutUseCase) GetWorkout(ctx context.Context, accountID, workoutID string) (*dto.WorkoutResponse, error) {
+	swimmer, err := uc.workoutRepo.GetSwimmer(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	workout, err := uc.workoutRepo.GetWorkout(ctx, accountID, workoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := uc.toResponse(workout, swimmer)
+	return &out, nil
+}
+
+func (uc *workoutUseCase) ListWorkouts(ctx context.Context, accountID string, query dto.ListWorkoutsQuery) ([]dto.WorkoutResponse, int, error) {
+	swimmer, err := uc.workoutRepo.GetSwimmer(ctx, accountID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	workouts, total, err := uc.workoutRepo.ListWorkouts(ctx, accountID, query.Filter())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]dto.WorkoutResponse, 0, len(workouts))
+	for i := range workouts {
+		out = append(out, uc.toResponse(&workouts[i], swimmer))
+	}
+
+	return out, total, nil
+}
+
+// UpdateWorkout replaces the workout and all of its sets.
+func (uc *workoutUseCase) UpdateWorkout(ctx context.Context, accountID, workoutID string, req dto.WorkoutRequest) (*dto.WorkoutResponse, error) {
+	swimmer, err := uc.workoutRepo.GetSwimmer(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	workout, verr := req.ToEntity(accountID, swimmer.Units())
+	if verr != nil {
+		return nil, verr
+	}
+	workout.ID = workoutID
+
+	// Transaction Start
+	tx, err := uc.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := uc.workoutRepo.UpdateWorkout(ctx, tx, workout); err != nil {
+		return nil, err
+	}
+
+	if err := uc.workoutRepo.ReplaceSets(ctx, tx, workout.ID, workout.Sets); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		slog.Error("update workout: commit transaction failed", slog.String("workout_id", workoutID), slog.String("err", err.Error()))
+		return nil, err
+	}
+
+	slog.Info("workout updated", slog.String("account_id", accountID), slog.String("workout_id", workoutID))
+
+	out := uc.toResponse(workout, swimmer)
+	return &out, nil
+}
+
+func (uc *workoutUseCase) DeleteWorkout(ctx context.Context, accountID, workoutID string) error {
+	if err := uc.workoutRepo.DeleteWorkout(ctx, accountID, workoutID); err != nil {
+		return err
+	}
+
+	slog.Info("workout deleted", slog.String("account_id", accountID), slog.String("workout_id", workoutID))
+	return nil
+}
+
+// WeeklyTotals sums distance, time and estimated calories by ISO week.
+func (uc *workoutUseCase) WeeklyTotals(ctx context.Context, accountID string, query dto.WeeklyTotalsQuery) ([]dto.WeekResponse, error) {
+	swimmer, err := uc.workoutRepo.GetSwimmer(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	first, last := query.Weeks(time.Now())
+	to := last.AddDate(0, 0, 6)
+	workouts, err := uc.listAll(ctx, accountID, entity.WorkoutFilter{From: &first, To: &to})
+	if err != nil {
+		return nil, err
+	}
+
+	var calories []*entity.Calories
+	if swimmer.WeightKG != nil && *swimmer.WeightKG > 0 {
+		calories = make([]*entity.Calories, len(workouts))
+		for i := range workouts {
+			calories[i] = uc.estimateCalories(&workouts[i], swimmer)
+		}
+	}
+
+	return dto.ToWeekResponses(first, last, workouts, calories, swimmer.Units()), nil
+}
+
+// Metrics aggregates SWOLF, stroke and pace metrics of the timed sets by stroke and pool length.
+func (uc *workoutUseCase) Metrics(ctx context.Context, accountID string, query dto.MetricsQuery) (*dto.MetricsSummaryResponse, error) {
+	swimmer, err := uc.workoutRepo.GetSwimmer(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := query.Range(time.Now())
+	workouts, err := uc.listAll(ctx, accountID, entity.WorkoutFilter{From: &from, To: &to})
+	if err != nil {
+		return nil, err
+	}
+
+	out := dto.ToMetricsSummaryResponse(from, to, workouts, swimmer.Units())
+	return &out, nil
+}
+
+// ExportAccountData contributes every logged workout to the account export.
+func (uc *workoutUseCase) ExportAccountData(ctx context.Context, accountID string) (map[string]any, error) {
+	swimmer, err := uc.workoutRepo.GetSwimmer(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	workouts, err := uc.listAll(ctx, accountID, entity.WorkoutFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]dto.WorkoutResponse, 0, len(workouts))
+	for i := range workouts {
+		out = append(out, uc.toResponse(&workouts[i], swimmer))
+	}
+
+	return map[string]any{"workouts": out}, nil
+}
+
+// listAll pages through every workout matching filter, ignoring its page.
+func (uc *workoutUseCase) listAll(ctx context.Context, accountID string, filter entity.WorkoutFilter) ([]entity.Workout, error) {
+	out := make([]entity.Workout, 0)
+	filter.Limit, filter.Offset = response.MaxPageLimit, 0
+	for {
+		workouts, total, err := uc.workoutRepo.ListWorkouts(ctx, accountID, filter)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, workouts...)
+
+		filter.Offset += len(workouts)
+		if len(workouts) == 0 || filter.Offset >= total {
+			break
+		}
+	}
+
+	return out, nil
+}
+
+func (uc *workoutUseCase) toResponse(workout *entity.Workout, swimmer *entity.Swimmer) dto.WorkoutResponse {
+	return dto.ToWorkoutResponse(workout, swimmer.Units(), uc.estimateCalories(workout, swimmer))
+}
+
+// estimateCalories leaves the estimate out rather than failing the request when the MET table misses a stroke.
+func (uc *workoutUseCase) estimateCalories(workout *entity.Workout, swimmer *entity.Swimmer) *entity.Calories {
+	calories, err := workout.EstimateCalories(uc.metTable, swimmer)
+	if err != nil {
+		slog.Warn("workout calories: estimate failed", slog.String("workout_id", workout.ID), slog.String("err", err.Error()))
+		return nil
+	}
+	return calories
+}
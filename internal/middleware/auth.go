@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"context"
+	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/security"
+	"log/slog"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	KindGuest = "guest"
+	KindUser  = "user"
+
+	principalKey = "auth.principal"
+)
+
+// Policy decides which token kinds may access a route.
+type Policy int
+
+const (
+	UserOnly Policy = iota
+	GuestAllowed
+	GuestOnly
+)
+
+func (p Policy) allows(kind string) bool {
+	switch p {
+	case UserOnly:
+		return kind == KindUser
+	case GuestOnly:
+		return kind == KindGuest
+	case GuestAllowed:
+		return kind == KindUser || kind == KindGuest
+	default:
+		return false
+	}
+}
+
+// SessionChecker reports whether the session behind a token can still be used.
+type SessionChecker interface {
+	IsSessionActive(ctx context.Context, sessionID, kind string) (bool, error)
+}
+
+// Principal is the authenticated caller attached to the request.
+type Principal struct {
+	AccountID string // empty for guest
+	SessionID string
+	Kind      string
+	Roles     []string // from the access token, empty for guest
+}
+
+func (p *Principal) IsGuest() bool { return p.Kind == KindGuest }
+
+type AuthMiddleware struct {
+	keys     *security.KeySet
+	sessions SessionChecker
+}
+
+func NewAuthMiddleware(keys *security.KeySet, sessions SessionChecker) *AuthMiddleware {
+	return &AuthMiddleware{keys, sessions}
+}
+
+// Require authenticates the bearer token and enforces the route policy.
+func (m *AuthMiddleware) Require(policy Policy) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, ok := bearerToken(c.Get(fiber.HeaderAuthorization))
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(response.Base{Message: "Missing or malformed bearer token."})
+		}
+
+		claims, err := m.keys.ParseAccessToken(token, KindUser, KindGuest)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(response.Base{Message: "Invalid or expired token."})
+		}
+
+		if claims.SessionID == "" || (claims.Kind == KindUser && claims.Sub == "") {
+			return c.Status(fiber.StatusUnauthorized).JSON(response.Base{Message: "Invalid or expired token."})
+		}
+
+		active, err := m.sessions.IsSessionActive(c.Context(), claims.SessionID, claims.Kind)
+		if err != nil {
+			slog.Error("auth: session lookup failed", slog.String("sid", claims.SessionID), slog.String("err", err.Error()))
+			return err
+		}
+		if !active {
+			return c.Status(fiber.StatusUnauthorized).JSON(response.Base{Message: "Session has been revoked or expired."})
+		}
+
+		if !policy.allows(claims.Kind) {
+			return c.Status(fiber.StatusForbidden).JSON(response.Base{Message: "You are not allowed to access this resource."})
+		}
+
+		c.Locals(principalKey, &Principal{
+			AccountID: claims.Sub,
+			SessionID: claims.SessionID,
+			Kind:      claims.Kind,
+			Roles:     claims.Roles,
+		})
+
+		return c.Next()
+	}
+}
+
+// GetPrincipal returns the caller set by Require, or nil on unauthenticated routes.
+func GetPrincipal(c *fiber.Ctx) *Principal {
+	p, _ := c.Locals(principalKey).(*Principal)
+	return p
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"haphap/swimo-api/pkg/response"
+	"io"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// MediaPrefix holds routes that upload or stream files. They apply their own body
+// limits and caching headers, the shared cache and ETag middlewares skip them.
+const MediaPrefix = "/api/v1/media/"
+
+func IsMediaRequest(c *fiber.Ctx) bool {
+	return strings.HasPrefix(c.Path(), MediaPrefix)
+}
+
+// BodyLimit enforces the global request body limit. The server streams bodies larger
+// than its BodyLimit instead of rejecting them, so media uploads can go further.
+func BodyLimit(max int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if IsMediaRequest(c) || !c.Request().IsBodyStream() {
+			return c.Next()
+		}
+
+		length := c.Request().Header.ContentLength()
+		if length > max {
+			// the unread rest of the body would be parsed as the next request
+			c.Context().SetConnectionClose()
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(response.Base{Message: "Request body is too large."})
+		}
+
+		// chunked bodies have no length, read them up to the limit
+		if length < 0 {
+			body, err := io.ReadAll(io.LimitReader(c.Context().RequestBodyStream(), int64(max)+1))
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(response.Base{Message: "Invalid request body."})
+			}
+			if len(body) > max {
+				return c.Status(fiber.StatusRequestEntityTooLarge).JSON(response.Base{Message: "Request body is too large."})
+			}
+			c.Request().SetBody(body)
+		}
+
+		return c.Next()
+	}
+}
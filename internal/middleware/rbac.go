@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"haphap/swimo-api/pkg/rbac"
+	"haphap/swimo-api/pkg/response"
+	"haphap/swimo-api/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CoachLinks reports whether a coach has been linked to a swimmer.
+type CoachLinks interface {
+	IsCoachOf(ctx context.Context, coachAccountID, swimmerAccountID string) (bool, error)
+}
+
+func (p *Principal) Can(perm rbac.Permission) bool {
+	return p != nil && rbac.Can(p.Roles, perm)
+}
+
+// RequirePermission must be chained after Require(UserOnly). Roles come from the access
+// token, revoking a role revokes the sessions so it is never trusted longer than one token.
+func RequirePermission(perm rbac.Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		principal := GetPrincipal(c)
+		if principal == nil || principal.Kind != KindUser {
+			return c.Status(fiber.StatusUnauthorized).JSON(response.Base{Message: "Unauthorized"})
+		}
+
+		if !principal.Can(perm) {
+			return c.Status(fiber.StatusForbidden).JSON(response.Base{Message: "You are not allowed to access this resource."})
+		}
+
+		return c.Next()
+	}
+}
+
+// CanActOn reports whether the caller may act on the data of accountID: their own data,
+// any swimmer they coach when their role grants perm, or everything for admins.
+func CanActOn(ctx context.Context, links CoachLinks, principal *Principal, accountID string, perm rbac.Permission) (bool, error) {
+	if principal == nil || principal.Kind != KindUser || !validator.UUIDPattern.MatchString(accountID) {
+		return false, nil
+	}
+
+	switch {
+	case principal.AccountID == accountID:
+		return true, nil
+	case rbac.HasRole(principal.Roles, rbac.RoleAdmin):
+		return true, nil
+	case principal.Can(perm):
+		return links.IsCoachOf(ctx, principal.AccountID, accountID)
+	default:
+		return false, nil
+	}
+}
+
+// RequireActOn guards routes scoped to another account, ex: /swimmers/:accountId/workouts.
+// perm is what a coach needs, PermViewSwimmers to read and PermManageSwimmers to write.
+func RequireActOn(links CoachLinks, param string, perm rbac.Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		allowed, err := CanActOn(c.Context(), links, GetPrincipal(c), c.Params(param), perm)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return c.Status(fiber.StatusForbidden).JSON(response.Base{Message: "You are not allowed to access this resource."})
+		}
+
+		return c.Next()
+	}
+}
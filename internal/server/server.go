@@ -1,6 +1,7 @@
 package server
 
 import (
+	"bytes"
 	"haphap/swimo-api/config"
 	"haphap/swimo-api/internal/middleware"
 	"haphap/swimo-api/pkg/response"
@@ -17,6 +18,7 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/valyala/fasthttp"
 )
 
 type Server struct {
@@ -48,8 +50,24 @@ func NewServer(cfg *config.Config) *Server {
 				Message: httpStatusMessage(code),
 			})
 		},
+
+		// Larger bodies reach the handlers as a stream, middleware.BodyLimit rejects
+		// them everywhere but on media uploads, which enforce their own limits
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
 	})
 
+	// Media routes move large files, give them time beyond the global timeouts
+	app.Server().HeaderReceived = func(header *fasthttp.RequestHeader) fasthttp.RequestConfig {
+		if !bytes.HasPrefix(header.RequestURI(), []byte(middleware.MediaPrefix)) {
+			return fasthttp.RequestConfig{}
+		}
+		return fasthttp.RequestConfig{
+			ReadTimeout:  cfg.HTTP.MediaTimeout,
+			WriteTimeout: cfg.HTTP.MediaTimeout,
+		}
+	}
+
 	// Middlewares
 	app.Use(recover.New(recover.Config{
 		EnableStackTrace: true,
@@ -57,6 +75,8 @@ func NewServer(cfg *config.Config) *Server {
 
 	app.Use(requestid.New())
 
+	app.Use(middleware.BodyLimit(cfg.HTTP.BodyLimitBytes))
+
 	// IP-based rate limiting
 	app.Use(limiter.New(limiter.Config{
 		Max:        20,
@@ -83,7 +103,10 @@ func NewServer(cfg *config.Config) *Server {
 
 	// ETag
 	if cfg.HTTP.EnableETag {
-		app.Use(etag.New())
+		app.Use(etag.New(etag.Config{
+			// media handlers tag files themselves, hashing would buffer whole videos
+			Next: middleware.IsMediaRequest,
+		}))
 	}
 
 	// Compression
@@ -93,6 +116,10 @@ func NewServer(cfg *config.Config) *Server {
 
 	// Cache with revalidation
 	app.Use(cache.New(cache.Config{
+		// Never share authenticated responses between callers
+		Next: func(c *fiber.Ctx) bool {
+			return c.Get(fiber.HeaderAuthorization) != "" || middleware.IsMediaRequest(c)
+		},
 		Expiration:   600 * time.Second, // Cache TTL set to 600 seconds (10 minutes)
 		CacheControl: true,              // Automatically sets Cache-Control header
 	}))
@@ -134,6 +161,8 @@ func httpStatusMessage(code int) string {
 		return "Too Many Requests"
 	case fiber.StatusBadRequest:
 		return "Bad Request"
+	case fiber.StatusRequestEntityTooLarge:
+		return "Request Entity Too Large"
 	case fiber.StatusUnprocessableEntity:
 		return "Unprocessable Entity"
 	default:
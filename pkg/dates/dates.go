@@ -0,0 +1,35 @@
+package dates
+
+import "time"
+
+// Layout is the calendar date format used by the API (ISO 8601).
+const Layout = "2006-01-02"
+
+func Parse(s string) (time.Time, error) {
+	return time.Parse(Layout, s)
+}
+
+// Age returns the completed years between birth and now.
+func Age(birth, now time.Time) int {
+	years := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+		years--
+	}
+	return years
+}
+
+// AgePtr is Age for optional birth dates, nil stays nil.
+func AgePtr(birth *time.Time) *int {
+	if birth == nil {
+		return nil
+	}
+
+	age := Age(*birth, time.Now())
+	return &age
+}
+
+// WeekStart returns the Monday of t's ISO week, at midnight.
+func WeekStart(t time.Time) time.Time {
+	days := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-days, 0, 0, 0, 0, t.Location())
+}
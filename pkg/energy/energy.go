@@ -0,0 +1,181 @@
+// Package energy estimates calories burned swimming from MET values.
+//
+// A MET (metabolic equivalent) is the energy spent relative to sitting still,
+// 1 MET burns about 1 kcal per kg of body weight per hour. The MET of a swim
+// depends on the stroke and on the intensity, which is read from the pace
+// relative to the swimmer's threshold pace (seconds per 100m at threshold).
+package energy
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"haphap/swimo-api/pkg/swim"
+	"os"
+)
+
+//go:embed met.json
+var defaultTable []byte
+
+var (
+	ErrUnknownStroke    = errors.New("energy: unknown stroke")
+	ErrUnknownIntensity = errors.New("energy: unknown intensity")
+)
+
+type (
+	// Table is the tunable part of the estimate, see met.json for the defaults.
+	Table struct {
+		RestMET          float64           `json:"restMet"`          // between repetitions
+		DefaultIntensity string            `json:"defaultIntensity"` // when the pace or the threshold is unknown
+		Intensities      []Intensity       `json:"intensities"`      // fastest first
+		Strokes          map[string]Stroke `json:"strokes"`
+	}
+
+	// Intensity applies up to a pace ratio (pace / threshold pace, lower is faster),
+	// the last one has no bound.
+	Intensity struct {
+		Name         string  `json:"name"`
+		MaxPaceRatio float64 `json:"maxPaceRatio"`
+	}
+
+	Stroke struct {
+		// PaceFactor scales the threshold pace, which is a freestyle pace,
+		// ex: 1.25 when breaststroke at threshold is 25% slower.
+		PaceFactor float64            `json:"paceFactor"`
+		MET        map[string]float64 `json:"met"` // by intensity name
+	}
+)
+
+// Default returns the embedded table.
+func Default() *Table {
+	table, err := Parse(defaultTable)
+	if err != nil {
+		panic("energy: embedded table: " + err.Error())
+	}
+	return table
+}
+
+// Load reads a table from path, the embedded one when path is empty.
+func Load(path string) (*Table, error) {
+	if path == "" {
+		return Default(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return table, nil
+}
+
+func Parse(data []byte) (*Table, error) {
+	var table Table
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, err
+	}
+
+	if err := table.validate(); err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+// validate makes sure every known stroke is in the table with a MET for every intensity,
+// and that no stroke of the table has a pace factor or MET that is not positive.
+func (t *Table) validate() error {
+	if t.RestMET <= 0 {
+		return errors.New("restMet must be positive")
+	}
+	if len(t.Intensities) == 0 {
+		return errors.New("at least one intensity is required")
+	}
+
+	names := make(map[string]bool, len(t.Intensities))
+	for i, intensity := range t.Intensities {
+		if intensity.Name == "" || names[intensity.Name] {
+			return fmt.Errorf("intensity %d: name is empty or repeated", i)
+		}
+		last := i == len(t.Intensities)-1
+		if !last && (intensity.MaxPaceRatio <= 0 || (i > 0 && intensity.MaxPaceRatio <= t.Intensities[i-1].MaxPaceRatio)) {
+			return fmt.Errorf("intensity %q: maxPaceRatio must be positive and increasing", intensity.Name)
+		}
+		names[intensity.Name] = true
+	}
+	if !names[t.DefaultIntensity] {
+		return fmt.Errorf("defaultIntensity %q is not an intensity", t.DefaultIntensity)
+	}
+
+	for _, name := range swim.Strokes() {
+		if _, ok := t.Strokes[name]; !ok {
+			return fmt.Errorf("stroke %q is missing", name)
+		}
+	}
+
+	for name, stroke := range t.Strokes {
+		if stroke.PaceFactor <= 0 {
+			return fmt.Errorf("stroke %q: paceFactor must be positive", name)
+		}
+		for intensity := range names {
+			if stroke.MET[intensity] <= 0 {
+				return fmt.Errorf("stroke %q: MET of %q must be positive", name, intensity)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Intensity returns the intensity of swimming stroke at paceSec per 100m.
+// An unknown pace or threshold (<= 0) gives the default intensity.
+func (t *Table) Intensity(stroke string, paceSec, thresholdPaceSec float64) (string, error) {
+	s, ok := t.Strokes[stroke]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownStroke, stroke)
+	}
+
+	if paceSec <= 0 || thresholdPaceSec <= 0 {
+		return t.DefaultIntensity, nil
+	}
+
+	ratio := paceSec / (thresholdPaceSec * s.PaceFactor)
+	for _, intensity := range t.Intensities[:len(t.Intensities)-1] {
+		if ratio <= intensity.MaxPaceRatio {
+			return intensity.Name, nil
+		}
+	}
+	return t.Intensities[len(t.Intensities)-1].Name, nil
+}
+
+// MET returns the MET of stroke at intensity.
+func (t *Table) MET(stroke, intensity string) (float64, error) {
+	s, ok := t.Strokes[stroke]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownStroke, stroke)
+	}
+
+	met, ok := s.MET[intensity]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownIntensity, intensity)
+	}
+	return met, nil
+}
+
+// SwimMET returns the MET of stroke swum at paceSec per 100m, see Intensity.
+func (t *Table) SwimMET(stroke string, paceSec, thresholdPaceSec float64) (float64, error) {
+	intensity, err := t.Intensity(stroke, paceSec, thresholdPaceSec)
+	if err != nil {
+		return 0, err
+	}
+	return t.MET(stroke, intensity)
+}
+
+// Kcal is the energy spent at met for seconds by someone weighing weightKG.
+func Kcal(met, weightKG, seconds float64) float64 {
+	return met * weightKG * seconds / 3600
+}
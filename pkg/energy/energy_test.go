@@ -0,0 +1,131 @@
+package energy
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// defaultWith parses the embedded table after edit changed its JSON.
+func defaultWith(t *testing.T, edit func(table map[string]any)) error {
+	t.Helper()
+
+	var table map[string]any
+	if err := json.Unmarshal(defaultTable, &table); err != nil {
+		t.Fatal(err)
+	}
+	edit(table)
+
+	data, err := json.Marshal(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Parse(data)
+	return err
+}
+
+func TestParseRejects(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(table map[string]any)
+	}{
+		{
+			name: "missing stroke",
+			edit: func(table map[string]any) { delete(table["strokes"].(map[string]any), "kick") },
+		},
+		{
+			name: "zero pace factor",
+			edit: func(table map[string]any) {
+				table["strokes"].(map[string]any)["breaststroke"].(map[string]any)["paceFactor"] = 0
+			},
+		},
+		{
+			name: "missing pace factor on an extra stroke",
+			edit: func(table map[string]any) {
+				table["strokes"].(map[string]any)["sidestroke"] = map[string]any{
+					"met": map[string]any{"max": 8, "hard": 7, "moderate": 6, "easy": 5},
+				}
+			},
+		},
+		{
+			name: "missing MET",
+			edit: func(table map[string]any) {
+				delete(table["strokes"].(map[string]any)["butterfly"].(map[string]any)["met"].(map[string]any), "easy")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := defaultWith(t, tt.edit); err == nil {
+				t.Fatal("Parse accepted the table")
+			}
+		})
+	}
+
+	if err := defaultWith(t, func(map[string]any) {}); err != nil {
+		t.Fatalf("Parse rejected the embedded table: %v", err)
+	}
+}
+
+func TestIntensity(t *testing.T) {
+	table := Default()
+
+	tests := []struct {
+		name      string
+		stroke    string
+		pace      float64
+		threshold float64
+		want      string
+	}{
+		{"faster than threshold", "freestyle", 95, 100, "max"},
+		{"at threshold", "freestyle", 100, 100, "hard"},
+		{"aerobic", "freestyle", 110, 100, "moderate"},
+		{"slower than every bound", "freestyle", 130, 100, "easy"},
+		{"scaled by the stroke pace factor", "breaststroke", 125, 100, "hard"},
+		{"unknown pace", "freestyle", 0, 100, "moderate"},
+		{"unknown threshold", "butterfly", 90, 0, "moderate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := table.Intensity(tt.stroke, tt.pace, tt.threshold)
+			if err != nil {
+				t.Fatalf("Intensity: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Intensity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := table.Intensity("sidestroke", 100, 100); !errors.Is(err, ErrUnknownStroke) {
+		t.Fatalf("Intensity of an unknown stroke: err = %v, want %v", err, ErrUnknownStroke)
+	}
+}
+
+func TestMET(t *testing.T) {
+	table := Default()
+
+	met, err := table.MET("freestyle", "hard")
+	if err != nil {
+		t.Fatalf("MET: %v", err)
+	}
+	if met != 8.3 {
+		t.Fatalf("MET() = %v, want 8.3", met)
+	}
+
+	if _, err := table.MET("sidestroke", "hard"); !errors.Is(err, ErrUnknownStroke) {
+		t.Fatalf("MET of an unknown stroke: err = %v, want %v", err, ErrUnknownStroke)
+	}
+	if _, err := table.MET("freestyle", "sprint"); !errors.Is(err, ErrUnknownIntensity) {
+		t.Fatalf("MET of an unknown intensity: err = %v, want %v", err, ErrUnknownIntensity)
+	}
+}
+
+func TestKcal(t *testing.T) {
+	if got := Kcal(7, 60, 1800); got != 210 {
+		t.Fatalf("Kcal() = %v, want 210", got)
+	}
+}
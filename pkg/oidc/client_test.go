@@ -0,0 +1,241 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID = "swimo-test"
+	testKeyID    = "test-key"
+	testCode     = "auth-code"
+)
+
+// mockProvider is a minimal OpenID provider: discovery, jwks and a token endpoint checking PKCE.
+type mockProvider struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	issuer    string // advertised in the discovery document
+	challenge string // code_challenge sent to the authorization endpoint
+	omitKid   bool   // sign ID tokens without a kid header
+	claims    func(p *mockProvider) jwt.MapClaims
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &mockProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, discoveryDocument{
+			Issuer:                p.issuer,
+			AuthorizationEndpoint: p.server.URL + "/authorize",
+			TokenEndpoint:         p.server.URL + "/token",
+			JWKSURI:               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, jsonWebKeySet{Keys: []jsonWebKey{{
+			Kty: "RSA",
+			Kid: testKeyID,
+			Use: "sig",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			writeJSON(w, http.StatusBadRequest, tokenResponse{Error: "invalid_request"})
+			return
+		}
+		if r.PostForm.Get("code") != testCode || CodeChallengeS256(r.PostForm.Get("code_verifier")) != p.challenge {
+			writeJSON(w, http.StatusBadRequest, tokenResponse{Error: "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, p.claims(p))
+		if !p.omitKid {
+			token.Header["kid"] = testKeyID
+		}
+		raw, err := token.SignedString(key)
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, tokenResponse{Error: "server_error"})
+			return
+		}
+		writeJSON(w, http.StatusOK, tokenResponse{AccessToken: "opaque", IDToken: raw})
+	})
+
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	p.issuer = p.server.URL
+	p.claims = func(p *mockProvider) jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            p.issuer,
+			"sub":            "provider-user",
+			"aud":            testClientID,
+			"exp":            time.Now().Add(time.Minute).Unix(),
+			"iat":            time.Now().Unix(),
+			"email":          "swimmer@example.com",
+			"email_verified": "true",
+			"nonce":          "the-nonce",
+		}
+	}
+
+	return p
+}
+
+func (p *mockProvider) client() *Client {
+	return NewClient(ProviderConfig{
+		Name:        "mock",
+		Issuer:      p.server.URL,
+		ClientID:    testClientID,
+		RedirectURL: "https://swimo.test/callback",
+	}, p.server.Client())
+}
+
+// authorize goes through AuthCodeURL like a browser would and records the PKCE challenge.
+func (p *mockProvider) authorize(t *testing.T, c *Client, verifier string) {
+	t.Helper()
+
+	authURL, err := c.AuthCodeURL(context.Background(), "the-state", "the-nonce", verifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := u.Query().Get("code_challenge_method"); got != "S256" {
+		t.Fatalf("code_challenge_method = %q, want S256", got)
+	}
+	p.challenge = u.Query().Get("code_challenge")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestExchange(t *testing.T) {
+	p := newMockProvider(t)
+	c := p.client()
+	p.authorize(t, c, "the-verifier")
+
+	claims, err := c.Exchange(context.Background(), testCode, "the-verifier", "the-nonce")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if claims.Subject != "provider-user" || claims.Email != "swimmer@example.com" || !bool(claims.EmailVerified) {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+}
+
+func TestExchangeWithoutKeyID(t *testing.T) {
+	p := newMockProvider(t)
+	p.omitKid = true
+	c := p.client()
+
+	// the second exchange reads the key from the cache
+	for i := range 2 {
+		p.authorize(t, c, "the-verifier")
+		if _, err := c.Exchange(context.Background(), testCode, "the-verifier", "the-nonce"); err != nil {
+			t.Fatalf("Exchange %d: %v", i+1, err)
+		}
+	}
+}
+
+func TestExchangeRequiresCodeVerifier(t *testing.T) {
+	p := newMockProvider(t)
+	c := p.client()
+	p.authorize(t, c, "the-verifier")
+
+	if _, err := c.Exchange(context.Background(), testCode, "another-verifier", "the-nonce"); err == nil {
+		t.Fatal("Exchange accepted a code verifier that does not match the challenge")
+	}
+}
+
+func TestExchangeRejectsIDToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		nonce   string
+		mutate  func(claims jwt.MapClaims)
+		wantErr error
+	}{
+		{
+			name:    "nonce",
+			nonce:   "another-nonce",
+			wantErr: ErrNonceMismatch,
+		},
+		{
+			name:    "issuer",
+			nonce:   "the-nonce",
+			mutate:  func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" },
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name:    "audience",
+			nonce:   "the-nonce",
+			mutate:  func(claims jwt.MapClaims) { claims["aud"] = "another-client" },
+			wantErr: ErrInvalidIDToken,
+		},
+		{
+			name:    "expired",
+			nonce:   "the-nonce",
+			mutate:  func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+			wantErr: ErrInvalidIDToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newMockProvider(t)
+			if tt.mutate != nil {
+				base := p.claims
+				p.claims = func(p *mockProvider) jwt.MapClaims {
+					claims := base(p)
+					tt.mutate(claims)
+					return claims
+				}
+			}
+
+			c := p.client()
+			p.authorize(t, c, "the-verifier")
+
+			_, err := c.Exchange(context.Background(), testCode, "the-verifier", tt.nonce)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Exchange error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiscoveryRejectsOtherIssuer(t *testing.T) {
+	p := newMockProvider(t)
+	p.issuer = "https://evil.example.com"
+
+	_, err := p.client().AuthCodeURL(context.Background(), "the-state", "the-nonce", "the-verifier")
+	if !errors.Is(err, ErrIssuerMismatch) {
+		t.Fatalf("AuthCodeURL error = %v, want %v", err, ErrIssuerMismatch)
+	}
+}
@@ -0,0 +1,59 @@
+package rbac
+
+import "slices"
+
+// Role is stored in account_roles and embedded in access tokens.
+type Role = string
+
+// Permission is what routes require, roles are only a way to grant them.
+type Permission string
+
+const (
+	RoleSwimmer Role = "swimmer"
+	RoleCoach   Role = "coach"
+	RoleAdmin   Role = "admin"
+)
+
+const (
+	PermManageOwnData  Permission = "self:manage"     // own profile, workouts, plans
+	PermViewSwimmers   Permission = "swimmers:read"   // data of linked swimmers
+	PermManageSwimmers Permission = "swimmers:manage" // assign plans, edit linked swimmers' workouts
+	PermManageContent  Permission = "content:manage"  // tutorials, programs, reference data
+	PermManageAccounts Permission = "accounts:manage" // moderation, roles, coach links
+	PermManageConfig   Permission = "config:manage"   // runtime app config
+	PermViewAuditLogs  Permission = "audit_logs:read"
+)
+
+var grants = map[Role][]Permission{
+	RoleSwimmer: {PermManageOwnData},
+	RoleCoach:   {PermManageOwnData, PermViewSwimmers, PermManageSwimmers},
+	RoleAdmin: {
+		PermManageOwnData, PermViewSwimmers, PermManageSwimmers, PermManageContent,
+		PermManageAccounts, PermManageConfig, PermViewAuditLogs,
+	},
+}
+
+// Roles lists every known role, in privilege order.
+func Roles() []Role {
+	return []Role{RoleSwimmer, RoleCoach, RoleAdmin}
+}
+
+func IsValidRole(role string) bool {
+	_, ok := grants[role]
+	return ok
+}
+
+// Can reports whether any of the roles grants perm.
+func Can(roles []Role, perm Permission) bool {
+	for _, role := range roles {
+		if slices.Contains(grants[role], perm) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func HasRole(roles []Role, role Role) bool {
+	return slices.Contains(roles, role)
+}
@@ -11,3 +11,29 @@ type ValidationError struct {
 	Message string `json:"message,omitempty"`
 	Errors  any    `json:"errors,omitempty"`
 }
+
+type Page struct {
+	Items  any `json:"items"`
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// NormalizePage clamps limit/offset query parameters to sane values.
+func NormalizePage(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
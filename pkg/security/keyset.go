@@ -0,0 +1,320 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrUnknownKey     = errors.New("unknown signing key")
+	ErrUnsupportedKey = errors.New("unsupported signing key")
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+
+	// LegacyKeyID names the HS256 key built from JWT_SECRET, tokens without a kid are checked against it.
+	LegacyKeyID = "default"
+
+	// Access tokens and MFA challenges share the keys, the typ header and the audience
+	// keep a verifier that only checks the signature from taking one for the other.
+	TypeAccess     = "at+jwt"
+	TypeMFA        = "mfa+jwt"
+	TypeReauth     = "reauth+jwt"
+	AudienceAccess = "swimo-api"
+	AudienceMFA    = "swimo-mfa"
+	AudienceReauth = "swimo-reauth"
+
+	KindMFA    = "mfa"
+	KindReauth = "reauth"
+)
+
+type (
+	// SigningKey is one entry of a KeySet. HS256 keys hold a shared secret and are never published.
+	SigningKey struct {
+		ID        string
+		Alg       string
+		RetiredAt *time.Time // retired keys stop signing but keep verifying older tokens
+
+		secret  []byte
+		private crypto.Signer
+	}
+
+	// KeySet signs access tokens with its active key and verifies tokens of every known key.
+	KeySet struct {
+		active *SigningKey
+		keys   map[string]*SigningKey
+		grace  time.Duration // longest token lifetime, retired keys are dropped after it
+	}
+
+	// JWK is the public part of a signing key (RFC 7517).
+	JWK struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		Alg string `json:"alg"`
+		N   string `json:"n,omitempty"`
+		E   string `json:"e,omitempty"`
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+	}
+
+	JWKS struct {
+		Keys []JWK `json:"keys"`
+	}
+)
+
+func NewHMACKey(id string, secret []byte) (*SigningKey, error) {
+	if len(secret) < 32 {
+		return nil, fmt.Errorf("%w: hmac secret %q must be at least 32 bytes", ErrUnsupportedKey, id)
+	}
+
+	return &SigningKey{ID: id, Alg: AlgHS256, secret: secret}, nil
+}
+
+// ParsePrivateKeyPEM loads an RSA (RS256) or Ed25519 (EdDSA) private key in PKCS#1 or PKCS#8 form.
+func ParsePrivateKeyPEM(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: key %q is not PEM encoded", ErrUnsupportedKey, id)
+	}
+
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %q: %w", id, err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < 2048 {
+			return nil, fmt.Errorf("%w: rsa key %q must be at least 2048 bits", ErrUnsupportedKey, id)
+		}
+		return &SigningKey{ID: id, Alg: AlgRS256, private: k}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{ID: id, Alg: AlgEdDSA, private: k}, nil
+	default:
+		return nil, fmt.Errorf("%w: key %q has type %T", ErrUnsupportedKey, id, key)
+	}
+}
+
+func (k *SigningKey) method() jwt.SigningMethod {
+	switch k.Alg {
+	case AlgRS256:
+		return jwt.SigningMethodRS256
+	case AlgEdDSA:
+		return jwt.SigningMethodEdDSA
+	default:
+		return jwt.SigningMethodHS256
+	}
+}
+
+func (k *SigningKey) signingKey() any {
+	if k.secret != nil {
+		return k.secret
+	}
+
+	return k.private
+}
+
+func (k *SigningKey) verifyKey() any {
+	if k.secret != nil {
+		return k.secret
+	}
+
+	return k.private.Public()
+}
+
+// NewKeySet builds a key set signing with activeID. grace should be the longest
+// lifetime of a token signed by the set (access token or MFA challenge).
+func NewKeySet(activeID string, grace time.Duration, keys ...*SigningKey) (*KeySet, error) {
+	s := &KeySet{keys: make(map[string]*SigningKey, len(keys)), grace: grace}
+
+	for _, k := range keys {
+		if _, dup := s.keys[k.ID]; dup {
+			return nil, fmt.Errorf("duplicate signing key %q", k.ID)
+		}
+		s.keys[k.ID] = k
+	}
+
+	active, ok := s.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("%w: active key %q is not configured", ErrUnknownKey, activeID)
+	}
+	if active.RetiredAt != nil {
+		return nil, fmt.Errorf("active key %q is retired", activeID)
+	}
+	s.active = active
+
+	return s, nil
+}
+
+// NewAccessToken signs the claims with the active key and stamps its kid in the header.
+func (s *KeySet) NewAccessToken(kind string, accountID, sessionID string, roles []string, ttl time.Duration) (token string, exp time.Time, err error) {
+	return s.sign(TypeAccess, AudienceAccess, Claims{
+		Kind:      kind,
+		SessionID: sessionID,
+		Sub:       accountID,
+		Roles:     roles,
+	}, ttl)
+}
+
+// NewMFAToken signs an MFA challenge: it only proves the password step of accountID.
+func (s *KeySet) NewMFAToken(accountID string, ttl time.Duration) (token string, exp time.Time, err error) {
+	return s.sign(TypeMFA, AudienceMFA, Claims{Kind: KindMFA, Sub: accountID}, ttl)
+}
+
+// ParseAccessToken verifies the signature and expiry of an access token and returns its claims.
+// kinds lists the token kinds the caller accepts, any other kind is rejected.
+func (s *KeySet) ParseAccessToken(token string, kinds ...string) (*Claims, error) {
+	claims, err := s.parse(token, TypeAccess, AudienceAccess)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(kinds, claims.Kind) {
+		return nil, fmt.Errorf("%w: unexpected kind %q", ErrInvalidToken, claims.Kind)
+	}
+
+	return claims, nil
+}
+
+// ParseMFAToken verifies an MFA challenge minted by NewMFAToken.
+func (s *KeySet) ParseMFAToken(token string) (*Claims, error) {
+	claims, err := s.parse(token, TypeMFA, AudienceMFA)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Kind != KindMFA || claims.Sub == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// NewReauthToken proves accountID just signed in again from sessionID, for accounts without a password.
+func (s *KeySet) NewReauthToken(accountID, sessionID string, ttl time.Duration) (token string, exp time.Time, err error) {
+	return s.sign(TypeReauth, AudienceReauth, Claims{Kind: KindReauth, Sub: accountID, SessionID: sessionID}, ttl)
+}
+
+// ParseReauthToken verifies a token minted by NewReauthToken.
+func (s *KeySet) ParseReauthToken(token string) (*Claims, error) {
+	claims, err := s.parse(token, TypeReauth, AudienceReauth)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Kind != KindReauth || claims.Sub == "" || claims.SessionID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+func (s *KeySet) sign(typ, audience string, claims Claims, ttl time.Duration) (token string, exp time.Time, err error) {
+	now := time.Now()
+	exp = now.Add(ttl)
+
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		Audience:  jwt.ClaimStrings{audience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(exp),
+	}
+
+	t := jwt.NewWithClaims(s.active.method(), claims)
+	t.Header["kid"] = s.active.ID
+	t.Header["typ"] = typ
+	token, err = t.SignedString(s.active.signingKey())
+	return
+}
+
+// parse verifies the signature, expiry, typ and audience of a token. Tokens of a
+// retired key are accepted only if they were issued before the retirement.
+func (s *KeySet) parse(token, typ, audience string) (*Claims, error) {
+	now := time.Now()
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		if got, _ := t.Header["typ"].(string); got != typ {
+			return nil, jwt.ErrTokenMalformed
+		}
+
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			kid = LegacyKeyID
+		}
+
+		key, ok := s.keys[kid]
+		if !ok || !s.verifies(key, now) {
+			return nil, ErrUnknownKey
+		}
+		if t.Method.Alg() != key.Alg {
+			return nil, jwt.ErrTokenSignatureInvalid
+		}
+		if key.RetiredAt != nil && (claims.IssuedAt == nil || claims.IssuedAt.After(*key.RetiredAt)) {
+			return nil, ErrUnknownKey
+		}
+
+		return key.verifyKey(), nil
+	}, jwt.WithValidMethods([]string{AlgHS256, AlgRS256, AlgEdDSA}), jwt.WithExpirationRequired(), jwt.WithAudience(audience))
+	if err != nil {
+		return nil, errors.Join(ErrInvalidToken, err)
+	}
+
+	return claims, nil
+}
+
+func (s *KeySet) verifies(k *SigningKey, now time.Time) bool {
+	return k.RetiredAt == nil || now.Before(k.RetiredAt.Add(s.grace))
+}
+
+// JWKS returns the public keys that can still verify tokens. HS256 keys are never published.
+func (s *KeySet) JWKS() JWKS {
+	now := time.Now()
+
+	out := JWKS{Keys: []JWK{}}
+	for _, k := range s.keys {
+		if k.secret != nil || !s.verifies(k, now) {
+			continue
+		}
+
+		jwk := JWK{Kid: k.ID, Use: "sig", Alg: k.Alg}
+		switch pub := k.private.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+
+		out.Keys = append(out.Keys, jwk)
+	}
+	sort.Slice(out.Keys, func(i, j int) bool { return out.Keys[i].Kid < out.Keys[j].Kid })
+
+	return out
+}
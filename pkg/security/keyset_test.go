@@ -0,0 +1,93 @@
+package security
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestKeySet(t *testing.T) *KeySet {
+	t.Helper()
+
+	key, err := NewHMACKey(LegacyKeyID, []byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := NewKeySet(LegacyKeyID, time.Hour, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func TestMFATokenIsNotAnAccessToken(t *testing.T) {
+	keys := newTestKeySet(t)
+
+	mfa, _, err := keys.NewMFAToken("account-1", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keys.ParseAccessToken(mfa, "user", "guest", KindMFA); err == nil {
+		t.Fatal("mfa challenge accepted as an access token")
+	}
+
+	claims, err := keys.ParseMFAToken(mfa)
+	if err != nil {
+		t.Fatalf("ParseMFAToken: %v", err)
+	}
+	if claims.Sub != "account-1" {
+		t.Fatalf("sub = %q, want account-1", claims.Sub)
+	}
+}
+
+func TestAccessTokenKinds(t *testing.T) {
+	keys := newTestKeySet(t)
+
+	access, _, err := keys.NewAccessToken("user", "account-1", "session-1", nil, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := keys.ParseMFAToken(access); err == nil {
+		t.Fatal("access token accepted as an mfa challenge")
+	}
+	if _, err := keys.ParseAccessToken(access, "guest"); err == nil {
+		t.Fatal("user token accepted where only guests are expected")
+	}
+	if _, err := keys.ParseAccessToken(access, "user", "guest"); err != nil {
+		t.Fatalf("ParseAccessToken: %v", err)
+	}
+}
+
+func TestRetiredLegacyKey(t *testing.T) {
+	legacy := newTestKeySet(t)
+	token, _, err := legacy.NewAccessToken("user", "account-1", "session-1", nil, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotated := func(grace time.Duration) *KeySet {
+		old, err := NewHMACKey(LegacyKeyID, []byte("0123456789abcdef0123456789abcdef"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		retiredAt := time.Now()
+		old.RetiredAt = &retiredAt
+
+		active, err := NewHMACKey("2026-01", []byte("fedcba9876543210fedcba9876543210"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys, err := NewKeySet("2026-01", grace, active, old)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return keys
+	}
+
+	if _, err := rotated(time.Hour).ParseAccessToken(token, "user"); err != nil {
+		t.Fatalf("token signed before retirement rejected within the grace period: %v", err)
+	}
+	if _, err := rotated(time.Nanosecond).ParseAccessToken(token, "user"); err == nil {
+		t.Fatal("retired key still verifies after the grace period")
+	}
+}
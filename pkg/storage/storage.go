@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var ErrNotFound = errors.New("storage: object not found")
+
+// Object describes a stored file. Checksum is the hex sha256 of the content.
+type Object struct {
+	Key      string
+	Size     int64
+	Checksum string
+	ModTime  time.Time
+}
+
+// Storage keeps uploaded files, keys are slash separated paths like tutorials/<id>/video.
+type Storage interface {
+	// Put stores r under key, replacing any previous object once r is fully read.
+	// A failed read leaves the previous object untouched.
+	Put(ctx context.Context, key string, r io.Reader) (Object, error)
+	// Open returns a seekable reader, the caller closes it.
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, Object, error)
+	Delete(ctx context.Context, key string) error
+}
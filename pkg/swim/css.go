@@ -0,0 +1,58 @@
+package swim
+
+import "slices"
+
+// Threshold paces are seconds per 100m, the bounds match users.threshold_pace_sec.
+const (
+	MinThresholdPace = 30
+	MaxThresholdPace = 600
+)
+
+// CSSPace returns the Critical Swim Speed as a pace in seconds per 100m from a
+// 400m and a 200m time trial: the 200m between them divided by the time it took.
+func CSSPace(time400Sec, time200Sec float64) float64 {
+	return (time400Sec - time200Sec) / 2
+}
+
+const (
+	ZoneRecovery  = "recovery"
+	ZoneEndurance = "endurance"
+	ZoneThreshold = "threshold"
+	ZoneVO2Max    = "vo2max"
+)
+
+// Zone is a pace range relative to CSS in seconds per 100, positive is slower.
+type Zone struct {
+	Name       string
+	FastOffset float64
+	SlowOffset float64
+}
+
+// Target is the pace prescribed when a plan names the zone, its middle.
+func (z Zone) Target() float64 { return (z.FastOffset + z.SlowOffset) / 2 }
+
+var zones = []Zone{
+	{Name: ZoneRecovery, FastOffset: 12, SlowOffset: 20},
+	{Name: ZoneEndurance, FastOffset: 5, SlowOffset: 10},
+	{Name: ZoneThreshold, FastOffset: -1, SlowOffset: 3},
+	{Name: ZoneVO2Max, FastOffset: -6, SlowOffset: -2},
+}
+
+// Zones returns the pace zones, slowest first.
+func Zones() []Zone { return zones }
+
+func ZoneNames() []string {
+	names := make([]string, len(zones))
+	for i, zone := range zones {
+		names[i] = zone.Name
+	}
+	return names
+}
+
+func ZoneByName(name string) (Zone, bool) {
+	i := slices.IndexFunc(zones, func(z Zone) bool { return z.Name == name })
+	if i < 0 {
+		return Zone{}, false
+	}
+	return zones[i], true
+}
@@ -0,0 +1,53 @@
+package swim
+
+import "testing"
+
+func TestCSSPace(t *testing.T) {
+	tests := []struct {
+		name    string
+		time400 float64
+		time200 float64
+		want    float64
+	}{
+		{"whole seconds", 360, 170, 95},
+		{"fractions", 330.5, 155.1, 87.7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CSSPace(tt.time400, tt.time200); got < tt.want-1e-9 || got > tt.want+1e-9 {
+				t.Fatalf("CSSPace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZones(t *testing.T) {
+	all := Zones()
+	if len(all) != len(ZoneNames()) {
+		t.Fatalf("%d zones but %d names", len(all), len(ZoneNames()))
+	}
+
+	for i, zone := range all {
+		if zone.FastOffset >= zone.SlowOffset {
+			t.Fatalf("zone %q: fast offset %v is not faster than %v", zone.Name, zone.FastOffset, zone.SlowOffset)
+		}
+		if target := zone.Target(); target <= zone.FastOffset || target >= zone.SlowOffset {
+			t.Fatalf("zone %q: target %v is outside its range", zone.Name, target)
+		}
+		if i > 0 && zone.SlowOffset > all[i-1].FastOffset {
+			t.Fatalf("zone %q overlaps %q, zones go from slowest to fastest", zone.Name, all[i-1].Name)
+		}
+	}
+}
+
+func TestZoneByName(t *testing.T) {
+	zone, ok := ZoneByName(ZoneThreshold)
+	if !ok || zone.Target() != 1 {
+		t.Fatalf("ZoneByName(%q) = %+v, %v, want a target of CSS+1", ZoneThreshold, zone, ok)
+	}
+
+	if _, ok := ZoneByName("sprint"); ok {
+		t.Fatal("ZoneByName found an unknown zone")
+	}
+}
@@ -0,0 +1,60 @@
+package swim
+
+import "slices"
+
+const (
+	Pool25M       = "25m"
+	Pool50M       = "50m"
+	Pool25Yd      = "25yd"
+	PoolOpenWater = "open_water"
+)
+
+const (
+	StrokeFreestyle    = "freestyle"
+	StrokeBackstroke   = "backstroke"
+	StrokeBreaststroke = "breaststroke"
+	StrokeButterfly    = "butterfly"
+	StrokeIM           = "im"
+	StrokeKick         = "kick"
+	StrokePull         = "pull"
+	StrokeDrill        = "drill"
+	StrokeChoice       = "choice"
+)
+
+var poolLengthsM = map[string]float64{
+	Pool25M:  25,
+	Pool50M:  50,
+	Pool25Yd: 22.86,
+}
+
+var strokes = []string{
+	StrokeFreestyle, StrokeBackstroke, StrokeBreaststroke, StrokeButterfly,
+	StrokeIM, StrokeKick, StrokePull, StrokeDrill, StrokeChoice,
+}
+
+func IsValidPoolLength(pool string) bool {
+	_, ok := poolLengthsM[pool]
+	return ok || pool == PoolOpenWater
+}
+
+// PoolLengthM returns the length of one pool length in meters, false for open water.
+func PoolLengthM(pool string) (float64, bool) {
+	m, ok := poolLengthsM[pool]
+	return m, ok
+}
+
+func Strokes() []string { return strokes }
+
+func IsValidStroke(stroke string) bool { return slices.Contains(strokes, stroke) }
+
+const (
+	SkillBeginner     = "beginner"
+	SkillIntermediate = "intermediate"
+	SkillAdvanced     = "advanced"
+)
+
+var skillLevels = []string{SkillBeginner, SkillIntermediate, SkillAdvanced}
+
+func SkillLevels() []string { return skillLevels }
+
+func IsValidSkillLevel(level string) bool { return slices.Contains(skillLevels, level) }
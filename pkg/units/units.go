@@ -0,0 +1,106 @@
+package units
+
+import "math"
+
+// Values are stored in metric (kg, cm, meters) and converted at the API edge.
+
+const (
+	SystemMetric   = "metric"
+	SystemImperial = "imperial"
+
+	DistanceMeters = "m"
+	DistanceYards  = "yd"
+
+	kgPerLb     = 0.45359237
+	cmPerInch   = 2.54
+	metersPerYd = 0.9144
+)
+
+func IsValidSystem(s string) bool { return s == SystemMetric || s == SystemImperial }
+
+func IsValidDistance(s string) bool { return s == DistanceMeters || s == DistanceYards }
+
+// Preference is how a user reads and writes body measurements and pool distances.
+type Preference struct {
+	System   string
+	Distance string
+}
+
+func (p Preference) WeightUnit() string {
+	if p.System == SystemImperial {
+		return "lb"
+	}
+	return "kg"
+}
+
+func (p Preference) HeightUnit() string {
+	if p.System == SystemImperial {
+		return "in"
+	}
+	return "cm"
+}
+
+// WeightFromKG converts a stored weight for display, rounded to 0.1.
+func (p Preference) WeightFromKG(kg float64) float64 {
+	if p.System == SystemImperial {
+		kg /= kgPerLb
+	}
+	return Round(kg, 1)
+}
+
+func (p Preference) WeightToKG(v float64) float64 {
+	if p.System == SystemImperial {
+		v *= kgPerLb
+	}
+	return Round(v, 2)
+}
+
+// HeightFromCM converts a stored height for display, rounded to 0.1.
+func (p Preference) HeightFromCM(cm float64) float64 {
+	if p.System == SystemImperial {
+		cm /= cmPerInch
+	}
+	return Round(cm, 1)
+}
+
+func (p Preference) HeightToCM(v float64) float64 {
+	if p.System == SystemImperial {
+		v *= cmPerInch
+	}
+	return Round(v, 2)
+}
+
+// DistanceFromMeters converts a stored distance for display, rounded to 0.1.
+func (p Preference) DistanceFromMeters(m float64) float64 {
+	if p.Distance == DistanceYards {
+		m /= metersPerYd
+	}
+	return Round(m, 1)
+}
+
+func (p Preference) DistanceToMeters(v float64) float64 {
+	if p.Distance == DistanceYards {
+		v *= metersPerYd
+	}
+	return Round(v, 2)
+}
+
+func Round(v float64, places int) float64 {
+	pow := math.Pow10(places)
+	return math.Round(v*pow) / pow
+}
+
+// PaceFromSecPer100m converts a stored pace to seconds per 100 of the distance unit, rounded to 0.1.
+func (p Preference) PaceFromSecPer100m(sec float64) float64 {
+	if p.Distance == DistanceYards {
+		sec *= metersPerYd
+	}
+	return Round(sec, 1)
+}
+
+func (p Preference) PaceToSecPer100m(v float64) float64 {
+	if p.Distance == DistanceYards {
+		v /= metersPerYd
+	}
+	return Round(v, 2)
+}
@@ -0,0 +1,24 @@
+package validator
+
+import (
+	"haphap/swimo-api/pkg/dates"
+	"time"
+)
+
+// BirthDate returns an error message, or "" when s is a plausible birth date.
+func BirthDate(s string) string {
+	t, err := dates.Parse(s)
+	if err != nil {
+		return "Birth date must be formatted as YYYY-MM-DD"
+	}
+
+	now := time.Now()
+	if t.After(now) {
+		return "Birth date cannot be in the future"
+	}
+	if dates.Age(t, now) > 120 {
+		return "Birth date is too far in the past"
+	}
+
+	return ""
+}